@@ -0,0 +1,42 @@
+// Command cbs-sim serves the in-memory core banking system simulator over HTTP,
+// with the faults of the configuration and the port of CBS.SimulatorPort.
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/cbssim"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	pkglog "go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+)
+
+func main() {
+	c := config.Load()
+	pkglog.Configure(c.App.Env)
+
+	srv := cbssim.NewServer(cbssim.New(c))
+	go func() {
+		err := srv.Start(":" + c.CBS.SimulatorPort)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Panic().Err(err).Msg("Failed to start simulator")
+		}
+	}()
+
+	s := make(chan os.Signal, 1)
+	signal.Notify(s, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	<-s
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to shut down simulator")
+	}
+}
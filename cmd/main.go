@@ -10,6 +10,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/server"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/worker"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/db/postgres"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
@@ -39,6 +40,8 @@ func main() {
 	defer cancel()
 	// run http server
 	go a.http.Run()
+	// run background jobs
+	go a.worker.Run()
 
 	// wait for termination syscalls and doing cleanup operations after received it
 	wait := gracefulShutdown(ctx, 3*time.Second, map[string]operation{
@@ -51,22 +54,27 @@ func main() {
 		"http-server": func(ctx context.Context) error {
 			return a.http.Shutdown(ctx)
 		},
+		"worker": func(ctx context.Context) error {
+			return a.worker.Shutdown(ctx)
+		},
 	})
 
 	<-wait
 }
 
 type krudApp struct {
-	http *server.HTTPServer
-	db   *gorm.DB
-	rds  *redis.Client
+	http   *server.HTTPServer
+	worker *worker.Worker
+	db     *gorm.DB
+	rds    *redis.Client
 }
 
-func newKrudApp(http *server.HTTPServer, db *gorm.DB, rds *redis.Client) *krudApp {
+func newKrudApp(http *server.HTTPServer, worker *worker.Worker, db *gorm.DB, rds *redis.Client) *krudApp {
 	return &krudApp{
-		http: http,
-		db:   db,
-		rds:  rds,
+		http:   http,
+		worker: worker,
+		db:     db,
+		rds:    rds,
 	}
 }
 
@@ -9,16 +9,29 @@ package main
 import (
 	"github.com/labstack/echo/v4"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/api"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/broker"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/cbssim"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/handler"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/server"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/resilience"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/service"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/repo"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/worker"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/db/postgres"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/db/redis"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/httpclient"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/account"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/authentication"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/bulktransfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/reversal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/savingsgoal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/standingorder"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/system"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/tapmoney"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transaction"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
@@ -30,27 +43,65 @@ import (
 func initKrudApp(cfg *config.Configs) *krudApp {
 	echoEcho := echo.New()
 	validator := validation.New()
-	cbsStatusAPI := api.NewCBSStatusAPI()
 	db := postgres.New(cfg)
 	transactionRepo := repo.NewTransactionRepo(db)
 	client := httpclient.New()
-	paymentGateway := api.NewPaymentGateway(cfg, client)
-	cbsAccountAPI := api.NewCBSAccountAPI()
-	usecase := tapmoney.NewUsecase(cbsStatusAPI, transactionRepo, paymentGateway, cbsAccountAPI)
+	registry := resilience.NewRegistry(cfg)
+	paymentService := api.NewPaymentService(cfg, client, registry)
+	simulator := cbssim.New(cfg)
+	repository := api.NewAccountRepository(cfg, simulator, registry)
+	usecase := tapmoney.NewUsecase(cfg, transactionRepo, paymentService, repository)
 	tapMoneyHandler := handler.NewTapMoneyHandler(validator, usecase)
-	cbsTransferAPI := api.NewCBSTransferAPI()
-	transferUsecase := transfer.NewUsecase(cbsStatusAPI, transactionRepo, cbsAccountAPI, cbsTransferAPI)
+	cbsService := api.NewCBSService(cfg, simulator, registry)
+	beneficiaryRepo := repo.NewBeneficiaryRepo(db)
+	transferService := api.NewTransferService(cfg, simulator, registry)
+	biFastTransferAPI := api.NewBIFastTransferAPI()
+	sknTransferAPI := api.NewSKNTransferAPI()
+	rtgsTransferAPI := api.NewRTGSTransferAPI()
+	standInRepo := repo.NewStandInRepo(db)
+	notificationAPI := api.NewNotificationAPI()
+	unitOfWork := repo.NewUnitOfWork(db)
+	transferUsecase := transfer.NewUsecase(cfg, cbsService, transactionRepo, repository, beneficiaryRepo, transferService, biFastTransferAPI, sknTransferAPI, rtgsTransferAPI, standInRepo, notificationAPI, unitOfWork)
 	transferHandler := handler.NewTransferHandler(validator, transferUsecase)
 	redisClient := redis.New(cfg)
 	userRepo := repo.NewUserRepo(cfg, db, redisClient)
 	authService := service.NewAuthService(cfg)
 	authenticationUsecase := authentication.NewUsecase(userRepo, authService)
 	authenticationHandler := handler.NewAuthenticationHandler(validator, authenticationUsecase)
-	userUsecase := user.NewUsecase(userRepo, authService, cbsAccountAPI)
+	userUsecase := user.NewUsecase(userRepo, authService, repository)
 	userHandler := handler.NewUserHandler(validator, userUsecase)
-	transactionUsecase := transaction.NewUsecase(transactionRepo)
+	transactionUsecase := transaction.NewUsecase(cfg, transactionRepo, repository)
 	transactionHandler := handler.NewTransactionHandler(validator, transactionUsecase)
-	httpServer := server.NewHTTP(cfg, echoEcho, tapMoneyHandler, transferHandler, authenticationHandler, userHandler, transactionHandler)
-	mainKrudApp := newKrudApp(httpServer, db, redisClient)
+	standingOrderRepo := repo.NewStandingOrderRepo(db)
+	standingorderUsecase := standingorder.NewUsecase(cfg, cbsService, standingOrderRepo, unitOfWork, notificationAPI, transferUsecase)
+	standingOrderHandler := handler.NewStandingOrderHandler(validator, standingorderUsecase)
+	bulkTransferRepo := repo.NewBulkTransferRepo(db)
+	bulktransferUsecase := bulktransfer.NewUsecase(cfg, bulkTransferRepo, transactionRepo, repository, transferUsecase)
+	bulkTransferHandler := handler.NewBulkTransferHandler(validator, bulktransferUsecase)
+	otpRepo := repo.NewOTPRepo(redisClient)
+	beneficiaryUsecase := beneficiary.NewUsecase(cfg, beneficiaryRepo, otpRepo, repository, biFastTransferAPI, authService, notificationAPI)
+	beneficiaryHandler := handler.NewBeneficiaryHandler(validator, beneficiaryUsecase)
+	auditRepo := repo.NewAuditRepo(db)
+	reversalUsecase := reversal.NewUsecase(cfg, transactionRepo, auditRepo, unitOfWork, transferService, paymentService)
+	reversalHandler := handler.NewReversalHandler(validator, reversalUsecase)
+	accountUsecase := account.NewUsecase(cfg, repository, userRepo, transactionRepo, auditRepo, transferUsecase)
+	accountHandler := handler.NewAccountHandler(validator, accountUsecase)
+	savingsGoalRepo := repo.NewSavingsGoalRepo(db)
+	savingsgoalUsecase := savingsgoal.NewUsecase(cfg, cbsService, savingsGoalRepo, transactionRepo, repository, userRepo, unitOfWork, notificationAPI, transferUsecase)
+	savingsGoalHandler := handler.NewSavingsGoalHandler(validator, savingsgoalUsecase)
+	depositRepo := repo.NewDepositRepo(db)
+	depositService := api.NewDepositService(cfg, simulator)
+	depositUsecase := deposit.NewUsecase(cfg, cbsService, depositRepo, depositService, transactionRepo, repository, userRepo, transferService, transferUsecase)
+	depositHandler := handler.NewDepositHandler(validator, depositUsecase)
+	systemUsecase := system.NewUsecase(cfg, cbsService)
+	systemHandler := handler.NewSystemHandler(systemUsecase)
+	healthHandler := handler.NewHealthHandler(registry)
+	nonceRepo := repo.NewNonceRepo(redisClient)
+	httpServer := server.NewHTTP(cfg, echoEcho, tapMoneyHandler, transferHandler, authenticationHandler, userHandler, transactionHandler, standingOrderHandler, bulkTransferHandler, beneficiaryHandler, reversalHandler, accountHandler, savingsGoalHandler, depositHandler, systemHandler, healthHandler, systemUsecase, nonceRepo)
+	outboxRepo := repo.NewOutboxRepo(db)
+	publisher := broker.NewPublisher(cfg, redisClient)
+	outboxUsecase := outbox.NewUsecase(cfg, outboxRepo, publisher, unitOfWork)
+	workerWorker := worker.NewWorker(cfg, standingorderUsecase, transactionUsecase, transferUsecase, outboxUsecase, savingsgoalUsecase, depositUsecase, accountUsecase, bulktransferUsecase, reversalUsecase)
+	mainKrudApp := newKrudApp(httpServer, workerWorker, db, redisClient)
 	return mainKrudApp
 }
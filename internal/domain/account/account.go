@@ -1,15 +1,131 @@
 // Package account contains account domain logic and entities.
 package account
 
+import (
+	"strings"
+	"time"
+)
+
+// visibleDigits is the number of trailing digits left unmasked in an account number.
+const visibleDigits = 4
+
+const (
+	// TypeSavings represents a savings account.
+	TypeSavings = "savings"
+	// TypeCurrent represents a current account.
+	TypeCurrent = "current"
+	// TypePocket represents a sub-account with its own balance under a savings or current account.
+	TypePocket = "pocket"
+	// TypeDeposit represents the account holding the principal of a time deposit.
+	TypeDeposit = "deposit"
+)
+
+const (
+	// StatusActive represents an open account.
+	StatusActive = "active"
+	// StatusDormant represents an account without customer activity for the dormancy period.
+	// It is credited but not debited until it is reactivated.
+	StatusDormant = "dormant"
+	// StatusFrozenDebit represents an account frozen for debits, it is still credited.
+	StatusFrozenDebit = "frozen_debit"
+	// StatusFrozenCredit represents an account frozen for credits, it is still debited.
+	StatusFrozenCredit = "frozen_credit"
+	// StatusClosed represents a closed account.
+	StatusClosed = "closed"
+)
+
 // Account represents a bank account entity.
 type Account struct {
 	CIF           string
 	AccountNumber string
+	ParentAccount string // Account number of the account a pocket belongs to.
 	FullName      string
+	Name          string // Name the customer gave a pocket.
 	Type          string
-	Balance       int64
+	Status        string
+	// Balance is the ledger balance, the sum of the posted entries of the account.
+	Balance int64
+	// AvailableBalance is the ledger balance minus the amounts on hold.
+	AvailableBalance int64
+	OpenedAt         time.Time
+}
+
+// IsPocket checks if the account is a pocket.
+func (acc Account) IsPocket() bool {
+	return acc.Type == TypePocket
+}
+
+// IsDeposit checks if the account holds a time deposit.
+func (acc Account) IsDeposit() bool {
+	return acc.Type == TypeDeposit
+}
+
+// IsClosed checks if the account is closed.
+func (acc Account) IsClosed() bool {
+	return acc.Status == StatusClosed
+}
+
+// CanDebit checks if the status of the account allows debits.
+func (acc Account) CanDebit() bool {
+	switch acc.Status {
+	case StatusDormant, StatusFrozenDebit, StatusClosed:
+		return false
+	default:
+		return true
+	}
+}
+
+// CanCredit checks if the status of the account allows credits.
+func (acc Account) CanCredit() bool {
+	switch acc.Status {
+	case StatusFrozenCredit, StatusClosed:
+		return false
+	default:
+		return true
+	}
+}
+
+// CanChangeStatus checks if the account can move to the status.
+// Closed accounts stay closed, and only savings and current accounts become dormant.
+func (acc Account) CanChangeStatus(status string) bool {
+	if acc.IsClosed() || acc.Status == status {
+		return false
+	}
+	if status == StatusDormant {
+		return acc.Type == TypeSavings || acc.Type == TypeCurrent
+	}
+	return true
+}
+
+// Dormant checks if an active savings or current account had no customer activity since the cutoff.
+// The activity is the later of the opening date and lastActivity,
+// an account without either is never dormant.
+func (acc Account) Dormant(lastActivity, cutoff time.Time) bool {
+	if acc.Status != StatusActive || !acc.CanChangeStatus(StatusDormant) {
+		return false
+	}
+	if acc.OpenedAt.After(lastActivity) {
+		lastActivity = acc.OpenedAt
+	}
+	return !lastActivity.IsZero() && lastActivity.Before(cutoff)
+}
+
+// OwnedBy checks if the account belongs to the customer.
+func (acc Account) OwnedBy(cif string) bool {
+	return cif != "" && acc.CIF == cif
+}
+
+// MaskedNumber returns the account number with all but the last digits masked.
+func (acc Account) MaskedNumber() string {
+	n := len(acc.AccountNumber)
+	if n <= visibleDigits {
+		return acc.AccountNumber
+	}
+	return strings.Repeat("*", n-visibleDigits) + acc.AccountNumber[n-visibleDigits:]
 }
 
+// CanTransfer checks if the account can be debited and the available balance covers the amount.
+// Time deposits are only debited at maturity.
 func (acc Account) CanTransfer(amount int64) bool {
-	return acc.Balance >= amount
+	return acc.CanDebit() && !acc.IsDeposit() && acc.AvailableBalance >= amount
 }
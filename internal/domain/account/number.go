@@ -0,0 +1,62 @@
+package account
+
+import "fmt"
+
+const (
+	// NumberPrefix is the bank prefix every account number of the bank starts with.
+	NumberPrefix = "10"
+	// NumberLength is the length of an account number, the check digit included.
+	NumberLength = 10
+)
+
+// sequenceDigits is the number of digits of the sequence between the prefix and the check digit.
+const sequenceDigits = NumberLength - len(NumberPrefix) - 1
+
+// maxSequence is the first sequence that no longer fits in sequenceDigits.
+const maxSequence = 10_000_000
+
+// mod11Weights are the weights of the digits before the check digit, applied from the right.
+var mod11Weights = [...]int{2, 3, 4, 5, 6, 7}
+
+// ValidNumber checks if the account number has the bank prefix, the length and a valid mod-11 check digit.
+func ValidNumber(number string) bool {
+	if len(number) != NumberLength || number[:len(NumberPrefix)] != NumberPrefix {
+		return false
+	}
+	for i := range len(number) {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+	}
+	check, ok := checkDigit(number[:NumberLength-1])
+	return ok && number[NumberLength-1] == check
+}
+
+// NewNumber returns the account number of the sequence, the prefix and the zero-padded sequence
+// followed by the check digit. It returns false when the sequence does not fit
+// or has no mod-11 check digit, such sequences are skipped.
+func NewNumber(sequence int64) (string, bool) {
+	if sequence < 0 || sequence >= maxSequence {
+		return "", false
+	}
+	body := fmt.Sprintf("%s%0*d", NumberPrefix, sequenceDigits, sequence)
+	check, ok := checkDigit(body)
+	if !ok {
+		return "", false
+	}
+	return body + string(check), true
+}
+
+// checkDigit returns the mod-11 check digit of the digits, a remainder of 1 has no check digit.
+func checkDigit(digits string) (byte, bool) {
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		sum += d * mod11Weights[i%len(mod11Weights)]
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 0, false
+	}
+	return byte('0' + check), true
+}
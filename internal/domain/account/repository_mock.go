@@ -21,6 +21,100 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// CaptureHold provides a mock function with given fields: ctx, reference
+func (_m *MockRepository) CaptureHold(ctx context.Context, reference string) error {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CaptureHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CaptureHold'
+type MockRepository_CaptureHold_Call struct {
+	*mock.Call
+}
+
+// CaptureHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockRepository_Expecter) CaptureHold(ctx interface{}, reference interface{}) *MockRepository_CaptureHold_Call {
+	return &MockRepository_CaptureHold_Call{Call: _e.mock.On("CaptureHold", ctx, reference)}
+}
+
+func (_c *MockRepository_CaptureHold_Call) Run(run func(ctx context.Context, reference string)) *MockRepository_CaptureHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CaptureHold_Call) Return(_a0 error) *MockRepository_CaptureHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CaptureHold_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_CaptureHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Close provides a mock function with given fields: ctx, accountNumber
+func (_m *MockRepository) Close(ctx context.Context, accountNumber string) error {
+	ret := _m.Called(ctx, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockRepository_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+func (_e *MockRepository_Expecter) Close(ctx interface{}, accountNumber interface{}) *MockRepository_Close_Call {
+	return &MockRepository_Close_Call{Call: _e.mock.On("Close", ctx, accountNumber)}
+}
+
+func (_c *MockRepository_Close_Call) Run(run func(ctx context.Context, accountNumber string)) *MockRepository_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Close_Call) Return(_a0 error) *MockRepository_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Close_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, username
 func (_m *MockRepository) Create(ctx context.Context, username string) (Account, error) {
 	ret := _m.Called(ctx, username)
@@ -135,6 +229,314 @@ func (_c *MockRepository_Get_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// ListByCIF provides a mock function with given fields: ctx, cif
+func (_m *MockRepository) ListByCIF(ctx context.Context, cif string) ([]Account, error) {
+	ret := _m.Called(ctx, cif)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByCIF")
+	}
+
+	var r0 []Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Account, error)); ok {
+		return rf(ctx, cif)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Account); ok {
+		r0 = rf(ctx, cif)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, cif)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListByCIF_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByCIF'
+type MockRepository_ListByCIF_Call struct {
+	*mock.Call
+}
+
+// ListByCIF is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cif string
+func (_e *MockRepository_Expecter) ListByCIF(ctx interface{}, cif interface{}) *MockRepository_ListByCIF_Call {
+	return &MockRepository_ListByCIF_Call{Call: _e.mock.On("ListByCIF", ctx, cif)}
+}
+
+func (_c *MockRepository_ListByCIF_Call) Run(run func(ctx context.Context, cif string)) *MockRepository_ListByCIF_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListByCIF_Call) Return(_a0 []Account, _a1 error) *MockRepository_ListByCIF_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListByCIF_Call) RunAndReturn(run func(context.Context, string) ([]Account, error)) *MockRepository_ListByCIF_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: ctx, acc
+func (_m *MockRepository) Open(ctx context.Context, acc Account) (Account, error) {
+	ret := _m.Called(ctx, acc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Account) (Account, error)); ok {
+		return rf(ctx, acc)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Account) Account); ok {
+		r0 = rf(ctx, acc)
+	} else {
+		r0 = ret.Get(0).(Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Account) error); ok {
+		r1 = rf(ctx, acc)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockRepository_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - acc Account
+func (_e *MockRepository_Expecter) Open(ctx interface{}, acc interface{}) *MockRepository_Open_Call {
+	return &MockRepository_Open_Call{Call: _e.mock.On("Open", ctx, acc)}
+}
+
+func (_c *MockRepository_Open_Call) Run(run func(ctx context.Context, acc Account)) *MockRepository_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Account))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Open_Call) Return(_a0 Account, _a1 error) *MockRepository_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Open_Call) RunAndReturn(run func(context.Context, Account) (Account, error)) *MockRepository_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlaceHold provides a mock function with given fields: ctx, accountNumber, reference, amount
+func (_m *MockRepository) PlaceHold(ctx context.Context, accountNumber string, reference string, amount int64) error {
+	ret := _m.Called(ctx, accountNumber, reference, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlaceHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
+		r0 = rf(ctx, accountNumber, reference, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_PlaceHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlaceHold'
+type MockRepository_PlaceHold_Call struct {
+	*mock.Call
+}
+
+// PlaceHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+//   - reference string
+//   - amount int64
+func (_e *MockRepository_Expecter) PlaceHold(ctx interface{}, accountNumber interface{}, reference interface{}, amount interface{}) *MockRepository_PlaceHold_Call {
+	return &MockRepository_PlaceHold_Call{Call: _e.mock.On("PlaceHold", ctx, accountNumber, reference, amount)}
+}
+
+func (_c *MockRepository_PlaceHold_Call) Run(run func(ctx context.Context, accountNumber string, reference string, amount int64)) *MockRepository_PlaceHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_PlaceHold_Call) Return(_a0 error) *MockRepository_PlaceHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_PlaceHold_Call) RunAndReturn(run func(context.Context, string, string, int64) error) *MockRepository_PlaceHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseHold provides a mock function with given fields: ctx, reference
+func (_m *MockRepository) ReleaseHold(ctx context.Context, reference string) error {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_ReleaseHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseHold'
+type MockRepository_ReleaseHold_Call struct {
+	*mock.Call
+}
+
+// ReleaseHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockRepository_Expecter) ReleaseHold(ctx interface{}, reference interface{}) *MockRepository_ReleaseHold_Call {
+	return &MockRepository_ReleaseHold_Call{Call: _e.mock.On("ReleaseHold", ctx, reference)}
+}
+
+func (_c *MockRepository_ReleaseHold_Call) Run(run func(ctx context.Context, reference string)) *MockRepository_ReleaseHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ReleaseHold_Call) Return(_a0 error) *MockRepository_ReleaseHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_ReleaseHold_Call) RunAndReturn(run func(context.Context, string) error) *MockRepository_ReleaseHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function with given fields: ctx, accountNumber, name
+func (_m *MockRepository) Rename(ctx context.Context, accountNumber string, name string) error {
+	ret := _m.Called(ctx, accountNumber, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accountNumber, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockRepository_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+//   - name string
+func (_e *MockRepository_Expecter) Rename(ctx interface{}, accountNumber interface{}, name interface{}) *MockRepository_Rename_Call {
+	return &MockRepository_Rename_Call{Call: _e.mock.On("Rename", ctx, accountNumber, name)}
+}
+
+func (_c *MockRepository_Rename_Call) Run(run func(ctx context.Context, accountNumber string, name string)) *MockRepository_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Rename_Call) Return(_a0 error) *MockRepository_Rename_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Rename_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStatus provides a mock function with given fields: ctx, accountNumber, status
+func (_m *MockRepository) SetStatus(ctx context.Context, accountNumber string, status string) error {
+	ret := _m.Called(ctx, accountNumber, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accountNumber, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStatus'
+type MockRepository_SetStatus_Call struct {
+	*mock.Call
+}
+
+// SetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+//   - status string
+func (_e *MockRepository_Expecter) SetStatus(ctx interface{}, accountNumber interface{}, status interface{}) *MockRepository_SetStatus_Call {
+	return &MockRepository_SetStatus_Call{Call: _e.mock.On("SetStatus", ctx, accountNumber, status)}
+}
+
+func (_c *MockRepository_SetStatus_Call) Run(run func(ctx context.Context, accountNumber string, status string)) *MockRepository_SetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetStatus_Call) Return(_a0 error) *MockRepository_SetStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetStatus_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_SetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
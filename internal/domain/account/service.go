@@ -1,13 +1,62 @@
 package account
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned when no account has the account number.
+	ErrNotFound = errors.New("account not found")
+
+	// ErrInsufficientBalance is returned when the available balance does not cover a hold.
+	ErrInsufficientBalance = errors.New("insufficient available balance")
+
+	// ErrHoldNotFound is returned when no hold is placed under a reference.
+	ErrHoldNotFound = errors.New("hold not found")
+
+	// ErrBalanceNotZero is returned when an account with money or holds is closed.
+	ErrBalanceNotZero = errors.New("account balance is not zero")
+
+	// ErrRestricted is returned when the status of an account does not allow the debit or credit.
+	ErrRestricted = errors.New("account is restricted")
+)
 
 // Repository defines a contract for account data access and persistence operations.
 // Repository can be an API, database, or any other service that provides account data.
 type Repository interface {
-	// Get retrieves an account from the repository by its account number.
+	// Get retrieves an account from the repository by its account number,
+	// with the CIF of its owner as ListByCIF returns it.
 	Get(ctx context.Context, accountNumber string) (Account, error)
 
-	// Create creates a new account in the repository.
+	// ListByCIF retrieves the accounts of a customer by their CIF.
+	ListByCIF(ctx context.Context, cif string) ([]Account, error)
+
+	// Create creates a new customer in the repository and returns the CIF of the customer.
 	Create(ctx context.Context, username string) (Account, error)
+
+	// Open opens an account of the type for the customer with the CIF of acc,
+	// pockets are opened under the parent account with the name of acc.
+	Open(ctx context.Context, acc Account) (Account, error)
+
+	// Rename changes the name of a pocket.
+	Rename(ctx context.Context, accountNumber, name string) error
+
+	// Close closes an account, it returns ErrBalanceNotZero while the account has money or holds.
+	Close(ctx context.Context, accountNumber string) error
+
+	// SetStatus changes the status of an open account, accounts are closed with Close.
+	SetStatus(ctx context.Context, accountNumber, status string) error
+
+	// PlaceHold reserves the amount on the account under the reference,
+	// lowering the available balance but not the ledger balance.
+	// Placing a hold twice under the same reference is a no-op.
+	// It returns ErrRestricted when the account cannot be debited.
+	PlaceHold(ctx context.Context, accountNumber, reference string, amount int64) error
+
+	// CaptureHold settles the hold under the reference once the held amount is debited.
+	CaptureHold(ctx context.Context, reference string) error
+
+	// ReleaseHold drops the hold under the reference and returns the amount to the available balance.
+	ReleaseHold(ctx context.Context, reference string) error
 }
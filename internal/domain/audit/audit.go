@@ -0,0 +1,30 @@
+// Package audit contains the audit trail of operations performed by bank staff and background jobs.
+package audit
+
+import "time"
+
+const (
+	// ActionReversalCompleted is recorded when a transaction was reversed.
+	ActionReversalCompleted = "reversal.completed"
+	// ActionReversalFailed is recorded when the reversal of a transaction was refused.
+	ActionReversalFailed = "reversal.failed"
+	// ActionAccountStatusChanged is recorded when the status of an account was changed.
+	ActionAccountStatusChanged = "account.status_changed"
+)
+
+const (
+	// ResourceTransaction is the resource type of transaction audit entries.
+	ResourceTransaction = "transaction"
+	// ResourceAccount is the resource type of account audit entries, identified by the account number.
+	ResourceAccount = "account"
+)
+
+// Entry represents an operation performed by an actor on a resource.
+type Entry struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceUUID string
+	Detail       string
+	CreatedAt    time.Time
+}
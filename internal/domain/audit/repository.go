@@ -0,0 +1,9 @@
+package audit
+
+import "context"
+
+// Repository defines a contract for data access and persistence operations.
+type Repository interface {
+	// Create records an audit entry.
+	Create(ctx context.Context, e Entry) error
+}
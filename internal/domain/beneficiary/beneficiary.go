@@ -0,0 +1,22 @@
+// Package beneficiary contains the address book domain entities.
+package beneficiary
+
+import "time"
+
+// Beneficiary represents a saved transfer destination of a user.
+type Beneficiary struct {
+	UUID          string
+	Username      string
+	BankCode      string
+	AccountNumber string
+	Nickname      string
+	// VerifiedName is the account holder name returned by the account inquiry.
+	VerifiedName string
+	Favourite    bool
+	CreatedAt    time.Time
+}
+
+// OwnedBy checks if the beneficiary belongs to the user.
+func (b Beneficiary) OwnedBy(username string) bool {
+	return b.Username == username
+}
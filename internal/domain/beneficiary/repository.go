@@ -0,0 +1,32 @@
+package beneficiary
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned when a beneficiary is not found.
+	ErrNotFound = errors.New("beneficiary not found")
+
+	// ErrDuplicate is returned when the user already saved the account.
+	ErrDuplicate = errors.New("duplicate beneficiary")
+)
+
+// Repository defines a contract for beneficiary persistence operations.
+type Repository interface {
+	// Create creates a beneficiary.
+	Create(ctx context.Context, b Beneficiary) error
+
+	// GetByUUID retrieves a beneficiary by its UUID.
+	GetByUUID(ctx context.Context, uuid string) (Beneficiary, error)
+
+	// GetByUsername retrieves the beneficiaries of a user, favourites first.
+	GetByUsername(ctx context.Context, username string) ([]Beneficiary, error)
+
+	// Update updates the nickname and favourite flag of a beneficiary.
+	Update(ctx context.Context, b Beneficiary) error
+
+	// Delete deletes a beneficiary.
+	Delete(ctx context.Context, uuid string) error
+}
@@ -0,0 +1,24 @@
+// Package bulktransfer contains bulk transfer domain entities.
+package bulktransfer
+
+import "time"
+
+const (
+	// StatusProcessing represents a batch whose rows are still being transferred.
+	StatusProcessing = "processing"
+	// StatusCompleted represents a batch whose rows are all processed.
+	StatusCompleted = "completed"
+)
+
+// Batch represents a group of transfers from one source account,
+// with one transaction per row linked by the batch UUID.
+type Batch struct {
+	UUID          string
+	Username      string
+	SourceAccount string
+	TotalRows     int
+	TotalAmount   int64
+	Status        string
+	CreatedAt     time.Time
+	CompletedAt   time.Time
+}
@@ -0,0 +1,24 @@
+package bulktransfer
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a batch is not found.
+var ErrNotFound = errors.New("batch not found")
+
+// Repository defines a contract for batch persistence operations.
+type Repository interface {
+	// Create creates a batch.
+	Create(ctx context.Context, batch Batch) error
+
+	// GetByUUID retrieves a batch by its UUID.
+	GetByUUID(ctx context.Context, uuid string) (Batch, error)
+
+	// GetByStatus retrieves the batches with the status, oldest first.
+	GetByStatus(ctx context.Context, status string) ([]Batch, error)
+
+	// Update updates an existing batch.
+	Update(ctx context.Context, batch Batch) error
+}
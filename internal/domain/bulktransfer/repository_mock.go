@@ -0,0 +1,246 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package bulktransfer
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, batch
+func (_m *MockRepository) Create(ctx context.Context, batch Batch) error {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Batch) error); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch Batch
+func (_e *MockRepository_Expecter) Create(ctx interface{}, batch interface{}) *MockRepository_Create_Call {
+	return &MockRepository_Create_Call{Call: _e.mock.On("Create", ctx, batch)}
+}
+
+func (_c *MockRepository_Create_Call) Run(run func(ctx context.Context, batch Batch)) *MockRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Batch))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) Return(_a0 error) *MockRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) RunAndReturn(run func(context.Context, Batch) error) *MockRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByStatus provides a mock function with given fields: ctx, status
+func (_m *MockRepository) GetByStatus(ctx context.Context, status string) ([]Batch, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByStatus")
+	}
+
+	var r0 []Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Batch, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Batch); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByStatus'
+type MockRepository_GetByStatus_Call struct {
+	*mock.Call
+}
+
+// GetByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+func (_e *MockRepository_Expecter) GetByStatus(ctx interface{}, status interface{}) *MockRepository_GetByStatus_Call {
+	return &MockRepository_GetByStatus_Call{Call: _e.mock.On("GetByStatus", ctx, status)}
+}
+
+func (_c *MockRepository_GetByStatus_Call) Run(run func(ctx context.Context, status string)) *MockRepository_GetByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByStatus_Call) Return(_a0 []Batch, _a1 error) *MockRepository_GetByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByStatus_Call) RunAndReturn(run func(context.Context, string) ([]Batch, error)) *MockRepository_GetByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUUID provides a mock function with given fields: ctx, uuid
+func (_m *MockRepository) GetByUUID(ctx context.Context, uuid string) (Batch, error) {
+	ret := _m.Called(ctx, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUUID")
+	}
+
+	var r0 Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Batch, error)); ok {
+		return rf(ctx, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Batch); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		r0 = ret.Get(0).(Batch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByUUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUUID'
+type MockRepository_GetByUUID_Call struct {
+	*mock.Call
+}
+
+// GetByUUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+func (_e *MockRepository_Expecter) GetByUUID(ctx interface{}, uuid interface{}) *MockRepository_GetByUUID_Call {
+	return &MockRepository_GetByUUID_Call{Call: _e.mock.On("GetByUUID", ctx, uuid)}
+}
+
+func (_c *MockRepository_GetByUUID_Call) Run(run func(ctx context.Context, uuid string)) *MockRepository_GetByUUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) Return(_a0 Batch, _a1 error) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) RunAndReturn(run func(context.Context, string) (Batch, error)) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, batch
+func (_m *MockRepository) Update(ctx context.Context, batch Batch) error {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Batch) error); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch Batch
+func (_e *MockRepository_Expecter) Update(ctx interface{}, batch interface{}) *MockRepository_Update_Call {
+	return &MockRepository_Update_Call{Call: _e.mock.On("Update", ctx, batch)}
+}
+
+func (_c *MockRepository_Update_Call) Run(run func(ctx context.Context, batch Batch)) *MockRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Batch))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) Return(_a0 error) *MockRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) RunAndReturn(run func(context.Context, Batch) error) *MockRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
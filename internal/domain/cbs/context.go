@@ -0,0 +1,18 @@
+package cbs
+
+import "context"
+
+type ContextKeyType string
+
+// ContextKey represents the key for storing the status the request was checked against in the context.
+const ContextKey ContextKeyType = "cbs_status"
+
+// GetStatus returns the status stored in the context,
+// or asks the service when the context has none, e.g. in background jobs.
+func GetStatus(ctx context.Context, svc Service) (Status, error) {
+	status, ok := ctx.Value(ContextKey).(Status)
+	if ok {
+		return status, nil
+	}
+	return svc.GetStatus(ctx)
+}
@@ -0,0 +1,35 @@
+package cbs
+
+import "time"
+
+// minRetryAfter is the delay estimated for an end of day that overran its window.
+const minRetryAfter = time.Minute
+
+// Schedule is the daily end of day window of the core banking system.
+type Schedule struct {
+	// Start is the time of day the end of day starts, as the offset from midnight.
+	Start time.Duration
+	// Duration is how long the end of day usually takes.
+	Duration time.Duration
+}
+
+// Window returns the end of day window in progress at t, or the next one.
+func (s Schedule) Window(t time.Time) (start, end time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	// The window of yesterday may run past midnight.
+	start = day.AddDate(0, 0, -1).Add(s.Start)
+	for !start.Add(s.Duration).After(t) {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start, start.Add(s.Duration)
+}
+
+// RetryAfter estimates how long the end of day running at t still takes.
+// An end of day running outside its window is estimated at a minute.
+func (s Schedule) RetryAfter(t time.Time) time.Duration {
+	start, end := s.Window(t)
+	if t.Before(start) {
+		return minRetryAfter
+	}
+	return max(end.Sub(t), minRetryAfter)
+}
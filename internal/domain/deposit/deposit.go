@@ -0,0 +1,113 @@
+// Package deposit contains time deposit domain logic and entities.
+package deposit
+
+import (
+	"math/big"
+	"time"
+)
+
+const (
+	// DaysInYear is the day-count basis, interest accrues on Actual/365 Fixed.
+	DaysInYear = 365
+	// bpsPerUnit is the number of basis points in a rate of 1.
+	bpsPerUnit = 10000
+)
+
+const (
+	// InstructionRollover places the principal and the net interest again for the same term at maturity.
+	InstructionRollover = "rollover"
+	// InstructionCredit credits the principal and the net interest back to the source account at maturity.
+	InstructionCredit = "credit"
+)
+
+const (
+	// StatusPending represents a deposit whose funding transfer is not settled yet.
+	StatusPending = "pending"
+	// StatusActive represents a placed deposit waiting for its maturity date.
+	StatusActive = "active"
+	// StatusMatured represents a deposit paid back to the source account.
+	StatusMatured = "matured"
+	// StatusFailed represents a deposit whose funding transfer failed.
+	StatusFailed = "failed"
+)
+
+// Product represents the annual interest rate of a deposit term.
+type Product struct {
+	TermMonths int
+	// RateBps is the annual rate in basis points, 350 is 3.50%.
+	RateBps int64
+}
+
+// Deposit represents a time deposit entity. The principal is held on a deposit account
+// of the customer from the start date until the maturity date, both core banking system dates.
+type Deposit struct {
+	UUID           string
+	Username       string
+	SourceAccount  string
+	DepositAccount string
+	Principal      int64
+	TermMonths     int
+	RateBps        int64
+	StartDate      time.Time
+	MaturityDate   time.Time
+	Instruction    string
+	Status         string
+	// FundingTransactionUUID is the transfer of the principal from the source account.
+	FundingTransactionUUID string
+	// Rollovers is the number of times the deposit was placed again at maturity.
+	Rollovers int
+	// GrossInterest, Tax and NetInterest are the interest of the last maturity.
+	GrossInterest int64
+	Tax           int64
+	NetInterest   int64
+	CreatedAt     time.Time
+}
+
+// IsActive checks if the deposit is placed and waiting for its maturity date.
+func (d *Deposit) IsActive() bool {
+	return d.Status == StatusActive
+}
+
+// Due checks if the deposit matures on or before the core banking system date.
+func (d *Deposit) Due(systemDate time.Time) bool {
+	return d.IsActive() && !d.MaturityDate.After(systemDate)
+}
+
+// Days returns the actual number of days from the start date to the maturity date.
+func (d *Deposit) Days() int {
+	return int(d.MaturityDate.Sub(d.StartDate).Hours() / 24)
+}
+
+// Accrue calculates the interest of the current term and the tax withheld from it at the tax rate in basis points.
+func (d *Deposit) Accrue(taxRateBps int64) {
+	d.GrossInterest = Interest(d.Principal, d.RateBps, d.Days())
+	d.Tax = d.GrossInterest * taxRateBps / bpsPerUnit
+	d.NetInterest = d.GrossInterest - d.Tax
+}
+
+// Rollover places the principal and the net interest of the term that matured again
+// for the same term at the rate in basis points.
+func (d *Deposit) Rollover(rateBps int64) {
+	d.Principal += d.NetInterest
+	d.RateBps = rateBps
+	d.StartDate = d.MaturityDate
+	d.MaturityDate = MaturityDate(d.StartDate, d.TermMonths)
+	d.Rollovers++
+}
+
+// Interest returns the interest of the principal at the annual rate in basis points for the days,
+// on the Actual/365 Fixed day count, rounded down to the minor unit.
+func Interest(principal, rateBps int64, days int) int64 {
+	interest := new(big.Int).Mul(big.NewInt(principal), big.NewInt(rateBps))
+	interest.Mul(interest, big.NewInt(int64(days)))
+	interest.Quo(interest, big.NewInt(bpsPerUnit*DaysInYear))
+	return interest.Int64()
+}
+
+// MaturityDate returns the date the months after the start date,
+// clamped to the end of shorter months.
+func MaturityDate(start time.Time, months int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(months), 1, 0, 0, 0, 0, start.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(start.Day(), lastDay)-1)
+}
@@ -0,0 +1,26 @@
+package deposit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a deposit is not found.
+var ErrNotFound = errors.New("deposit not found")
+
+// Repository defines a contract for time deposit persistence operations.
+type Repository interface {
+	// Create creates a deposit.
+	Create(ctx context.Context, d Deposit) error
+	// GetByUUID retrieves a deposit by its UUID.
+	GetByUUID(ctx context.Context, uuid string) (Deposit, error)
+	// GetByUsername retrieves the deposits of a user.
+	GetByUsername(ctx context.Context, username string) ([]Deposit, error)
+	// GetDue retrieves the active deposits maturing on or before the date.
+	GetDue(ctx context.Context, date time.Time) ([]Deposit, error)
+	// GetPending retrieves the deposits waiting for their funding transfer.
+	GetPending(ctx context.Context) ([]Deposit, error)
+	// Update updates an existing deposit.
+	Update(ctx context.Context, d Deposit) error
+}
@@ -0,0 +1,364 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package deposit
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, d
+func (_m *MockRepository) Create(ctx context.Context, d Deposit) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Deposit) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d Deposit
+func (_e *MockRepository_Expecter) Create(ctx interface{}, d interface{}) *MockRepository_Create_Call {
+	return &MockRepository_Create_Call{Call: _e.mock.On("Create", ctx, d)}
+}
+
+func (_c *MockRepository_Create_Call) Run(run func(ctx context.Context, d Deposit)) *MockRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Deposit))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) Return(_a0 error) *MockRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) RunAndReturn(run func(context.Context, Deposit) error) *MockRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUUID provides a mock function with given fields: ctx, uuid
+func (_m *MockRepository) GetByUUID(ctx context.Context, uuid string) (Deposit, error) {
+	ret := _m.Called(ctx, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUUID")
+	}
+
+	var r0 Deposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Deposit, error)); ok {
+		return rf(ctx, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Deposit); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		r0 = ret.Get(0).(Deposit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByUUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUUID'
+type MockRepository_GetByUUID_Call struct {
+	*mock.Call
+}
+
+// GetByUUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+func (_e *MockRepository_Expecter) GetByUUID(ctx interface{}, uuid interface{}) *MockRepository_GetByUUID_Call {
+	return &MockRepository_GetByUUID_Call{Call: _e.mock.On("GetByUUID", ctx, uuid)}
+}
+
+func (_c *MockRepository_GetByUUID_Call) Run(run func(ctx context.Context, uuid string)) *MockRepository_GetByUUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) Return(_a0 Deposit, _a1 error) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) RunAndReturn(run func(context.Context, string) (Deposit, error)) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *MockRepository) GetByUsername(ctx context.Context, username string) ([]Deposit, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUsername")
+	}
+
+	var r0 []Deposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Deposit, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Deposit); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Deposit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
+type MockRepository_GetByUsername_Call struct {
+	*mock.Call
+}
+
+// GetByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockRepository_Expecter) GetByUsername(ctx interface{}, username interface{}) *MockRepository_GetByUsername_Call {
+	return &MockRepository_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
+}
+
+func (_c *MockRepository_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *MockRepository_GetByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByUsername_Call) Return(_a0 []Deposit, _a1 error) *MockRepository_GetByUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByUsername_Call) RunAndReturn(run func(context.Context, string) ([]Deposit, error)) *MockRepository_GetByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDue provides a mock function with given fields: ctx, date
+func (_m *MockRepository) GetDue(ctx context.Context, date time.Time) ([]Deposit, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDue")
+	}
+
+	var r0 []Deposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]Deposit, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []Deposit); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Deposit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDue'
+type MockRepository_GetDue_Call struct {
+	*mock.Call
+}
+
+// GetDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - date time.Time
+func (_e *MockRepository_Expecter) GetDue(ctx interface{}, date interface{}) *MockRepository_GetDue_Call {
+	return &MockRepository_GetDue_Call{Call: _e.mock.On("GetDue", ctx, date)}
+}
+
+func (_c *MockRepository_GetDue_Call) Run(run func(ctx context.Context, date time.Time)) *MockRepository_GetDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetDue_Call) Return(_a0 []Deposit, _a1 error) *MockRepository_GetDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetDue_Call) RunAndReturn(run func(context.Context, time.Time) ([]Deposit, error)) *MockRepository_GetDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPending provides a mock function with given fields: ctx
+func (_m *MockRepository) GetPending(ctx context.Context) ([]Deposit, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPending")
+	}
+
+	var r0 []Deposit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Deposit, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Deposit); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Deposit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPending'
+type MockRepository_GetPending_Call struct {
+	*mock.Call
+}
+
+// GetPending is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) GetPending(ctx interface{}) *MockRepository_GetPending_Call {
+	return &MockRepository_GetPending_Call{Call: _e.mock.On("GetPending", ctx)}
+}
+
+func (_c *MockRepository_GetPending_Call) Run(run func(ctx context.Context)) *MockRepository_GetPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetPending_Call) Return(_a0 []Deposit, _a1 error) *MockRepository_GetPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetPending_Call) RunAndReturn(run func(context.Context) ([]Deposit, error)) *MockRepository_GetPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, d
+func (_m *MockRepository) Update(ctx context.Context, d Deposit) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Deposit) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d Deposit
+func (_e *MockRepository_Expecter) Update(ctx interface{}, d interface{}) *MockRepository_Update_Call {
+	return &MockRepository_Update_Call{Call: _e.mock.On("Update", ctx, d)}
+}
+
+func (_c *MockRepository_Update_Call) Run(run func(ctx context.Context, d Deposit)) *MockRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Deposit))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) Return(_a0 error) *MockRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) RunAndReturn(run func(context.Context, Deposit) error) *MockRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,11 @@
+package deposit
+
+import "context"
+
+// Service defines the time deposit operations of the core banking system.
+type Service interface {
+	// CreditInterest posts the gross interest from the interest expense ledger to the account
+	// and withholds the tax to the tax payable ledger, the account is credited with gross minus tax.
+	// Posting the same reference again has no effect.
+	CreditInterest(ctx context.Context, accountNumber string, gross, tax int64, reference string) error
+}
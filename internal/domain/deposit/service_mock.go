@@ -0,0 +1,86 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package deposit
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// CreditInterest provides a mock function with given fields: ctx, accountNumber, gross, tax, reference
+func (_m *MockService) CreditInterest(ctx context.Context, accountNumber string, gross int64, tax int64, reference string) error {
+	ret := _m.Called(ctx, accountNumber, gross, tax, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreditInterest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, string) error); ok {
+		r0 = rf(ctx, accountNumber, gross, tax, reference)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_CreditInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreditInterest'
+type MockService_CreditInterest_Call struct {
+	*mock.Call
+}
+
+// CreditInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+//   - gross int64
+//   - tax int64
+//   - reference string
+func (_e *MockService_Expecter) CreditInterest(ctx interface{}, accountNumber interface{}, gross interface{}, tax interface{}, reference interface{}) *MockService_CreditInterest_Call {
+	return &MockService_CreditInterest_Call{Call: _e.mock.On("CreditInterest", ctx, accountNumber, gross, tax, reference)}
+}
+
+func (_c *MockService_CreditInterest_Call) Run(run func(ctx context.Context, accountNumber string, gross int64, tax int64, reference string)) *MockService_CreditInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_CreditInterest_Call) Return(_a0 error) *MockService_CreditInterest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_CreditInterest_Call) RunAndReturn(run func(context.Context, string, int64, int64, string) error) *MockService_CreditInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,9 @@
+// Package notification contains customer notification domain entities.
+package notification
+
+// Notification represents a message sent to a user.
+type Notification struct {
+	Username string
+	Title    string
+	Message  string
+}
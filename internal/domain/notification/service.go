@@ -0,0 +1,9 @@
+package notification
+
+import "context"
+
+// Service defines the interface for sending notifications to users.
+type Service interface {
+	// Send delivers the notification to the user.
+	Send(ctx context.Context, n Notification) error
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package notification
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, n
+func (_m *MockService) Send(ctx context.Context, n Notification) error {
+	ret := _m.Called(ctx, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Notification) error); ok {
+		r0 = rf(ctx, n)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockService_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - n Notification
+func (_e *MockService_Expecter) Send(ctx interface{}, n interface{}) *MockService_Send_Call {
+	return &MockService_Send_Call{Call: _e.mock.On("Send", ctx, n)}
+}
+
+func (_c *MockService_Send_Call) Run(run func(ctx context.Context, n Notification)) *MockService_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Notification))
+	})
+	return _c
+}
+
+func (_c *MockService_Send_Call) Return(_a0 error) *MockService_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_Send_Call) RunAndReturn(run func(context.Context, Notification) error) *MockService_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
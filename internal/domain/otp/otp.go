@@ -0,0 +1,22 @@
+// Package otp contains one-time password entities used for step-up verification.
+package otp
+
+import "time"
+
+// PurposeCreateBeneficiary is the OTP purpose for saving a new beneficiary.
+const PurposeCreateBeneficiary = "create_beneficiary"
+
+// OTP represents a one-time password sent to a user for a purpose.
+type OTP struct {
+	Username string
+	Purpose  string
+	// CodeHash is the hashed code, the plain code is only sent to the user.
+	CodeHash  string
+	Attempts  int
+	ExpiresAt time.Time
+}
+
+// Expired checks if the OTP can no longer be used.
+func (o OTP) Expired() bool {
+	return time.Now().After(o.ExpiresAt)
+}
@@ -0,0 +1,22 @@
+package otp
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when no OTP was requested or it has expired.
+var ErrNotFound = errors.New("otp not found")
+
+// Repository defines a contract for OTP persistence operations.
+// An OTP is kept until it is deleted or expires.
+type Repository interface {
+	// Save saves the OTP of a user for its purpose, replacing the previous one.
+	Save(ctx context.Context, o OTP) error
+
+	// Get retrieves the OTP of a user for a purpose.
+	Get(ctx context.Context, username, purpose string) (OTP, error)
+
+	// Delete deletes the OTP of a user for a purpose.
+	Delete(ctx context.Context, username, purpose string) error
+}
@@ -0,0 +1,189 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package otp
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, username, purpose
+func (_m *MockRepository) Delete(ctx context.Context, username string, purpose string) error {
+	ret := _m.Called(ctx, username, purpose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, purpose)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - purpose string
+func (_e *MockRepository_Expecter) Delete(ctx interface{}, username interface{}, purpose interface{}) *MockRepository_Delete_Call {
+	return &MockRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, username, purpose)}
+}
+
+func (_c *MockRepository_Delete_Call) Run(run func(ctx context.Context, username string, purpose string)) *MockRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Delete_Call) Return(_a0 error) *MockRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Delete_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, username, purpose
+func (_m *MockRepository) Get(ctx context.Context, username string, purpose string) (OTP, error) {
+	ret := _m.Called(ctx, username, purpose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 OTP
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (OTP, error)); ok {
+		return rf(ctx, username, purpose)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) OTP); ok {
+		r0 = rf(ctx, username, purpose)
+	} else {
+		r0 = ret.Get(0).(OTP)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, purpose)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - purpose string
+func (_e *MockRepository_Expecter) Get(ctx interface{}, username interface{}, purpose interface{}) *MockRepository_Get_Call {
+	return &MockRepository_Get_Call{Call: _e.mock.On("Get", ctx, username, purpose)}
+}
+
+func (_c *MockRepository_Get_Call) Run(run func(ctx context.Context, username string, purpose string)) *MockRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) Return(_a0 OTP, _a1 error) *MockRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) RunAndReturn(run func(context.Context, string, string) (OTP, error)) *MockRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, o
+func (_m *MockRepository) Save(ctx context.Context, o OTP) error {
+	ret := _m.Called(ctx, o)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, OTP) error); ok {
+		r0 = rf(ctx, o)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - o OTP
+func (_e *MockRepository_Expecter) Save(ctx interface{}, o interface{}) *MockRepository_Save_Call {
+	return &MockRepository_Save_Call{Call: _e.mock.On("Save", ctx, o)}
+}
+
+func (_c *MockRepository_Save_Call) Run(run func(ctx context.Context, o OTP)) *MockRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(OTP))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Save_Call) Return(_a0 error) *MockRepository_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Save_Call) RunAndReturn(run func(context.Context, OTP) error) *MockRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,27 @@
+// Package outbox contains the entities of the transactional outbox,
+// which records state changes in the same database transaction as the change itself
+// so they can be published to downstream systems.
+package outbox
+
+import "time"
+
+// AggregateTransaction is the aggregate type of transaction events.
+const AggregateTransaction = "transaction"
+
+const (
+	// EventTransactionCreated is recorded when a transaction is created.
+	EventTransactionCreated = "transaction.created"
+	// EventTransactionUpdated is recorded when a transaction is updated.
+	EventTransactionUpdated = "transaction.updated"
+)
+
+// Event represents a state change waiting to be published.
+// Events of the same aggregate are published in ID order.
+type Event struct {
+	ID            int64
+	AggregateType string
+	AggregateUUID string
+	Type          string
+	Payload       []byte
+	CreatedAt     time.Time
+}
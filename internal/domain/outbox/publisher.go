@@ -0,0 +1,10 @@
+package outbox
+
+import "context"
+
+// Publisher is the message broker the outbox events are published to.
+type Publisher interface {
+	// Publish delivers the event to the broker.
+	// The same event may be published more than once.
+	Publish(ctx context.Context, e Event) error
+}
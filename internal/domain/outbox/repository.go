@@ -0,0 +1,17 @@
+package outbox
+
+import "context"
+
+// Repository defines a contract for reading the outbox.
+// Events are written by the repositories of the aggregates.
+type Repository interface {
+	// Lock takes the relay lock until the end of the unit of work in the context,
+	// it returns false when another relay holds it.
+	Lock(ctx context.Context) (bool, error)
+
+	// GetUnpublished retrieves up to limit unpublished events, oldest first.
+	GetUnpublished(ctx context.Context, limit int) ([]Event, error)
+
+	// MarkPublished marks the event as published.
+	MarkPublished(ctx context.Context, id int64) error
+}
@@ -0,0 +1,198 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package outbox
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetUnpublished provides a mock function with given fields: ctx, limit
+func (_m *MockRepository) GetUnpublished(ctx context.Context, limit int) ([]Event, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnpublished")
+	}
+
+	var r0 []Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]Event, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []Event); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetUnpublished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnpublished'
+type MockRepository_GetUnpublished_Call struct {
+	*mock.Call
+}
+
+// GetUnpublished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockRepository_Expecter) GetUnpublished(ctx interface{}, limit interface{}) *MockRepository_GetUnpublished_Call {
+	return &MockRepository_GetUnpublished_Call{Call: _e.mock.On("GetUnpublished", ctx, limit)}
+}
+
+func (_c *MockRepository_GetUnpublished_Call) Run(run func(ctx context.Context, limit int)) *MockRepository_GetUnpublished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetUnpublished_Call) Return(_a0 []Event, _a1 error) *MockRepository_GetUnpublished_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetUnpublished_Call) RunAndReturn(run func(context.Context, int) ([]Event, error)) *MockRepository_GetUnpublished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx
+func (_m *MockRepository) Lock(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockRepository_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) Lock(ctx interface{}) *MockRepository_Lock_Call {
+	return &MockRepository_Lock_Call{Call: _e.mock.On("Lock", ctx)}
+}
+
+func (_c *MockRepository_Lock_Call) Run(run func(ctx context.Context)) *MockRepository_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Lock_Call) Return(_a0 bool, _a1 error) *MockRepository_Lock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Lock_Call) RunAndReturn(run func(context.Context) (bool, error)) *MockRepository_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkPublished provides a mock function with given fields: ctx, id
+func (_m *MockRepository) MarkPublished(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_MarkPublished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkPublished'
+type MockRepository_MarkPublished_Call struct {
+	*mock.Call
+}
+
+// MarkPublished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockRepository_Expecter) MarkPublished(ctx interface{}, id interface{}) *MockRepository_MarkPublished_Call {
+	return &MockRepository_MarkPublished_Call{Call: _e.mock.On("MarkPublished", ctx, id)}
+}
+
+func (_c *MockRepository_MarkPublished_Call) Run(run func(ctx context.Context, id int64)) *MockRepository_MarkPublished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_MarkPublished_Call) Return(_a0 error) *MockRepository_MarkPublished_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_MarkPublished_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_MarkPublished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
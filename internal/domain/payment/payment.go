@@ -22,6 +22,16 @@ type Biller struct {
 	Type string
 }
 
+// Reversal represents the refund of a completed payment to its source account.
+type Reversal struct {
+	PaymentID string
+	Amount    int64
+	// Fee is the part of the payment fee returned with the amount.
+	Fee int64
+	// ReferenceID identifies the reversal, the gateway refunds a payment once per reference.
+	ReferenceID string
+}
+
 // Bill represents a bill in the payment gateway system.
 type Bill struct {
 	BillNumber         string
@@ -31,4 +41,6 @@ type Bill struct {
 	DestinationAccount string
 	Fee                int64
 	FreeFee            bool
+	// ReferenceID identifies the payment, the gateway executes a bill once per reference.
+	ReferenceID string
 }
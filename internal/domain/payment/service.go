@@ -1,6 +1,13 @@
 package payment
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrOutcomeUnknown is returned when a payment may or may not have been executed,
+// e.g. on a timeout or a server error after the request was sent.
+var ErrOutcomeUnknown = errors.New("payment outcome is unknown")
 
 // Service defines the interface for payment operations.
 type Service interface {
@@ -9,4 +16,10 @@ type Service interface {
 
 	// Payment performs a payment operation for a payment.
 	Payment(ctx context.Context, Bill Bill) (Payment, error)
+
+	// CancelInquiry releases the inquiry session of a payment that will not be paid.
+	CancelInquiry(ctx context.Context, paymentID string) error
+
+	// Reverse refunds a completed payment.
+	Reverse(ctx context.Context, rv Reversal) (Payment, error)
 }
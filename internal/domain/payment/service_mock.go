@@ -21,6 +21,53 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// CancelInquiry provides a mock function with given fields: ctx, paymentID
+func (_m *MockService) CancelInquiry(ctx context.Context, paymentID string) error {
+	ret := _m.Called(ctx, paymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelInquiry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, paymentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_CancelInquiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelInquiry'
+type MockService_CancelInquiry_Call struct {
+	*mock.Call
+}
+
+// CancelInquiry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - paymentID string
+func (_e *MockService_Expecter) CancelInquiry(ctx interface{}, paymentID interface{}) *MockService_CancelInquiry_Call {
+	return &MockService_CancelInquiry_Call{Call: _e.mock.On("CancelInquiry", ctx, paymentID)}
+}
+
+func (_c *MockService_CancelInquiry_Call) Run(run func(ctx context.Context, paymentID string)) *MockService_CancelInquiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_CancelInquiry_Call) Return(_a0 error) *MockService_CancelInquiry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_CancelInquiry_Call) RunAndReturn(run func(context.Context, string) error) *MockService_CancelInquiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Inquiry provides a mock function with given fields: ctx, channel, bill
 func (_m *MockService) Inquiry(ctx context.Context, channel Channel, bill Bill) (Payment, error) {
 	ret := _m.Called(ctx, channel, bill)
@@ -136,6 +183,63 @@ func (_c *MockService_Payment_Call) RunAndReturn(run func(context.Context, Bill)
 	return _c
 }
 
+// Reverse provides a mock function with given fields: ctx, rv
+func (_m *MockService) Reverse(ctx context.Context, rv Reversal) (Payment, error) {
+	ret := _m.Called(ctx, rv)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reverse")
+	}
+
+	var r0 Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Reversal) (Payment, error)); ok {
+		return rf(ctx, rv)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Reversal) Payment); ok {
+		r0 = rf(ctx, rv)
+	} else {
+		r0 = ret.Get(0).(Payment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Reversal) error); ok {
+		r1 = rf(ctx, rv)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Reverse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reverse'
+type MockService_Reverse_Call struct {
+	*mock.Call
+}
+
+// Reverse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rv Reversal
+func (_e *MockService_Expecter) Reverse(ctx interface{}, rv interface{}) *MockService_Reverse_Call {
+	return &MockService_Reverse_Call{Call: _e.mock.On("Reverse", ctx, rv)}
+}
+
+func (_c *MockService_Reverse_Call) Run(run func(ctx context.Context, rv Reversal)) *MockService_Reverse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Reversal))
+	})
+	return _c
+}
+
+func (_c *MockService_Reverse_Call) Return(_a0 Payment, _a1 error) *MockService_Reverse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Reverse_Call) RunAndReturn(run func(context.Context, Reversal) (Payment, error)) *MockService_Reverse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockService(t interface {
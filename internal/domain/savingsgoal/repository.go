@@ -0,0 +1,31 @@
+package savingsgoal
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a savings goal is not found.
+var ErrNotFound = errors.New("savings goal not found")
+
+// Repository defines a contract for savings goal persistence operations.
+type Repository interface {
+	// Create creates a savings goal.
+	Create(ctx context.Context, goal Goal) error
+	// GetByUUID retrieves a savings goal by its UUID.
+	GetByUUID(ctx context.Context, uuid string) (Goal, error)
+	// GetByUsername retrieves the savings goals of a user.
+	GetByUsername(ctx context.Context, username string) ([]Goal, error)
+	// ClaimActive retrieves the active savings goals with a round-up sweep
+	// or a scheduled sweep with a next sweep date up to the given time
+	// and claims their current sweep for the lease, so other schedulers skip them until the sweep
+	// is recorded or the lease expires.
+	ClaimActive(ctx context.Context, at time.Time, lease time.Duration) ([]Goal, error)
+	// Update updates an existing savings goal.
+	Update(ctx context.Context, goal Goal) error
+	// CreateSweep records the outcome of a sweep.
+	CreateSweep(ctx context.Context, sweep Sweep) error
+	// GetSweeps retrieves the sweeps of a savings goal, latest first.
+	GetSweeps(ctx context.Context, goalUUID string) ([]Sweep, error)
+}
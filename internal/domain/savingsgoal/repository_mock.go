@@ -0,0 +1,413 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package savingsgoal
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimActive provides a mock function with given fields: ctx, at, lease
+func (_m *MockRepository) ClaimActive(ctx context.Context, at time.Time, lease time.Duration) ([]Goal, error) {
+	ret := _m.Called(ctx, at, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimActive")
+	}
+
+	var r0 []Goal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) ([]Goal, error)); ok {
+		return rf(ctx, at, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) []Goal); ok {
+		r0 = rf(ctx, at, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Goal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, at, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ClaimActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimActive'
+type MockRepository_ClaimActive_Call struct {
+	*mock.Call
+}
+
+// ClaimActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - at time.Time
+//   - lease time.Duration
+func (_e *MockRepository_Expecter) ClaimActive(ctx interface{}, at interface{}, lease interface{}) *MockRepository_ClaimActive_Call {
+	return &MockRepository_ClaimActive_Call{Call: _e.mock.On("ClaimActive", ctx, at, lease)}
+}
+
+func (_c *MockRepository_ClaimActive_Call) Run(run func(ctx context.Context, at time.Time, lease time.Duration)) *MockRepository_ClaimActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ClaimActive_Call) Return(_a0 []Goal, _a1 error) *MockRepository_ClaimActive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ClaimActive_Call) RunAndReturn(run func(context.Context, time.Time, time.Duration) ([]Goal, error)) *MockRepository_ClaimActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, goal
+func (_m *MockRepository) Create(ctx context.Context, goal Goal) error {
+	ret := _m.Called(ctx, goal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Goal) error); ok {
+		r0 = rf(ctx, goal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - goal Goal
+func (_e *MockRepository_Expecter) Create(ctx interface{}, goal interface{}) *MockRepository_Create_Call {
+	return &MockRepository_Create_Call{Call: _e.mock.On("Create", ctx, goal)}
+}
+
+func (_c *MockRepository_Create_Call) Run(run func(ctx context.Context, goal Goal)) *MockRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Goal))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) Return(_a0 error) *MockRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) RunAndReturn(run func(context.Context, Goal) error) *MockRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSweep provides a mock function with given fields: ctx, sweep
+func (_m *MockRepository) CreateSweep(ctx context.Context, sweep Sweep) error {
+	ret := _m.Called(ctx, sweep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSweep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Sweep) error); ok {
+		r0 = rf(ctx, sweep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateSweep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSweep'
+type MockRepository_CreateSweep_Call struct {
+	*mock.Call
+}
+
+// CreateSweep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sweep Sweep
+func (_e *MockRepository_Expecter) CreateSweep(ctx interface{}, sweep interface{}) *MockRepository_CreateSweep_Call {
+	return &MockRepository_CreateSweep_Call{Call: _e.mock.On("CreateSweep", ctx, sweep)}
+}
+
+func (_c *MockRepository_CreateSweep_Call) Run(run func(ctx context.Context, sweep Sweep)) *MockRepository_CreateSweep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Sweep))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateSweep_Call) Return(_a0 error) *MockRepository_CreateSweep_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateSweep_Call) RunAndReturn(run func(context.Context, Sweep) error) *MockRepository_CreateSweep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUUID provides a mock function with given fields: ctx, uuid
+func (_m *MockRepository) GetByUUID(ctx context.Context, uuid string) (Goal, error) {
+	ret := _m.Called(ctx, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUUID")
+	}
+
+	var r0 Goal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Goal, error)); ok {
+		return rf(ctx, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Goal); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		r0 = ret.Get(0).(Goal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByUUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUUID'
+type MockRepository_GetByUUID_Call struct {
+	*mock.Call
+}
+
+// GetByUUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+func (_e *MockRepository_Expecter) GetByUUID(ctx interface{}, uuid interface{}) *MockRepository_GetByUUID_Call {
+	return &MockRepository_GetByUUID_Call{Call: _e.mock.On("GetByUUID", ctx, uuid)}
+}
+
+func (_c *MockRepository_GetByUUID_Call) Run(run func(ctx context.Context, uuid string)) *MockRepository_GetByUUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) Return(_a0 Goal, _a1 error) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) RunAndReturn(run func(context.Context, string) (Goal, error)) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *MockRepository) GetByUsername(ctx context.Context, username string) ([]Goal, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUsername")
+	}
+
+	var r0 []Goal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Goal, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Goal); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Goal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
+type MockRepository_GetByUsername_Call struct {
+	*mock.Call
+}
+
+// GetByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockRepository_Expecter) GetByUsername(ctx interface{}, username interface{}) *MockRepository_GetByUsername_Call {
+	return &MockRepository_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
+}
+
+func (_c *MockRepository_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *MockRepository_GetByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByUsername_Call) Return(_a0 []Goal, _a1 error) *MockRepository_GetByUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByUsername_Call) RunAndReturn(run func(context.Context, string) ([]Goal, error)) *MockRepository_GetByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSweeps provides a mock function with given fields: ctx, goalUUID
+func (_m *MockRepository) GetSweeps(ctx context.Context, goalUUID string) ([]Sweep, error) {
+	ret := _m.Called(ctx, goalUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSweeps")
+	}
+
+	var r0 []Sweep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Sweep, error)); ok {
+		return rf(ctx, goalUUID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Sweep); ok {
+		r0 = rf(ctx, goalUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Sweep)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, goalUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetSweeps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSweeps'
+type MockRepository_GetSweeps_Call struct {
+	*mock.Call
+}
+
+// GetSweeps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - goalUUID string
+func (_e *MockRepository_Expecter) GetSweeps(ctx interface{}, goalUUID interface{}) *MockRepository_GetSweeps_Call {
+	return &MockRepository_GetSweeps_Call{Call: _e.mock.On("GetSweeps", ctx, goalUUID)}
+}
+
+func (_c *MockRepository_GetSweeps_Call) Run(run func(ctx context.Context, goalUUID string)) *MockRepository_GetSweeps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetSweeps_Call) Return(_a0 []Sweep, _a1 error) *MockRepository_GetSweeps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetSweeps_Call) RunAndReturn(run func(context.Context, string) ([]Sweep, error)) *MockRepository_GetSweeps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, goal
+func (_m *MockRepository) Update(ctx context.Context, goal Goal) error {
+	ret := _m.Called(ctx, goal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Goal) error); ok {
+		r0 = rf(ctx, goal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - goal Goal
+func (_e *MockRepository_Expecter) Update(ctx interface{}, goal interface{}) *MockRepository_Update_Call {
+	return &MockRepository_Update_Call{Call: _e.mock.On("Update", ctx, goal)}
+}
+
+func (_c *MockRepository_Update_Call) Run(run func(ctx context.Context, goal Goal)) *MockRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Goal))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) Return(_a0 error) *MockRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) RunAndReturn(run func(context.Context, Goal) error) *MockRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
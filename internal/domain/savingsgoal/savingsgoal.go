@@ -0,0 +1,110 @@
+// Package savingsgoal contains savings goal domain logic and entities.
+package savingsgoal
+
+import "time"
+
+const (
+	// SweepScheduled sweeps a fixed amount into the pocket on a schedule.
+	SweepScheduled = "scheduled"
+	// SweepRoundUp sweeps the round-up of each completed transaction of the source account into the pocket.
+	SweepRoundUp = "roundup"
+)
+
+const (
+	// FrequencyDaily represents a sweep repeated every day.
+	FrequencyDaily = "daily"
+	// FrequencyWeekly represents a sweep repeated every week.
+	FrequencyWeekly = "weekly"
+	// FrequencyMonthly represents a sweep repeated every month.
+	FrequencyMonthly = "monthly"
+)
+
+const (
+	// StatusActive represents a goal that is still being swept into.
+	StatusActive = "active"
+	// StatusReached represents a goal whose pocket holds the target amount.
+	StatusReached = "reached"
+	// StatusCancelled represents a goal cancelled by the user.
+	StatusCancelled = "cancelled"
+)
+
+const (
+	// SweepStatusCompleted represents a sweep that moved the amount into the pocket.
+	SweepStatusCompleted = "completed"
+	// SweepStatusFailed represents a sweep that failed to move the amount.
+	SweepStatusFailed = "failed"
+)
+
+// Goal represents a savings target linked to a pocket.
+type Goal struct {
+	UUID     string
+	Username string
+	Name     string
+	// SourceAccount is the parent account of the pocket, the sweeps are taken from it.
+	SourceAccount string
+	PocketAccount string
+	TargetAmount  int64
+	Deadline      time.Time
+	SweepRule     string
+	// SweepAmount and Frequency are set for scheduled sweeps only.
+	SweepAmount   int64
+	Frequency     string
+	NextSweepDate time.Time
+	// LastSweptAt is the update time of the last transaction rounded up.
+	LastSweptAt time.Time
+	Status      string
+	// Attempts is the number of failed attempts of the current sweep.
+	Attempts int
+	// ClaimedUntil is when the claim of a scheduler on the current sweep expires, zero when it is not claimed.
+	ClaimedUntil time.Time
+	CreatedAt    time.Time
+}
+
+// Sweep represents the outcome of a sweep into the pocket of a goal.
+type Sweep struct {
+	GoalUUID        string
+	TransactionUUID string
+	Amount          int64
+	Status          string
+	Reason          string
+	SweptAt         time.Time
+}
+
+// IsActive checks if the goal is still being swept into.
+func (g *Goal) IsActive() bool {
+	return g.Status == StatusActive
+}
+
+// Due checks if a scheduled sweep of the goal should run at the given time.
+func (g *Goal) Due(at time.Time) bool {
+	return g.IsActive() && g.SweepRule == SweepScheduled && !g.NextSweepDate.After(at)
+}
+
+// Remaining returns the amount still missing from the target with the pocket holding balance.
+func (g *Goal) Remaining(balance int64) int64 {
+	return max(g.TargetAmount-balance, 0)
+}
+
+// Advance moves a scheduled goal to its next sweep date.
+// Monthly sweeps keep the day of the creation date, clamped to the end of shorter months.
+func (g *Goal) Advance() {
+	switch g.Frequency {
+	case FrequencyDaily:
+		g.NextSweepDate = g.NextSweepDate.AddDate(0, 0, 1)
+	case FrequencyWeekly:
+		g.NextSweepDate = g.NextSweepDate.AddDate(0, 0, 7)
+	case FrequencyMonthly:
+		next := g.NextSweepDate
+		firstOfNext := time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		lastDay := firstOfNext.AddDate(0, 1, -1).Day()
+		g.NextSweepDate = firstOfNext.AddDate(0, 0, min(g.CreatedAt.Day(), lastDay)-1)
+	}
+}
+
+// RoundUp returns the amount that rounds the debited amount up to the next multiple of unit.
+func RoundUp(amount, unit int64) int64 {
+	if unit <= 0 {
+		return 0
+	}
+	return (unit - amount%unit) % unit
+}
@@ -0,0 +1,15 @@
+package standin
+
+import "context"
+
+// Repository defines a contract for the stand-in queue persistence operations.
+type Repository interface {
+	// Enqueue appends the entry to the queue.
+	Enqueue(ctx context.Context, e Entry) error
+	// GetQueued retrieves the entries waiting to be forwarded in queue order.
+	GetQueued(ctx context.Context) ([]Entry, error)
+	// GetQueuedAmount returns the total amount waiting to be forwarded from the source account.
+	GetQueuedAmount(ctx context.Context, sourceAccount string) (int64, error)
+	// Update updates the status of an existing entry.
+	Update(ctx context.Context, e Entry) error
+}
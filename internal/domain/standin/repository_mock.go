@@ -0,0 +1,245 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package standin
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: ctx, e
+func (_m *MockRepository) Enqueue(ctx context.Context, e Entry) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Entry) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockRepository_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e Entry
+func (_e *MockRepository_Expecter) Enqueue(ctx interface{}, e interface{}) *MockRepository_Enqueue_Call {
+	return &MockRepository_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, e)}
+}
+
+func (_c *MockRepository_Enqueue_Call) Run(run func(ctx context.Context, e Entry)) *MockRepository_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Entry))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Enqueue_Call) Return(_a0 error) *MockRepository_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Enqueue_Call) RunAndReturn(run func(context.Context, Entry) error) *MockRepository_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQueued provides a mock function with given fields: ctx
+func (_m *MockRepository) GetQueued(ctx context.Context) ([]Entry, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueued")
+	}
+
+	var r0 []Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Entry, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Entry); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetQueued_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQueued'
+type MockRepository_GetQueued_Call struct {
+	*mock.Call
+}
+
+// GetQueued is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) GetQueued(ctx interface{}) *MockRepository_GetQueued_Call {
+	return &MockRepository_GetQueued_Call{Call: _e.mock.On("GetQueued", ctx)}
+}
+
+func (_c *MockRepository_GetQueued_Call) Run(run func(ctx context.Context)) *MockRepository_GetQueued_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetQueued_Call) Return(_a0 []Entry, _a1 error) *MockRepository_GetQueued_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetQueued_Call) RunAndReturn(run func(context.Context) ([]Entry, error)) *MockRepository_GetQueued_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQueuedAmount provides a mock function with given fields: ctx, sourceAccount
+func (_m *MockRepository) GetQueuedAmount(ctx context.Context, sourceAccount string) (int64, error) {
+	ret := _m.Called(ctx, sourceAccount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueuedAmount")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, sourceAccount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, sourceAccount)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sourceAccount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetQueuedAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQueuedAmount'
+type MockRepository_GetQueuedAmount_Call struct {
+	*mock.Call
+}
+
+// GetQueuedAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceAccount string
+func (_e *MockRepository_Expecter) GetQueuedAmount(ctx interface{}, sourceAccount interface{}) *MockRepository_GetQueuedAmount_Call {
+	return &MockRepository_GetQueuedAmount_Call{Call: _e.mock.On("GetQueuedAmount", ctx, sourceAccount)}
+}
+
+func (_c *MockRepository_GetQueuedAmount_Call) Run(run func(ctx context.Context, sourceAccount string)) *MockRepository_GetQueuedAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetQueuedAmount_Call) Return(_a0 int64, _a1 error) *MockRepository_GetQueuedAmount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetQueuedAmount_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockRepository_GetQueuedAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, e
+func (_m *MockRepository) Update(ctx context.Context, e Entry) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Entry) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e Entry
+func (_e *MockRepository_Expecter) Update(ctx interface{}, e interface{}) *MockRepository_Update_Call {
+	return &MockRepository_Update_Call{Call: _e.mock.On("Update", ctx, e)}
+}
+
+func (_c *MockRepository_Update_Call) Run(run func(ctx context.Context, e Entry)) *MockRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Entry))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) Return(_a0 error) *MockRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) RunAndReturn(run func(context.Context, Entry) error) *MockRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,63 @@
+// Package standin contains the store-and-forward queue of transfers
+// accepted while the core banking system is at the end of day.
+package standin
+
+import "time"
+
+// Entry statuses.
+const (
+	StatusQueued    = "queued"
+	StatusForwarded = "forwarded"
+	StatusRejected  = "rejected"
+	// StatusParked is an entry that failed to be forwarded too many times,
+	// it is left out of the replay and its transfer is given up.
+	StatusParked = "parked"
+)
+
+// Entry represents a transfer waiting to be forwarded to the core banking system.
+// Entries are forwarded in Sequence order.
+type Entry struct {
+	Sequence           int64
+	TransactionUUID    string
+	SourceAccount      string
+	DestinationAccount string
+	Amount             int64
+	Status             string
+	Reason             string
+	// Attempts is the number of failed forwards of the entry.
+	Attempts    int
+	CreatedAt   time.Time
+	ForwardedAt time.Time
+}
+
+// Policy defines which transfers are accepted while the core banking system is at the end of day.
+type Policy struct {
+	// Limit is the largest amount of a transfer, zero disables the stand-in.
+	Limit int64
+	// AccountLimit is the largest amount queued for a source account, zero is unlimited.
+	AccountLimit int64
+	// MaxAttempts is the number of failed forwards after which an entry is parked.
+	MaxAttempts int
+}
+
+// Enabled returns true if transfers are queued during the end of day.
+func (p Policy) Enabled() bool {
+	return p.Limit > 0
+}
+
+// Accepts returns true if a transfer of the amount can be queued.
+func (p Policy) Accepts(amount int64) bool {
+	return p.Enabled() && amount <= p.Limit
+}
+
+// Parks returns true if an entry that failed to be forwarded the number of attempts is parked,
+// so it no longer holds up the entries queued after it.
+func (p Policy) Parks(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
+
+// WithinAccountLimit returns true if the amount fits the account limit
+// next to the amount already queued for the account.
+func (p Policy) WithinAccountLimit(queued, amount int64) bool {
+	return p.AccountLimit <= 0 || queued+amount <= p.AccountLimit
+}
@@ -0,0 +1,33 @@
+package standingorder
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a standing order is not found.
+var ErrNotFound = errors.New("standing order not found")
+
+// Repository defines a contract for standing order persistence operations.
+type Repository interface {
+	// Create creates a standing order.
+	Create(ctx context.Context, order StandingOrder) error
+
+	// GetByUUID retrieves a standing order by its UUID.
+	GetByUUID(ctx context.Context, uuid string) (StandingOrder, error)
+
+	// GetByUsername retrieves the standing orders of a user.
+	GetByUsername(ctx context.Context, username string) ([]StandingOrder, error)
+
+	// ClaimDue retrieves the active standing orders with a next run date up to the given time
+	// and claims their current run for the lease, so other schedulers skip them until the run
+	// is recorded or the lease expires.
+	ClaimDue(ctx context.Context, at time.Time, lease time.Duration) ([]StandingOrder, error)
+
+	// Update updates an existing standing order.
+	Update(ctx context.Context, order StandingOrder) error
+
+	// CreateExecution records the outcome of a standing order run.
+	CreateExecution(ctx context.Context, execution Execution) error
+}
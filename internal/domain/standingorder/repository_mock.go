@@ -0,0 +1,354 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package standingorder
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDue provides a mock function with given fields: ctx, at, lease
+func (_m *MockRepository) ClaimDue(ctx context.Context, at time.Time, lease time.Duration) ([]StandingOrder, error) {
+	ret := _m.Called(ctx, at, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []StandingOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) ([]StandingOrder, error)); ok {
+		return rf(ctx, at, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) []StandingOrder); ok {
+		r0 = rf(ctx, at, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]StandingOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, at, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ClaimDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDue'
+type MockRepository_ClaimDue_Call struct {
+	*mock.Call
+}
+
+// ClaimDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - at time.Time
+//   - lease time.Duration
+func (_e *MockRepository_Expecter) ClaimDue(ctx interface{}, at interface{}, lease interface{}) *MockRepository_ClaimDue_Call {
+	return &MockRepository_ClaimDue_Call{Call: _e.mock.On("ClaimDue", ctx, at, lease)}
+}
+
+func (_c *MockRepository_ClaimDue_Call) Run(run func(ctx context.Context, at time.Time, lease time.Duration)) *MockRepository_ClaimDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ClaimDue_Call) Return(_a0 []StandingOrder, _a1 error) *MockRepository_ClaimDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ClaimDue_Call) RunAndReturn(run func(context.Context, time.Time, time.Duration) ([]StandingOrder, error)) *MockRepository_ClaimDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, order
+func (_m *MockRepository) Create(ctx context.Context, order StandingOrder) error {
+	ret := _m.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, StandingOrder) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order StandingOrder
+func (_e *MockRepository_Expecter) Create(ctx interface{}, order interface{}) *MockRepository_Create_Call {
+	return &MockRepository_Create_Call{Call: _e.mock.On("Create", ctx, order)}
+}
+
+func (_c *MockRepository_Create_Call) Run(run func(ctx context.Context, order StandingOrder)) *MockRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(StandingOrder))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) Return(_a0 error) *MockRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Create_Call) RunAndReturn(run func(context.Context, StandingOrder) error) *MockRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateExecution provides a mock function with given fields: ctx, execution
+func (_m *MockRepository) CreateExecution(ctx context.Context, execution Execution) error {
+	ret := _m.Called(ctx, execution)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExecution")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Execution) error); ok {
+		r0 = rf(ctx, execution)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateExecution'
+type MockRepository_CreateExecution_Call struct {
+	*mock.Call
+}
+
+// CreateExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - execution Execution
+func (_e *MockRepository_Expecter) CreateExecution(ctx interface{}, execution interface{}) *MockRepository_CreateExecution_Call {
+	return &MockRepository_CreateExecution_Call{Call: _e.mock.On("CreateExecution", ctx, execution)}
+}
+
+func (_c *MockRepository_CreateExecution_Call) Run(run func(ctx context.Context, execution Execution)) *MockRepository_CreateExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Execution))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateExecution_Call) Return(_a0 error) *MockRepository_CreateExecution_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateExecution_Call) RunAndReturn(run func(context.Context, Execution) error) *MockRepository_CreateExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUUID provides a mock function with given fields: ctx, uuid
+func (_m *MockRepository) GetByUUID(ctx context.Context, uuid string) (StandingOrder, error) {
+	ret := _m.Called(ctx, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUUID")
+	}
+
+	var r0 StandingOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (StandingOrder, error)); ok {
+		return rf(ctx, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) StandingOrder); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		r0 = ret.Get(0).(StandingOrder)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByUUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUUID'
+type MockRepository_GetByUUID_Call struct {
+	*mock.Call
+}
+
+// GetByUUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+func (_e *MockRepository_Expecter) GetByUUID(ctx interface{}, uuid interface{}) *MockRepository_GetByUUID_Call {
+	return &MockRepository_GetByUUID_Call{Call: _e.mock.On("GetByUUID", ctx, uuid)}
+}
+
+func (_c *MockRepository_GetByUUID_Call) Run(run func(ctx context.Context, uuid string)) *MockRepository_GetByUUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) Return(_a0 StandingOrder, _a1 error) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByUUID_Call) RunAndReturn(run func(context.Context, string) (StandingOrder, error)) *MockRepository_GetByUUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *MockRepository) GetByUsername(ctx context.Context, username string) ([]StandingOrder, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUsername")
+	}
+
+	var r0 []StandingOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]StandingOrder, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []StandingOrder); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]StandingOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
+type MockRepository_GetByUsername_Call struct {
+	*mock.Call
+}
+
+// GetByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockRepository_Expecter) GetByUsername(ctx interface{}, username interface{}) *MockRepository_GetByUsername_Call {
+	return &MockRepository_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
+}
+
+func (_c *MockRepository_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *MockRepository_GetByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetByUsername_Call) Return(_a0 []StandingOrder, _a1 error) *MockRepository_GetByUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetByUsername_Call) RunAndReturn(run func(context.Context, string) ([]StandingOrder, error)) *MockRepository_GetByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, order
+func (_m *MockRepository) Update(ctx context.Context, order StandingOrder) error {
+	ret := _m.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, StandingOrder) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order StandingOrder
+func (_e *MockRepository_Expecter) Update(ctx interface{}, order interface{}) *MockRepository_Update_Call {
+	return &MockRepository_Update_Call{Call: _e.mock.On("Update", ctx, order)}
+}
+
+func (_c *MockRepository_Update_Call) Run(run func(ctx context.Context, order StandingOrder)) *MockRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(StandingOrder))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) Return(_a0 error) *MockRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Update_Call) RunAndReturn(run func(context.Context, StandingOrder) error) *MockRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,106 @@
+// Package standingorder contains scheduled and recurring transfer domain logic and entities.
+package standingorder
+
+import "time"
+
+const (
+	// FrequencyOnce represents a transfer scheduled for a single future date.
+	FrequencyOnce = "once"
+	// FrequencyDaily represents a transfer repeated every day.
+	FrequencyDaily = "daily"
+	// FrequencyWeekly represents a transfer repeated every week.
+	FrequencyWeekly = "weekly"
+	// FrequencyMonthly represents a transfer repeated every month.
+	FrequencyMonthly = "monthly"
+)
+
+const (
+	// StatusActive represents a standing order that is still scheduled.
+	StatusActive = "active"
+	// StatusFinished represents a standing order that has no more runs.
+	StatusFinished = "finished"
+	// StatusCancelled represents a standing order cancelled by the user.
+	StatusCancelled = "cancelled"
+)
+
+const (
+	// ExecutionStatusCompleted represents a run that transferred the amount.
+	ExecutionStatusCompleted = "completed"
+	// ExecutionStatusFailed represents a run that failed to transfer the amount.
+	ExecutionStatusFailed = "failed"
+)
+
+// StandingOrder represents a scheduled or recurring transfer entity.
+type StandingOrder struct {
+	UUID                string
+	Username            string
+	SourceAccount       string
+	DestinationBankCode string
+	DestinationAccount  string
+	Amount              int64
+	Note                string
+	Frequency           string
+	StartDate           time.Time
+	EndDate             time.Time
+	NextRunDate         time.Time
+	Status              string
+	// Attempts is the number of failed attempts of the current run.
+	Attempts int
+	// ClaimedUntil is when the claim of a scheduler on the current run expires, zero when it is not claimed.
+	ClaimedUntil time.Time
+	CreatedAt    time.Time
+}
+
+// Execution represents the outcome of a standing order run.
+type Execution struct {
+	OrderUUID       string
+	TransactionUUID string
+	Status          string
+	Reason          string
+	Attempt         int
+	ExecutedAt      time.Time
+}
+
+// IsActive checks if the standing order is still scheduled.
+func (o *StandingOrder) IsActive() bool {
+	return o.Status == StatusActive
+}
+
+// Due checks if the standing order should run at the given time.
+func (o *StandingOrder) Due(at time.Time) bool {
+	return o.IsActive() && !o.NextRunDate.After(at)
+}
+
+// Advance moves the standing order to its next run date, resetting the attempts.
+// The order is finished when the next run date passes the end date.
+func (o *StandingOrder) Advance() {
+	o.Attempts = 0
+	next, ok := o.nextRunDate()
+	if !ok || (!o.EndDate.IsZero() && next.After(o.EndDate)) {
+		o.Status = StatusFinished
+		return
+	}
+	o.NextRunDate = next
+}
+
+// nextRunDate returns the run date after the current one.
+// Monthly orders keep the day of the start date, clamped to the end of shorter months.
+func (o *StandingOrder) nextRunDate() (time.Time, bool) {
+	switch o.Frequency {
+	case FrequencyDaily:
+		return o.NextRunDate.AddDate(0, 0, 1), true
+	case FrequencyWeekly:
+		return o.NextRunDate.AddDate(0, 0, 7), true
+	case FrequencyMonthly:
+		return addMonth(o.NextRunDate, o.StartDate.Day()), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// addMonth returns the date one month after t on the given day of the month.
+func addMonth(t time.Time, day int) time.Time {
+	firstOfNext := time.Date(t.Year(), t.Month()+1, 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	lastDay := firstOfNext.AddDate(0, 1, -1).Day()
+	return firstOfNext.AddDate(0, 0, min(day, lastDay)-1)
+}
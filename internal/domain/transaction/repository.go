@@ -1,6 +1,17 @@
 package transaction
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrDuplicate is returned when a transaction with the same unique data already exists,
+// e.g. a second active reversal of a transaction.
+var ErrDuplicate = errors.New("duplicate transaction")
+
+// ErrConflict is returned when the transaction was changed by someone else since it was read.
+var ErrConflict = errors.New("transaction was modified concurrently")
 
 // Repository defines a contract for data access and persistence operations.
 type Repository interface {
@@ -13,6 +24,30 @@ type Repository interface {
 	// Create creates a transaction entity in the repository.
 	Create(ctx context.Context, tx Transaction) error
 
-	// Update updates an existing transaction entity in the repository.
+	// Update updates an existing transaction entity in the repository and records its status change.
+	// It returns ErrConflict when the stored version is not tx.Version,
+	// and a TransitionError when the stored status cannot move to tx.Status.
 	Update(ctx context.Context, tx Transaction) error
+
+	// Claim moves an initiated transaction to pending with the reason before it is sent
+	// to the core banking system, and returns the claimed transaction.
+	// It returns ErrConflict when the transaction is no longer initiated,
+	// so only one caller can move the money of a transaction.
+	Claim(ctx context.Context, uuid, reason string) (Transaction, error)
+
+	// GetHistory retrieves the status changes of a transaction, oldest first.
+	GetHistory(ctx context.Context, uuid string) ([]StatusChange, error)
+
+	// ExpireInitiated moves the transactions still initiated since before createdBefore
+	// to the expired status with the reason, and returns the expired transactions.
+	ExpireInitiated(ctx context.Context, createdBefore time.Time, reason string) ([]Transaction, error)
+
+	// GetCompletedDebits retrieves the completed transactions debiting the account
+	// that were updated after updatedAfter, oldest update first.
+	GetCompletedDebits(ctx context.Context, accountNumber string, updatedAfter time.Time) ([]Transaction, error)
+
+	// GetLastDebitAt retrieves the creation time of the latest completed transaction debiting the account
+	// initiated by its customer, reversals and transactions with an origin excluded.
+	// It returns the zero time without one.
+	GetLastDebitAt(ctx context.Context, accountNumber string) (time.Time, error)
 }
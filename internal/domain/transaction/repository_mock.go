@@ -4,6 +4,7 @@ package transaction
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -21,6 +22,64 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// Claim provides a mock function with given fields: ctx, uuid, reason
+func (_m *MockRepository) Claim(ctx context.Context, uuid string, reason string) (Transaction, error) {
+	ret := _m.Called(ctx, uuid, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (Transaction, error)); ok {
+		return rf(ctx, uuid, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) Transaction); ok {
+		r0 = rf(ctx, uuid, reason)
+	} else {
+		r0 = ret.Get(0).(Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uuid, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockRepository_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+//   - reason string
+func (_e *MockRepository_Expecter) Claim(ctx interface{}, uuid interface{}, reason interface{}) *MockRepository_Claim_Call {
+	return &MockRepository_Claim_Call{Call: _e.mock.On("Claim", ctx, uuid, reason)}
+}
+
+func (_c *MockRepository_Claim_Call) Run(run func(ctx context.Context, uuid string, reason string)) *MockRepository_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Claim_Call) Return(_a0 Transaction, _a1 error) *MockRepository_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Claim_Call) RunAndReturn(run func(context.Context, string, string) (Transaction, error)) *MockRepository_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, tx
 func (_m *MockRepository) Create(ctx context.Context, tx Transaction) error {
 	ret := _m.Called(ctx, tx)
@@ -68,6 +127,66 @@ func (_c *MockRepository_Create_Call) RunAndReturn(run func(context.Context, Tra
 	return _c
 }
 
+// ExpireInitiated provides a mock function with given fields: ctx, createdBefore, reason
+func (_m *MockRepository) ExpireInitiated(ctx context.Context, createdBefore time.Time, reason string) ([]Transaction, error) {
+	ret := _m.Called(ctx, createdBefore, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireInitiated")
+	}
+
+	var r0 []Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) ([]Transaction, error)); ok {
+		return rf(ctx, createdBefore, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) []Transaction); ok {
+		r0 = rf(ctx, createdBefore, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, string) error); ok {
+		r1 = rf(ctx, createdBefore, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ExpireInitiated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireInitiated'
+type MockRepository_ExpireInitiated_Call struct {
+	*mock.Call
+}
+
+// ExpireInitiated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdBefore time.Time
+//   - reason string
+func (_e *MockRepository_Expecter) ExpireInitiated(ctx interface{}, createdBefore interface{}, reason interface{}) *MockRepository_ExpireInitiated_Call {
+	return &MockRepository_ExpireInitiated_Call{Call: _e.mock.On("ExpireInitiated", ctx, createdBefore, reason)}
+}
+
+func (_c *MockRepository_ExpireInitiated_Call) Run(run func(ctx context.Context, createdBefore time.Time, reason string)) *MockRepository_ExpireInitiated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ExpireInitiated_Call) Return(_a0 []Transaction, _a1 error) *MockRepository_ExpireInitiated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ExpireInitiated_Call) RunAndReturn(run func(context.Context, time.Time, string) ([]Transaction, error)) *MockRepository_ExpireInitiated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByParams provides a mock function with given fields: ctx, params
 func (_m *MockRepository) GetByParams(ctx context.Context, params map[string]interface{}) ([]Transaction, error) {
 	ret := _m.Called(ctx, params)
@@ -184,6 +303,182 @@ func (_c *MockRepository_GetByUUID_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetCompletedDebits provides a mock function with given fields: ctx, accountNumber, updatedAfter
+func (_m *MockRepository) GetCompletedDebits(ctx context.Context, accountNumber string, updatedAfter time.Time) ([]Transaction, error) {
+	ret := _m.Called(ctx, accountNumber, updatedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCompletedDebits")
+	}
+
+	var r0 []Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]Transaction, error)); ok {
+		return rf(ctx, accountNumber, updatedAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []Transaction); ok {
+		r0 = rf(ctx, accountNumber, updatedAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, accountNumber, updatedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetCompletedDebits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCompletedDebits'
+type MockRepository_GetCompletedDebits_Call struct {
+	*mock.Call
+}
+
+// GetCompletedDebits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+//   - updatedAfter time.Time
+func (_e *MockRepository_Expecter) GetCompletedDebits(ctx interface{}, accountNumber interface{}, updatedAfter interface{}) *MockRepository_GetCompletedDebits_Call {
+	return &MockRepository_GetCompletedDebits_Call{Call: _e.mock.On("GetCompletedDebits", ctx, accountNumber, updatedAfter)}
+}
+
+func (_c *MockRepository_GetCompletedDebits_Call) Run(run func(ctx context.Context, accountNumber string, updatedAfter time.Time)) *MockRepository_GetCompletedDebits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCompletedDebits_Call) Return(_a0 []Transaction, _a1 error) *MockRepository_GetCompletedDebits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetCompletedDebits_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]Transaction, error)) *MockRepository_GetCompletedDebits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHistory provides a mock function with given fields: ctx, uuid
+func (_m *MockRepository) GetHistory(ctx context.Context, uuid string) ([]StatusChange, error) {
+	ret := _m.Called(ctx, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []StatusChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]StatusChange, error)); ok {
+		return rf(ctx, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []StatusChange); ok {
+		r0 = rf(ctx, uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]StatusChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistory'
+type MockRepository_GetHistory_Call struct {
+	*mock.Call
+}
+
+// GetHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+func (_e *MockRepository_Expecter) GetHistory(ctx interface{}, uuid interface{}) *MockRepository_GetHistory_Call {
+	return &MockRepository_GetHistory_Call{Call: _e.mock.On("GetHistory", ctx, uuid)}
+}
+
+func (_c *MockRepository_GetHistory_Call) Run(run func(ctx context.Context, uuid string)) *MockRepository_GetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetHistory_Call) Return(_a0 []StatusChange, _a1 error) *MockRepository_GetHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetHistory_Call) RunAndReturn(run func(context.Context, string) ([]StatusChange, error)) *MockRepository_GetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastDebitAt provides a mock function with given fields: ctx, accountNumber
+func (_m *MockRepository) GetLastDebitAt(ctx context.Context, accountNumber string) (time.Time, error) {
+	ret := _m.Called(ctx, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastDebitAt")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetLastDebitAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastDebitAt'
+type MockRepository_GetLastDebitAt_Call struct {
+	*mock.Call
+}
+
+// GetLastDebitAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+func (_e *MockRepository_Expecter) GetLastDebitAt(ctx interface{}, accountNumber interface{}) *MockRepository_GetLastDebitAt_Call {
+	return &MockRepository_GetLastDebitAt_Call{Call: _e.mock.On("GetLastDebitAt", ctx, accountNumber)}
+}
+
+func (_c *MockRepository_GetLastDebitAt_Call) Run(run func(ctx context.Context, accountNumber string)) *MockRepository_GetLastDebitAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetLastDebitAt_Call) Return(_a0 time.Time, _a1 error) *MockRepository_GetLastDebitAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetLastDebitAt_Call) RunAndReturn(run func(context.Context, string) (time.Time, error)) *MockRepository_GetLastDebitAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, tx
 func (_m *MockRepository) Update(ctx context.Context, tx Transaction) error {
 	ret := _m.Called(ctx, tx)
@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrInvalidTransition is matched by every TransitionError.
+var ErrInvalidTransition = errors.New("invalid transaction status transition")
+
+// transitions lists the statuses a transaction can move to from each status.
+// Failed, expired, cancelled and reversed transactions are final.
+var transitions = map[string][]string{
+	StatusInitiated: {StatusPending, StatusExpired, StatusCancelled},
+	StatusPending:   {StatusCompleted, StatusFailed},
+	StatusCompleted: {StatusReversed},
+}
+
+// TransitionError is returned when a transaction cannot move from one status to another.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("transaction cannot move from %q to %q", e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// CanTransition checks if a transaction can move from one status to another.
+func CanTransition(from, to string) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Transition moves the transaction to the status with the reason,
+// or returns a TransitionError when the state machine does not allow it.
+func (tx *Transaction) Transition(to, reason string) error {
+	if !CanTransition(tx.Status, to) {
+		return &TransitionError{From: tx.Status, To: to}
+	}
+	tx.Status = to
+	tx.StatusReason = reason
+	return nil
+}
+
+// StatusChange is an entry of the status history of a transaction.
+// From is empty for the status the transaction was created with.
+type StatusChange struct {
+	TransactionUUID string
+	From            string
+	To              string
+	Reason          string
+	ChangedAt       time.Time
+}
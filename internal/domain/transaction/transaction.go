@@ -12,6 +12,29 @@ const (
 	StatusFailed = "failed"
 	// StatusCompleted represents a completed transaction status.
 	StatusCompleted = "completed"
+	// StatusExpired represents an initiated transaction that was not processed in time.
+	StatusExpired = "expired"
+	// StatusCancelled represents an initiated transaction cancelled before it was processed.
+	StatusCancelled = "cancelled"
+	// StatusReversed represents a completed transaction whose money was returned.
+	StatusReversed = "reversed"
+)
+
+// ReasonExpired is the status reason of an expired transaction.
+const ReasonExpired = "Transaction was not processed before it expired"
+
+const (
+	// OriginStandingOrder is a transaction initiated by the run of a standing order.
+	OriginStandingOrder = "standing_order"
+	// OriginSavingsGoal is a transaction initiated by the sweep of a savings goal.
+	OriginSavingsGoal = "savings_goal"
+)
+
+const (
+	// ReversalBankError is a reversal caused by the bank, a rail or a biller, the fee is refunded.
+	ReversalBankError = "bank_error"
+	// ReversalCustomerRequest is a reversal requested by the customer, the fee is kept.
+	ReversalCustomerRequest = "customer_request"
 )
 
 // Transaction represents a bank transaction entity.
@@ -19,13 +42,47 @@ type Transaction struct {
 	UUID                 string
 	TransactionReference string
 	SourceAccount        string
+	DestinationBankCode  string
 	DestinationAccount   string
 	TransactionType      string
+	BatchUUID            string
+	Origin               string // Scheduled job that initiated the transaction, empty when the customer did.
+	Rail                 string
 	Status               string
+	StatusReason         string
 	PaymentID            string
+	ReversalOf           string // UUID of the transaction a reversal returns the money of.
 	Note                 string
 	Amount               int64
 	Fee                  int64
 	Username             string
 	ProcessedAt          time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Version              int64 // Incremented on every update, a stale version fails the update.
+}
+
+// Expired checks if the transaction is still initiated after the ttl.
+// A zero ttl never expires transactions.
+func (tx Transaction) Expired(ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && tx.Status == StatusInitiated && now.Sub(tx.CreatedAt) > ttl
+}
+
+// RefundedFee returns the part of the fee returned when the transaction is reversed for the cause.
+// The fee of a completed transaction is the fee that was charged, a waived fee is not refunded.
+func (tx Transaction) RefundedFee(cause string) int64 {
+	if cause == ReversalBankError {
+		return tx.Fee
+	}
+	return 0
+}
+
+// HoldAmount returns the amount held on the source account until the transaction is settled.
+func (tx Transaction) HoldAmount() int64 {
+	return tx.Amount + tx.Fee
+}
+
+// Expire moves the transaction to the expired status.
+func (tx *Transaction) Expire() error {
+	return tx.Transition(StatusExpired, ReasonExpired)
 }
@@ -0,0 +1,208 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package transfer
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockBIFastService is an autogenerated mock type for the BIFastService type
+type MockBIFastService struct {
+	mock.Mock
+}
+
+type MockBIFastService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBIFastService) EXPECT() *MockBIFastService_Expecter {
+	return &MockBIFastService_Expecter{mock: &_m.Mock}
+}
+
+// AccountInquiry provides a mock function with given fields: ctx, bankCode, accountNumber
+func (_m *MockBIFastService) AccountInquiry(ctx context.Context, bankCode string, accountNumber string) (InterbankAccount, error) {
+	ret := _m.Called(ctx, bankCode, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccountInquiry")
+	}
+
+	var r0 InterbankAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (InterbankAccount, error)); ok {
+		return rf(ctx, bankCode, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) InterbankAccount); ok {
+		r0 = rf(ctx, bankCode, accountNumber)
+	} else {
+		r0 = ret.Get(0).(InterbankAccount)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bankCode, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBIFastService_AccountInquiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccountInquiry'
+type MockBIFastService_AccountInquiry_Call struct {
+	*mock.Call
+}
+
+// AccountInquiry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bankCode string
+//   - accountNumber string
+func (_e *MockBIFastService_Expecter) AccountInquiry(ctx interface{}, bankCode interface{}, accountNumber interface{}) *MockBIFastService_AccountInquiry_Call {
+	return &MockBIFastService_AccountInquiry_Call{Call: _e.mock.On("AccountInquiry", ctx, bankCode, accountNumber)}
+}
+
+func (_c *MockBIFastService_AccountInquiry_Call) Run(run func(ctx context.Context, bankCode string, accountNumber string)) *MockBIFastService_AccountInquiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBIFastService_AccountInquiry_Call) Return(_a0 InterbankAccount, _a1 error) *MockBIFastService_AccountInquiry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBIFastService_AccountInquiry_Call) RunAndReturn(run func(context.Context, string, string) (InterbankAccount, error)) *MockBIFastService_AccountInquiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreditTransfer provides a mock function with given fields: ctx, tf
+func (_m *MockBIFastService) CreditTransfer(ctx context.Context, tf InterbankTransfer) (InterbankTransfer, error) {
+	ret := _m.Called(ctx, tf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreditTransfer")
+	}
+
+	var r0 InterbankTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, InterbankTransfer) (InterbankTransfer, error)); ok {
+		return rf(ctx, tf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, InterbankTransfer) InterbankTransfer); ok {
+		r0 = rf(ctx, tf)
+	} else {
+		r0 = ret.Get(0).(InterbankTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, InterbankTransfer) error); ok {
+		r1 = rf(ctx, tf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBIFastService_CreditTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreditTransfer'
+type MockBIFastService_CreditTransfer_Call struct {
+	*mock.Call
+}
+
+// CreditTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tf InterbankTransfer
+func (_e *MockBIFastService_Expecter) CreditTransfer(ctx interface{}, tf interface{}) *MockBIFastService_CreditTransfer_Call {
+	return &MockBIFastService_CreditTransfer_Call{Call: _e.mock.On("CreditTransfer", ctx, tf)}
+}
+
+func (_c *MockBIFastService_CreditTransfer_Call) Run(run func(ctx context.Context, tf InterbankTransfer)) *MockBIFastService_CreditTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(InterbankTransfer))
+	})
+	return _c
+}
+
+func (_c *MockBIFastService_CreditTransfer_Call) Return(_a0 InterbankTransfer, _a1 error) *MockBIFastService_CreditTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBIFastService_CreditTransfer_Call) RunAndReturn(run func(context.Context, InterbankTransfer) (InterbankTransfer, error)) *MockBIFastService_CreditTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatus provides a mock function with given fields: ctx, reference
+func (_m *MockBIFastService) GetStatus(ctx context.Context, reference string) (InterbankTransfer, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 InterbankTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (InterbankTransfer, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) InterbankTransfer); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		r0 = ret.Get(0).(InterbankTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBIFastService_GetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatus'
+type MockBIFastService_GetStatus_Call struct {
+	*mock.Call
+}
+
+// GetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockBIFastService_Expecter) GetStatus(ctx interface{}, reference interface{}) *MockBIFastService_GetStatus_Call {
+	return &MockBIFastService_GetStatus_Call{Call: _e.mock.On("GetStatus", ctx, reference)}
+}
+
+func (_c *MockBIFastService_GetStatus_Call) Run(run func(ctx context.Context, reference string)) *MockBIFastService_GetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBIFastService_GetStatus_Call) Return(_a0 InterbankTransfer, _a1 error) *MockBIFastService_GetStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBIFastService_GetStatus_Call) RunAndReturn(run func(context.Context, string) (InterbankTransfer, error)) *MockBIFastService_GetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBIFastService creates a new instance of MockBIFastService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBIFastService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBIFastService {
+	mock := &MockBIFastService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,72 @@
+package transfer
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrNoRailAvailable is returned when no rail can settle the amount at the given time.
+var ErrNoRailAvailable = errors.New("no rail available")
+
+// RailRouter chooses the rail an interbank transfer is settled through,
+// based on the amount and the rail cut-off times.
+type RailRouter struct {
+	// BIFastLimit is the maximum amount that can be sent through BI-FAST.
+	BIFastLimit int64
+	// SKNLimit is the maximum amount that can be sent through SKN.
+	SKNLimit int64
+	// RTGSMinimum is the minimum amount that can be sent through RTGS.
+	RTGSMinimum int64
+	// SKNBatchCutOffs are the cut-off times of the daily SKN clearing batches,
+	// as durations since midnight.
+	SKNBatchCutOffs []time.Duration
+	// RTGSCutOff is the daily RTGS cut-off time, as a duration since midnight.
+	RTGSCutOff time.Duration
+	// BIFastFee, SKNFee and RTGSFee are charged to the sender of a transfer through the rail.
+	BIFastFee int64
+	SKNFee    int64
+	RTGSFee   int64
+}
+
+// Route returns the rail for the amount at the given time.
+// BI-FAST is used up to its limit at any time, SKN is used while a clearing batch
+// is still open, and RTGS is used for large values before its cut-off.
+func (r RailRouter) Route(amount int64, at time.Time) (string, error) {
+	if amount <= r.BIFastLimit {
+		return RailBIFast, nil
+	}
+	sinceMidnight := sinceMidnight(at)
+	if amount <= r.SKNLimit && r.sknBatchOpen(sinceMidnight) {
+		return RailSKN, nil
+	}
+	if amount >= r.RTGSMinimum && sinceMidnight < r.RTGSCutOff {
+		return RailRTGS, nil
+	}
+	return "", ErrNoRailAvailable
+}
+
+// Fee returns the fee of a transfer through the rail, internal transfers are free.
+func (r RailRouter) Fee(rail string) int64 {
+	switch rail {
+	case RailBIFast:
+		return r.BIFastFee
+	case RailSKN:
+		return r.SKNFee
+	case RailRTGS:
+		return r.RTGSFee
+	}
+	return 0
+}
+
+// sknBatchOpen returns true if there is still a clearing batch open today.
+func (r RailRouter) sknBatchOpen(sinceMidnight time.Duration) bool {
+	return slices.ContainsFunc(r.SKNBatchCutOffs, func(cutOff time.Duration) bool {
+		return sinceMidnight < cutOff
+	})
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return t.Sub(midnight)
+}
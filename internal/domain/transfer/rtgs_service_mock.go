@@ -0,0 +1,150 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package transfer
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRTGSService is an autogenerated mock type for the RTGSService type
+type MockRTGSService struct {
+	mock.Mock
+}
+
+type MockRTGSService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRTGSService) EXPECT() *MockRTGSService_Expecter {
+	return &MockRTGSService_Expecter{mock: &_m.Mock}
+}
+
+// GetStatus provides a mock function with given fields: ctx, reference
+func (_m *MockRTGSService) GetStatus(ctx context.Context, reference string) (InterbankTransfer, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 InterbankTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (InterbankTransfer, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) InterbankTransfer); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		r0 = ret.Get(0).(InterbankTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRTGSService_GetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatus'
+type MockRTGSService_GetStatus_Call struct {
+	*mock.Call
+}
+
+// GetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockRTGSService_Expecter) GetStatus(ctx interface{}, reference interface{}) *MockRTGSService_GetStatus_Call {
+	return &MockRTGSService_GetStatus_Call{Call: _e.mock.On("GetStatus", ctx, reference)}
+}
+
+func (_c *MockRTGSService_GetStatus_Call) Run(run func(ctx context.Context, reference string)) *MockRTGSService_GetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRTGSService_GetStatus_Call) Return(_a0 InterbankTransfer, _a1 error) *MockRTGSService_GetStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRTGSService_GetStatus_Call) RunAndReturn(run func(context.Context, string) (InterbankTransfer, error)) *MockRTGSService_GetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Settle provides a mock function with given fields: ctx, tf
+func (_m *MockRTGSService) Settle(ctx context.Context, tf InterbankTransfer) (InterbankTransfer, error) {
+	ret := _m.Called(ctx, tf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Settle")
+	}
+
+	var r0 InterbankTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, InterbankTransfer) (InterbankTransfer, error)); ok {
+		return rf(ctx, tf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, InterbankTransfer) InterbankTransfer); ok {
+		r0 = rf(ctx, tf)
+	} else {
+		r0 = ret.Get(0).(InterbankTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, InterbankTransfer) error); ok {
+		r1 = rf(ctx, tf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRTGSService_Settle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Settle'
+type MockRTGSService_Settle_Call struct {
+	*mock.Call
+}
+
+// Settle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tf InterbankTransfer
+func (_e *MockRTGSService_Expecter) Settle(ctx interface{}, tf interface{}) *MockRTGSService_Settle_Call {
+	return &MockRTGSService_Settle_Call{Call: _e.mock.On("Settle", ctx, tf)}
+}
+
+func (_c *MockRTGSService_Settle_Call) Run(run func(ctx context.Context, tf InterbankTransfer)) *MockRTGSService_Settle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(InterbankTransfer))
+	})
+	return _c
+}
+
+func (_c *MockRTGSService_Settle_Call) Return(_a0 InterbankTransfer, _a1 error) *MockRTGSService_Settle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRTGSService_Settle_Call) RunAndReturn(run func(context.Context, InterbankTransfer) (InterbankTransfer, error)) *MockRTGSService_Settle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRTGSService creates a new instance of MockRTGSService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRTGSService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRTGSService {
+	mock := &MockRTGSService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
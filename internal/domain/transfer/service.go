@@ -1,9 +1,73 @@
 package transfer
 
-import "context"
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+var (
+	// ErrOutcomeUnknown is returned when a transfer may or may not have been executed,
+	// e.g. on a timeout after the request was sent.
+	ErrOutcomeUnknown = errors.New("transfer outcome is unknown")
+
+	// ErrTransferNotFound is returned when the core banking system has no transfer with the remark.
+	ErrTransferNotFound = errors.New("transfer not found")
+)
+
+// IsOutcomeUnknown returns true if the transfer may or may not have been executed despite the error,
+// it is then settled by looking up its outcome instead of being failed.
+func IsOutcomeUnknown(err error) bool {
+	if errors.Is(err, ErrOutcomeUnknown) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
 
 type Service interface {
 	// Transfer moves amount from one account to another
 	// and returns an error if the operation fails.
 	Transfer(ctx context.Context, srcAccountNumber, destAccountNumber string, amount int64, remark string) (Transfer, error)
+
+	// GetTransferStatus retrieves the outcome of a transfer by the remark it was sent with.
+	GetTransferStatus(ctx context.Context, remark string) (Transfer, error)
+
+	// Reverse returns the money of a completed transfer to its source account.
+	// Reversals are deduplicated by their remark.
+	Reverse(ctx context.Context, rv Reversal) (Transfer, error)
+
+	// ChargeFee posts the fee of a settled transfer from the account to the fee income of the bank.
+	// Fees are deduplicated by the remark of the transfer.
+	ChargeFee(ctx context.Context, accountNumber string, fee int64, remark string) error
+}
+
+// BIFastService is the adapter for real-time interbank transfers through BI-FAST.
+type BIFastService interface {
+	// CreditTransfer sends the transfer and returns once it is settled or rejected.
+	CreditTransfer(ctx context.Context, tf InterbankTransfer) (InterbankTransfer, error)
+
+	// GetStatus retrieves the status of a transfer by its transaction reference.
+	GetStatus(ctx context.Context, reference string) (InterbankTransfer, error)
+
+	// AccountInquiry retrieves the holder of an account in another bank.
+	AccountInquiry(ctx context.Context, bankCode, accountNumber string) (InterbankAccount, error)
+}
+
+// SKNService is the adapter for batch interbank transfers through SKN clearing.
+type SKNService interface {
+	// Submit puts the transfer into the next clearing batch.
+	Submit(ctx context.Context, tf InterbankTransfer) (InterbankTransfer, error)
+
+	// GetStatus retrieves the status of a transfer by its transaction reference.
+	GetStatus(ctx context.Context, reference string) (InterbankTransfer, error)
+}
+
+// RTGSService is the adapter for large-value interbank transfers through RTGS.
+type RTGSService interface {
+	// Settle sends the transfer for gross settlement.
+	Settle(ctx context.Context, tf InterbankTransfer) (InterbankTransfer, error)
+
+	// GetStatus retrieves the status of a transfer by its transaction reference.
+	GetStatus(ctx context.Context, reference string) (InterbankTransfer, error)
 }
@@ -21,6 +21,169 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// ChargeFee provides a mock function with given fields: ctx, accountNumber, fee, remark
+func (_m *MockService) ChargeFee(ctx context.Context, accountNumber string, fee int64, remark string) error {
+	ret := _m.Called(ctx, accountNumber, fee, remark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargeFee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) error); ok {
+		r0 = rf(ctx, accountNumber, fee, remark)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_ChargeFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChargeFee'
+type MockService_ChargeFee_Call struct {
+	*mock.Call
+}
+
+// ChargeFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+//   - fee int64
+//   - remark string
+func (_e *MockService_Expecter) ChargeFee(ctx interface{}, accountNumber interface{}, fee interface{}, remark interface{}) *MockService_ChargeFee_Call {
+	return &MockService_ChargeFee_Call{Call: _e.mock.On("ChargeFee", ctx, accountNumber, fee, remark)}
+}
+
+func (_c *MockService_ChargeFee_Call) Run(run func(ctx context.Context, accountNumber string, fee int64, remark string)) *MockService_ChargeFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ChargeFee_Call) Return(_a0 error) *MockService_ChargeFee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ChargeFee_Call) RunAndReturn(run func(context.Context, string, int64, string) error) *MockService_ChargeFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferStatus provides a mock function with given fields: ctx, remark
+func (_m *MockService) GetTransferStatus(ctx context.Context, remark string) (Transfer, error) {
+	ret := _m.Called(ctx, remark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferStatus")
+	}
+
+	var r0 Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Transfer, error)); ok {
+		return rf(ctx, remark)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Transfer); ok {
+		r0 = rf(ctx, remark)
+	} else {
+		r0 = ret.Get(0).(Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, remark)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetTransferStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferStatus'
+type MockService_GetTransferStatus_Call struct {
+	*mock.Call
+}
+
+// GetTransferStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - remark string
+func (_e *MockService_Expecter) GetTransferStatus(ctx interface{}, remark interface{}) *MockService_GetTransferStatus_Call {
+	return &MockService_GetTransferStatus_Call{Call: _e.mock.On("GetTransferStatus", ctx, remark)}
+}
+
+func (_c *MockService_GetTransferStatus_Call) Run(run func(ctx context.Context, remark string)) *MockService_GetTransferStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_GetTransferStatus_Call) Return(_a0 Transfer, _a1 error) *MockService_GetTransferStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetTransferStatus_Call) RunAndReturn(run func(context.Context, string) (Transfer, error)) *MockService_GetTransferStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reverse provides a mock function with given fields: ctx, rv
+func (_m *MockService) Reverse(ctx context.Context, rv Reversal) (Transfer, error) {
+	ret := _m.Called(ctx, rv)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reverse")
+	}
+
+	var r0 Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Reversal) (Transfer, error)); ok {
+		return rf(ctx, rv)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Reversal) Transfer); ok {
+		r0 = rf(ctx, rv)
+	} else {
+		r0 = ret.Get(0).(Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Reversal) error); ok {
+		r1 = rf(ctx, rv)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Reverse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reverse'
+type MockService_Reverse_Call struct {
+	*mock.Call
+}
+
+// Reverse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rv Reversal
+func (_e *MockService_Expecter) Reverse(ctx interface{}, rv interface{}) *MockService_Reverse_Call {
+	return &MockService_Reverse_Call{Call: _e.mock.On("Reverse", ctx, rv)}
+}
+
+func (_c *MockService_Reverse_Call) Run(run func(ctx context.Context, rv Reversal)) *MockService_Reverse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Reversal))
+	})
+	return _c
+}
+
+func (_c *MockService_Reverse_Call) Return(_a0 Transfer, _a1 error) *MockService_Reverse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Reverse_Call) RunAndReturn(run func(context.Context, Reversal) (Transfer, error)) *MockService_Reverse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Transfer provides a mock function with given fields: ctx, srcAccountNumber, destAccountNumber, amount, remark
 func (_m *MockService) Transfer(ctx context.Context, srcAccountNumber string, destAccountNumber string, amount int64, remark string) (Transfer, error) {
 	ret := _m.Called(ctx, srcAccountNumber, destAccountNumber, amount, remark)
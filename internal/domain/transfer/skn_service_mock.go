@@ -0,0 +1,150 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package transfer
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSKNService is an autogenerated mock type for the SKNService type
+type MockSKNService struct {
+	mock.Mock
+}
+
+type MockSKNService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSKNService) EXPECT() *MockSKNService_Expecter {
+	return &MockSKNService_Expecter{mock: &_m.Mock}
+}
+
+// GetStatus provides a mock function with given fields: ctx, reference
+func (_m *MockSKNService) GetStatus(ctx context.Context, reference string) (InterbankTransfer, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 InterbankTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (InterbankTransfer, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) InterbankTransfer); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		r0 = ret.Get(0).(InterbankTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSKNService_GetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatus'
+type MockSKNService_GetStatus_Call struct {
+	*mock.Call
+}
+
+// GetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockSKNService_Expecter) GetStatus(ctx interface{}, reference interface{}) *MockSKNService_GetStatus_Call {
+	return &MockSKNService_GetStatus_Call{Call: _e.mock.On("GetStatus", ctx, reference)}
+}
+
+func (_c *MockSKNService_GetStatus_Call) Run(run func(ctx context.Context, reference string)) *MockSKNService_GetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSKNService_GetStatus_Call) Return(_a0 InterbankTransfer, _a1 error) *MockSKNService_GetStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSKNService_GetStatus_Call) RunAndReturn(run func(context.Context, string) (InterbankTransfer, error)) *MockSKNService_GetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Submit provides a mock function with given fields: ctx, tf
+func (_m *MockSKNService) Submit(ctx context.Context, tf InterbankTransfer) (InterbankTransfer, error) {
+	ret := _m.Called(ctx, tf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Submit")
+	}
+
+	var r0 InterbankTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, InterbankTransfer) (InterbankTransfer, error)); ok {
+		return rf(ctx, tf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, InterbankTransfer) InterbankTransfer); ok {
+		r0 = rf(ctx, tf)
+	} else {
+		r0 = ret.Get(0).(InterbankTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, InterbankTransfer) error); ok {
+		r1 = rf(ctx, tf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSKNService_Submit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Submit'
+type MockSKNService_Submit_Call struct {
+	*mock.Call
+}
+
+// Submit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tf InterbankTransfer
+func (_e *MockSKNService_Expecter) Submit(ctx interface{}, tf interface{}) *MockSKNService_Submit_Call {
+	return &MockSKNService_Submit_Call{Call: _e.mock.On("Submit", ctx, tf)}
+}
+
+func (_c *MockSKNService_Submit_Call) Run(run func(ctx context.Context, tf InterbankTransfer)) *MockSKNService_Submit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(InterbankTransfer))
+	})
+	return _c
+}
+
+func (_c *MockSKNService_Submit_Call) Return(_a0 InterbankTransfer, _a1 error) *MockSKNService_Submit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSKNService_Submit_Call) RunAndReturn(run func(context.Context, InterbankTransfer) (InterbankTransfer, error)) *MockSKNService_Submit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSKNService creates a new instance of MockSKNService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSKNService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSKNService {
+	mock := &MockSKNService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,5 +1,34 @@
 package transfer
 
+const (
+	// RailInternal represents a transfer between accounts held in the same bank.
+	RailInternal = "internal"
+	// RailBIFast represents a real-time interbank transfer through BI-FAST.
+	RailBIFast = "bifast"
+	// RailSKN represents a batch interbank transfer through SKN clearing.
+	RailSKN = "skn"
+	// RailRTGS represents a large-value interbank transfer through RTGS.
+	RailRTGS = "rtgs"
+)
+
+const (
+	// RailStatusSubmitted represents a transfer accepted by the rail for processing.
+	RailStatusSubmitted = "submitted"
+	// RailStatusQueued represents a transfer waiting for the next SKN clearing batch.
+	RailStatusQueued = "queued"
+	// RailStatusSettled represents a transfer settled at the destination bank.
+	RailStatusSettled = "settled"
+	// RailStatusRejected represents a transfer rejected by the rail or the destination bank.
+	RailStatusRejected = "rejected"
+)
+
+const (
+	// StatusSuccess represents a transfer executed by the core banking system.
+	StatusSuccess = "success"
+	// StatusFailed represents a transfer refused by the core banking system.
+	StatusFailed = "failed"
+)
+
 // Transfer represents a money transfer between accounts.
 type Transfer struct {
 	SourceAccount        string
@@ -11,3 +40,59 @@ type Transfer struct {
 	TransactionID        string
 	TransactionReference string
 }
+
+// Succeeded returns true if the transfer was executed.
+func (t Transfer) Succeeded() bool {
+	return t.Status == StatusSuccess
+}
+
+// Failed returns true if the transfer was refused.
+func (t Transfer) Failed() bool {
+	return t.Status == StatusFailed
+}
+
+// Reversal represents the return of a completed transfer to its source account.
+type Reversal struct {
+	// TransactionReference is the reference of the transfer being reversed.
+	TransactionReference string
+	SourceAccount        string
+	DestinationAccount   string
+	Amount               int64
+	// Fee is the part of the transfer fee returned with the amount.
+	Fee    int64
+	Remark string
+}
+
+// InterbankTransfer represents a money transfer to an account held in another bank.
+//
+// The status lifecycle depends on the rail:
+//   - BI-FAST: submitted -> settled | rejected
+//   - SKN: submitted -> queued -> settled | rejected
+//   - RTGS: submitted -> settled | rejected
+type InterbankTransfer struct {
+	SourceAccount        string
+	DestinationBankCode  string
+	DestinationAccount   string
+	Amount               int64
+	Remark               string
+	Status               string
+	TransactionReference string
+}
+
+// Settled returns true if the transfer is settled at the destination bank.
+func (t InterbankTransfer) Settled() bool {
+	return t.Status == RailStatusSettled
+}
+
+// Rejected returns true if the transfer is rejected by the rail.
+func (t InterbankTransfer) Rejected() bool {
+	return t.Status == RailStatusRejected
+}
+
+// InterbankAccount represents an account held in another bank,
+// as returned by the account inquiry of the rail.
+type InterbankAccount struct {
+	BankCode      string
+	AccountNumber string
+	Name          string
+}
@@ -0,0 +1,12 @@
+// Package uow contains the unit of work used to group writes in one database transaction.
+package uow
+
+import "context"
+
+// UnitOfWork runs several repository writes atomically.
+type UnitOfWork interface {
+	// Do runs fn in a database transaction, committed when fn returns nil and rolled back otherwise.
+	// The repositories called with the context passed to fn take part in the transaction.
+	// Calling Do inside fn joins the outer transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -24,6 +24,8 @@ type Repository interface {
 	// GetByUsername retrieves a user by their username.
 	GetByUsername(ctx context.Context, username string) (User, error)
 
+	// GetCustomers retrieves the users with the customer role and a CIF.
+	GetCustomers(ctx context.Context) ([]User, error)
 	// GetFieldsByUsername retrieves a user's fields by their username.
 	GetFieldsByUsername(ctx context.Context, username string, fields ...string) (User, error)
 
@@ -172,6 +172,64 @@ func (_c *MockRepository_GetByUsername_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetCustomers provides a mock function with given fields: ctx
+func (_m *MockRepository) GetCustomers(ctx context.Context) ([]User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomers")
+	}
+
+	var r0 []User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetCustomers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCustomers'
+type MockRepository_GetCustomers_Call struct {
+	*mock.Call
+}
+
+// GetCustomers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) GetCustomers(ctx interface{}) *MockRepository_GetCustomers_Call {
+	return &MockRepository_GetCustomers_Call{Call: _e.mock.On("GetCustomers", ctx)}
+}
+
+func (_c *MockRepository_GetCustomers_Call) Run(run func(ctx context.Context)) *MockRepository_GetCustomers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCustomers_Call) Return(_a0 []User, _a1 error) *MockRepository_GetCustomers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetCustomers_Call) RunAndReturn(run func(context.Context) ([]User, error)) *MockRepository_GetCustomers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFieldsByUsername provides a mock function with given fields: ctx, username, fields
 func (_m *MockRepository) GetFieldsByUsername(ctx context.Context, username string, fields ...string) (User, error) {
 	_va := make([]interface{}, len(fields))
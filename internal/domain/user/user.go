@@ -8,6 +8,13 @@ import (
 
 const StatusActive = "active"
 
+const (
+	// RoleCustomer is the role of bank customers, the default role.
+	RoleCustomer = "customer"
+	// RoleOps is the role of bank operations staff.
+	RoleOps = "ops"
+)
+
 // User represents a user in the system.
 type User struct {
 	UUID        string
@@ -19,6 +26,7 @@ type User struct {
 	Password    string
 	CIF         string
 	Status      string
+	Role        string
 	Address     string
 	DateOfBirth time.Time
 	LastLogin   time.Time
@@ -37,6 +45,14 @@ func (u *User) IsActive() bool {
 	return u.Status == "active"
 }
 
+// HasRole checks if the user has the role. Users without a role are customers.
+func (u *User) HasRole(role string) bool {
+	if u.Role == "" {
+		return role == RoleCustomer
+	}
+	return u.Role == role
+}
+
 // IsInactive checks if the user is inactive.
 func (u *User) IsInactive() bool {
 	return u.Status == "inactive"
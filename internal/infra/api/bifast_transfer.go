@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+)
+
+// BIFastTransferAPI is the BI-FAST service API for real-time interbank transfers.
+type BIFastTransferAPI struct{}
+
+func NewBIFastTransferAPI() *BIFastTransferAPI {
+	return &BIFastTransferAPI{}
+}
+
+func (api *BIFastTransferAPI) CreditTransfer(ctx context.Context, tf transfer.InterbankTransfer) (transfer.InterbankTransfer, error) {
+	tf.Status = transfer.RailStatusSettled
+	tf.TransactionReference = "BIFAST-" + uuid.New().String()
+	return tf, nil
+}
+
+func (api *BIFastTransferAPI) GetStatus(ctx context.Context, reference string) (transfer.InterbankTransfer, error) {
+	return transfer.InterbankTransfer{
+		Status:               transfer.RailStatusSettled,
+		TransactionReference: reference,
+	}, nil
+}
+
+func (api *BIFastTransferAPI) AccountInquiry(ctx context.Context, bankCode, accountNumber string) (transfer.InterbankAccount, error) {
+	return transfer.InterbankAccount{
+		BankCode:      bankCode,
+		AccountNumber: accountNumber,
+		Name:          "BIFAST ACCOUNT " + accountNumber,
+	}, nil
+}
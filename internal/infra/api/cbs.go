@@ -0,0 +1,50 @@
+package api
+
+import (
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/cbssim"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/resilience"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+)
+
+// NewAccountRepository returns the core banking simulator when it is enabled by the configuration,
+// the core banking system API otherwise, behind a circuit breaker.
+func NewAccountRepository(cfg *config.Configs, sim *cbssim.Simulator, registry *resilience.Registry) account.Repository {
+	var repo account.Repository = NewCBSAccountAPI()
+	if cfg.CBS.Simulator {
+		repo = sim
+	}
+	return resilience.NewAccountRepository(repo, registry)
+}
+
+// NewCBSService returns the core banking simulator when it is enabled by the configuration,
+// the core banking system API otherwise, behind a circuit breaker and the status cache.
+func NewCBSService(cfg *config.Configs, sim *cbssim.Simulator, registry *resilience.Registry) cbs.Service {
+	var svc cbs.Service = NewCBSStatusAPI()
+	if cfg.CBS.Simulator {
+		svc = sim
+	}
+	return resilience.NewStatusCache(resilience.NewCBSService(svc, registry), cfg.CBS.StatusTTL)
+}
+
+// NewTransferService returns the core banking simulator when it is enabled by the configuration,
+// the core banking system API otherwise, behind a circuit breaker.
+func NewTransferService(cfg *config.Configs, sim *cbssim.Simulator, registry *resilience.Registry) transfer.Service {
+	var svc transfer.Service = NewCBSTransferAPI()
+	if cfg.CBS.Simulator {
+		svc = sim
+	}
+	return resilience.NewTransferService(svc, registry)
+}
+
+// NewDepositService returns the core banking simulator when it is enabled by the configuration,
+// the core banking system API otherwise.
+func NewDepositService(cfg *config.Configs, sim *cbssim.Simulator) deposit.Service {
+	if cfg.CBS.Simulator {
+		return sim
+	}
+	return NewCBSDepositAPI()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/rand"
 	"strings"
+	"sync"
 
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
 )
@@ -11,19 +12,44 @@ import (
 const digits = "0123456789"
 
 // CBSAccountAPI is the core banking system service API for getting account information.
-type CBSAccountAPI struct{}
+type CBSAccountAPI struct {
+	mu sync.Mutex
+	// owners maps the accounts listed or opened to the CIF of their owner,
+	// so an account is returned with the owner it was listed for.
+	owners map[string]string
+}
 
 // NewAccountAPI creates a new instance of the AccountAPI.
 func NewCBSAccountAPI() *CBSAccountAPI {
-	return &CBSAccountAPI{}
+	return &CBSAccountAPI{
+		owners: make(map[string]string),
+	}
 }
 
 func (api *CBSAccountAPI) Get(ctx context.Context, accountNumber string) (account.Account, error) {
 	return account.Account{
-		AccountNumber: accountNumber,
-		FullName:      "John Doe",
-		Type:          "Savings",
-		Balance:       10000000,
+		CIF:              api.owner(accountNumber),
+		AccountNumber:    accountNumber,
+		FullName:         "John Doe",
+		Type:             account.TypeSavings,
+		Status:           account.StatusActive,
+		Balance:          10000000,
+		AvailableBalance: 10000000,
+	}, nil
+}
+
+func (api *CBSAccountAPI) ListByCIF(ctx context.Context, cif string) ([]account.Account, error) {
+	api.setOwner("1000000015", cif)
+	return []account.Account{
+		{
+			CIF:              cif,
+			AccountNumber:    "1000000015",
+			FullName:         "John Doe",
+			Type:             account.TypeSavings,
+			Status:           account.StatusActive,
+			Balance:          10000000,
+			AvailableBalance: 10000000,
+		},
 	}, nil
 }
 
@@ -37,3 +63,48 @@ func (api *CBSAccountAPI) Create(ctx context.Context, username string) (account.
 		CIF: cifBuilder.String(),
 	}, nil
 }
+
+func (api *CBSAccountAPI) PlaceHold(ctx context.Context, accountNumber, reference string, amount int64) error {
+	return nil
+}
+
+func (api *CBSAccountAPI) CaptureHold(ctx context.Context, reference string) error {
+	return nil
+}
+
+func (api *CBSAccountAPI) ReleaseHold(ctx context.Context, reference string) error {
+	return nil
+}
+
+func (api *CBSAccountAPI) Open(ctx context.Context, acc account.Account) (account.Account, error) {
+	for acc.AccountNumber == "" {
+		acc.AccountNumber, _ = account.NewNumber(rand.Int63n(10_000_000))
+	}
+	acc.Status = account.StatusActive
+	api.setOwner(acc.AccountNumber, acc.CIF)
+	return acc, nil
+}
+
+func (api *CBSAccountAPI) Rename(ctx context.Context, accountNumber, name string) error {
+	return nil
+}
+
+func (api *CBSAccountAPI) SetStatus(ctx context.Context, accountNumber, status string) error {
+	return nil
+}
+
+func (api *CBSAccountAPI) Close(ctx context.Context, accountNumber string) error {
+	return nil
+}
+
+func (api *CBSAccountAPI) owner(accountNumber string) string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.owners[accountNumber]
+}
+
+func (api *CBSAccountAPI) setOwner(accountNumber, cif string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.owners[accountNumber] = cif
+}
@@ -0,0 +1,14 @@
+package api
+
+import "context"
+
+// CBSDepositAPI is the core banking system service API for time deposits.
+type CBSDepositAPI struct{}
+
+func NewCBSDepositAPI() *CBSDepositAPI {
+	return &CBSDepositAPI{}
+}
+
+func (api *CBSDepositAPI) CreditInterest(ctx context.Context, accountNumber string, gross, tax int64, reference string) error {
+	return nil
+}
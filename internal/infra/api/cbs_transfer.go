@@ -17,7 +17,30 @@ func (ta *CBSTransferAPI) Transfer(ctx context.Context, srcAccountNumber, destAc
 		SourceAccount:        srcAccountNumber,
 		DestinationAccount:   destAccountNumber,
 		Amount:               amount,
-		Status:               "success",
+		Status:               transfer.StatusSuccess,
 		TransactionReference: "example-ref-123",
 	}, nil
 }
+
+func (ta *CBSTransferAPI) GetTransferStatus(ctx context.Context, remark string) (transfer.Transfer, error) {
+	return transfer.Transfer{
+		Status:               transfer.StatusSuccess,
+		Notes:                remark,
+		TransactionReference: "example-ref-123",
+	}, nil
+}
+
+func (ta *CBSTransferAPI) Reverse(ctx context.Context, rv transfer.Reversal) (transfer.Transfer, error) {
+	return transfer.Transfer{
+		SourceAccount:        rv.DestinationAccount,
+		DestinationAccount:   rv.SourceAccount,
+		Amount:               rv.Amount + rv.Fee,
+		Status:               transfer.StatusSuccess,
+		Notes:                rv.Remark,
+		TransactionReference: "example-rev-123",
+	}, nil
+}
+
+func (ta *CBSTransferAPI) ChargeFee(ctx context.Context, accountNumber string, fee int64, remark string) error {
+	return nil
+}
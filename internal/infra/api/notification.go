@@ -0,0 +1,24 @@
+package api
+
+import (
+	"context"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+)
+
+// NotificationAPI is the notification service API for sending messages to users.
+type NotificationAPI struct{}
+
+func NewNotificationAPI() *NotificationAPI {
+	return &NotificationAPI{}
+}
+
+func (api *NotificationAPI) Send(ctx context.Context, n notification.Notification) error {
+	l := log.WithContext(ctx, "NotificationAPI.Send")
+	l.Info().
+		Str("username", n.Username).
+		Str("title", n.Title).
+		Msg(n.Message)
+	return nil
+}
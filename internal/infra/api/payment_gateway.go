@@ -2,31 +2,125 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/resilience"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	tapmoney "go.bankkrud.com/bankkrud/backend/krudapp/pkg/api"
+	"go.bankkrud.com/bankkrud/backend/krudapp/pkg/signature"
 )
 
 // PaymentGateway is a middleware payment gateway.
-type PaymentGateway struct{}
+type PaymentGateway struct {
+	// tapMoney sends the inquiries and payments to the TapMoney service, nil answers them locally.
+	tapMoney tapmoney.Service
+}
 
+// NewPaymentGateway returns the payment gateway of the TapMoney service of the configuration,
+// it answers locally when no address is configured. Every attempt of a request is signed
+// when a signing secret is configured.
 func NewPaymentGateway(cfg *config.Configs, client *http.Client) *PaymentGateway {
-	return &PaymentGateway{}
+	if cfg.TapMoney.Addr == "" {
+		return &PaymentGateway{}
+	}
+
+	retry := tapmoney.DefaultRetryPolicy
+	if cfg.TapMoney.RetryAttempts > 0 {
+		retry = tapmoney.RetryPolicy{
+			MaxAttempts: cfg.TapMoney.RetryAttempts,
+			BaseDelay:   cfg.TapMoney.RetryBaseDelay,
+			MaxDelay:    cfg.TapMoney.RetryMaxDelay,
+		}
+	}
+	opts := []tapmoney.Option{tapmoney.WithRetryPolicy(retry)}
+	if cfg.TapMoney.SigningSecret != "" {
+		opts = append(opts, tapmoney.WithSigner(signature.NewSigner(cfg.TapMoney.SigningKeyID, cfg.TapMoney.SigningSecret)))
+	}
+	return &PaymentGateway{
+		tapMoney: tapmoney.NewClient(client, cfg.TapMoney.Addr, opts...),
+	}
+}
+
+// NewPaymentService returns the payment gateway behind a circuit breaker.
+func NewPaymentService(cfg *config.Configs, client *http.Client, registry *resilience.Registry) payment.Service {
+	return resilience.NewPaymentService(NewPaymentGateway(cfg, client), registry)
 }
 
 func (pg *PaymentGateway) Inquiry(ctx context.Context, channel payment.Channel, bill payment.Bill) (payment.Payment, error) {
+	if pg.tapMoney == nil {
+		return payment.Payment{
+			ID:      uuid.New().String(),
+			Status:  "success",
+			Channel: channel,
+			Bill:    bill,
+		}, nil
+	}
+
+	res, err := pg.tapMoney.Inquiry(ctx, tapmoney.InquiryRequest{
+		CardNumber:    bill.DestinationAccount,
+		SourceAccount: bill.SourceAccount,
+		Amount:        bill.Amount,
+	})
+	if err != nil {
+		return payment.Payment{}, err
+	}
 	return payment.Payment{
-		ID:      uuid.New().String(),
-		Status:  "success",
+		ID:      res.Data.SequenceNumber,
+		Status:  res.Data.Status,
 		Channel: channel,
 		Bill:    bill,
 	}, nil
 }
 
+// Payment pays the bill with its reference as the TapMoney transaction ID,
+// so the retries of the client do not pay it twice.
+// A payment TapMoney did not definitely refuse fails with payment.ErrOutcomeUnknown.
 func (pg *PaymentGateway) Payment(ctx context.Context, bill payment.Bill) (payment.Payment, error) {
+	if pg.tapMoney == nil {
+		return payment.Payment{
+			ID: uuid.New().String(),
+		}, nil
+	}
+
+	res, err := pg.tapMoney.Payment(ctx, tapmoney.PaymentRequest{
+		TransactionID: bill.ReferenceID,
+		Amount:        bill.Amount,
+	})
+	if err != nil {
+		return payment.Payment{}, paymentError(err)
+	}
+	bill.Fee = res.Data.Fee
+	return payment.Payment{
+		ID:     res.Data.TransactionID,
+		Status: res.Data.Status,
+		Bill:   bill,
+	}, nil
+}
+
+// paymentError wraps the error of a payment in payment.ErrOutcomeUnknown unless TapMoney refused it,
+// a timeout or a server error may come after an attempt of the payment was executed.
+func paymentError(err error) error {
+	if errors.Is(err, tapmoney.ErrBadRequest) ||
+		errors.Is(err, tapmoney.ErrUnauthorized) ||
+		errors.Is(err, tapmoney.ErrNotFound) ||
+		errors.Is(err, tapmoney.ErrConflict) ||
+		errors.Is(err, tapmoney.ErrRejected) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", payment.ErrOutcomeUnknown, err)
+}
+
+func (pg *PaymentGateway) CancelInquiry(ctx context.Context, paymentID string) error {
+	return nil
+}
+
+func (pg *PaymentGateway) Reverse(ctx context.Context, rv payment.Reversal) (payment.Payment, error) {
 	return payment.Payment{
-		ID: uuid.New().String(),
+		ID:     uuid.New().String(),
+		Status: "success",
 	}, nil
 }
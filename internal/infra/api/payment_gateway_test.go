@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	tapmoney "go.bankkrud.com/bankkrud/backend/krudapp/pkg/api"
+	"go.bankkrud.com/bankkrud/backend/krudapp/pkg/signature"
+)
+
+// memoryNonces is a NonceStore in memory.
+type memoryNonces map[string]bool
+
+func (m memoryNonces) Use(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	if m[keyID+nonce] {
+		return false, nil
+	}
+	m[keyID+nonce] = true
+	return true, nil
+}
+
+func TestPaymentGateway_Payment(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(tapmoney.HeaderIdempotencyKey))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"transactionID":"pay-123","status":"success","amount":10000,"fee":1500}}`))
+	}))
+	defer srv.Close()
+
+	cfg := new(config.Configs)
+	cfg.TapMoney.Addr = srv.URL
+	cfg.TapMoney.RetryAttempts = 2
+	cfg.TapMoney.RetryBaseDelay = time.Millisecond
+	pg := NewPaymentGateway(cfg, srv.Client())
+
+	res, err := pg.Payment(context.Background(), payment.Bill{ReferenceID: "pay-123", Amount: 10000})
+
+	if err != nil {
+		t.Fatalf("Payment() error = %v", err)
+	}
+	if res.ID != "pay-123" || res.Status != "success" || res.Bill.Fee != 1500 {
+		t.Errorf("Payment() = %+v, want pay-123 success with a fee of 1500", res)
+	}
+	if len(keys) != 2 || keys[0] != "pay-123" || keys[1] != "pay-123" {
+		t.Errorf("idempotency keys = %v, want pay-123 on both attempts", keys)
+	}
+}
+
+func TestPaymentGateway_PaymentFailed(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantUnknown bool
+	}{
+		{name: "bad_request", status: http.StatusBadRequest},
+		{name: "unauthorized", status: http.StatusUnauthorized},
+		{name: "conflict", status: http.StatusConflict},
+		{name: "rejected", status: http.StatusOK, body: `{"success":false,"error":{"name":"LIMIT","message":"card limit reached"}}`},
+		{name: "too_many_requests", status: http.StatusTooManyRequests, wantUnknown: true},
+		{name: "server_error", status: http.StatusInternalServerError, wantUnknown: true},
+		{name: "gateway_timeout", status: http.StatusGatewayTimeout, wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			cfg := new(config.Configs)
+			cfg.TapMoney.Addr = srv.URL
+			cfg.TapMoney.RetryAttempts = 1
+			pg := NewPaymentGateway(cfg, srv.Client())
+
+			_, err := pg.Payment(context.Background(), payment.Bill{ReferenceID: "pay-123", Amount: 10000})
+
+			if err == nil {
+				t.Fatal("Payment() error = nil, want an error")
+			}
+			if got := errors.Is(err, payment.ErrOutcomeUnknown); got != tt.wantUnknown {
+				t.Errorf("Payment() error = %v, outcome unknown = %t, want %t", err, got, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestPaymentGateway_Signed(t *testing.T) {
+	v := signature.NewVerifier(map[string]string{"krudapp": "secret"}, time.Minute, memoryNonces{})
+	var verifyErrs []error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyErrs = append(verifyErrs, v.Verify(r))
+		if len(verifyErrs) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"sequenceNumber":"pay-123","status":"success"}}`))
+	}))
+	defer srv.Close()
+
+	cfg := new(config.Configs)
+	cfg.TapMoney.Addr = srv.URL
+	cfg.TapMoney.SigningKeyID = "krudapp"
+	cfg.TapMoney.SigningSecret = "secret"
+	cfg.TapMoney.RetryAttempts = 2
+	cfg.TapMoney.RetryBaseDelay = time.Millisecond
+	pg := NewPaymentGateway(cfg, srv.Client())
+
+	res, err := pg.Inquiry(context.Background(), payment.Channel{ID: "01"}, payment.Bill{DestinationAccount: "6013501000500719", Amount: 10000})
+
+	if err != nil {
+		t.Fatalf("Inquiry() error = %v", err)
+	}
+	if res.ID != "pay-123" {
+		t.Errorf("Inquiry() ID = %q, want pay-123", res.ID)
+	}
+	if len(verifyErrs) != 2 {
+		t.Fatalf("service called %d times, want 2", len(verifyErrs))
+	}
+	for i, err := range verifyErrs {
+		if err != nil {
+			t.Errorf("attempt %d Verify() error = %v, want every attempt signed with a fresh nonce", i, err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+)
+
+// RTGSTransferAPI is the RTGS service API for large-value interbank transfers.
+type RTGSTransferAPI struct{}
+
+func NewRTGSTransferAPI() *RTGSTransferAPI {
+	return &RTGSTransferAPI{}
+}
+
+func (api *RTGSTransferAPI) Settle(ctx context.Context, tf transfer.InterbankTransfer) (transfer.InterbankTransfer, error) {
+	tf.Status = transfer.RailStatusSettled
+	tf.TransactionReference = "RTGS-" + uuid.New().String()
+	return tf, nil
+}
+
+func (api *RTGSTransferAPI) GetStatus(ctx context.Context, reference string) (transfer.InterbankTransfer, error) {
+	return transfer.InterbankTransfer{
+		Status:               transfer.RailStatusSettled,
+		TransactionReference: reference,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+)
+
+// SKNTransferAPI is the SKN clearing service API for batch interbank transfers.
+type SKNTransferAPI struct{}
+
+func NewSKNTransferAPI() *SKNTransferAPI {
+	return &SKNTransferAPI{}
+}
+
+func (api *SKNTransferAPI) Submit(ctx context.Context, tf transfer.InterbankTransfer) (transfer.InterbankTransfer, error) {
+	tf.Status = transfer.RailStatusQueued
+	tf.TransactionReference = "SKN-" + uuid.New().String()
+	return tf, nil
+}
+
+func (api *SKNTransferAPI) GetStatus(ctx context.Context, reference string) (transfer.InterbankTransfer, error) {
+	return transfer.InterbankTransfer{
+		Status:               transfer.RailStatusSettled,
+		TransactionReference: reference,
+	}, nil
+}
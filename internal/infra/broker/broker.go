@@ -0,0 +1,26 @@
+// Package broker contains the message brokers the outbox events are published to.
+package broker
+
+import (
+	"github.com/redis/go-redis/v9"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+)
+
+const (
+	// KindRedis publishes the events to a Redis stream.
+	KindRedis = "redis"
+	// KindMemory keeps the events in memory.
+	KindMemory = "memory"
+)
+
+// NewPublisher returns the outbox publisher chosen by the configuration,
+// Redis Streams by default.
+func NewPublisher(cfg *config.Configs, rdb *redis.Client) outbox.Publisher {
+	switch cfg.Outbox.Broker {
+	case KindMemory:
+		return NewMemoryBroker()
+	default:
+		return NewRedisStreamBroker(cfg, rdb)
+	}
+}
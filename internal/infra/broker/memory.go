@@ -0,0 +1,32 @@
+package broker
+
+import (
+	"context"
+	"sync"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
+)
+
+// MemoryBroker keeps the published events in memory, for tests and local runs.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	events []outbox.Event
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, e outbox.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, e)
+	return nil
+}
+
+// Events returns the published events in publishing order.
+func (b *MemoryBroker) Events() []outbox.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]outbox.Event(nil), b.events...)
+}
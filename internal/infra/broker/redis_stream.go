@@ -0,0 +1,41 @@
+package broker
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+)
+
+// RedisStreamBroker publishes the events to a Redis stream.
+// Consumers read the stream in order and deduplicate by event_id.
+type RedisStreamBroker struct {
+	stream string
+	maxLen int64
+	rdb    *redis.Client
+}
+
+func NewRedisStreamBroker(cfg *config.Configs, rdb *redis.Client) *RedisStreamBroker {
+	return &RedisStreamBroker{
+		stream: cfg.Outbox.Stream,
+		maxLen: cfg.Outbox.StreamMaxLen,
+		rdb:    rdb,
+	}
+}
+
+func (b *RedisStreamBroker) Publish(ctx context.Context, e outbox.Event) error {
+	return b.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: b.stream,
+		MaxLen: b.maxLen,
+		Approx: b.maxLen > 0,
+		Values: map[string]any{
+			"event_id":       strconv.FormatInt(e.ID, 10),
+			"aggregate_type": e.AggregateType,
+			"aggregate_uuid": e.AggregateUUID,
+			"event_type":     e.Type,
+			"payload":        string(e.Payload),
+		},
+	}).Err()
+}
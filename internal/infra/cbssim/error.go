@@ -0,0 +1,89 @@
+package cbssim
+
+import (
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+)
+
+// Response codes of the simulator, following the ISO 8583 response codes of the core banking system.
+const (
+	// CodeInvalidTransaction is returned for a request the account does not allow,
+	// e.g. a pocket under a pocket or closing an account with money.
+	CodeInvalidTransaction = "12"
+	// CodeInvalidAmount is returned for a zero or negative amount.
+	CodeInvalidAmount = "13"
+	// CodeInvalidAccount is returned for an unknown account.
+	CodeInvalidAccount = "14"
+	// CodeRecordNotFound is returned for an unknown hold or transfer.
+	CodeRecordNotFound = "25"
+	// CodeInsufficientFunds is returned when the balance does not cover the amount.
+	CodeInsufficientFunds = "51"
+	// CodeRestrictedAccount is returned for a closed account, or an account whose status
+	// does not allow the debit or credit.
+	CodeRestrictedAccount = "62"
+	// CodeSystemUnavailable is returned during the end of day without stand-in.
+	CodeSystemUnavailable = "91"
+	// CodeDuplicateTransaction is returned for a hold placed twice with different amounts.
+	CodeDuplicateTransaction = "94"
+	// CodeSystemMalfunction is returned for an unexpected failure.
+	CodeSystemMalfunction = "96"
+)
+
+var codeMessages = map[string]string{
+	CodeInvalidTransaction:   "invalid transaction",
+	CodeInvalidAmount:        "invalid amount",
+	CodeInvalidAccount:       "invalid account",
+	CodeRecordNotFound:       "record not found",
+	CodeInsufficientFunds:    "insufficient funds",
+	CodeRestrictedAccount:    "restricted account",
+	CodeSystemUnavailable:    "system unavailable",
+	CodeDuplicateTransaction: "duplicate transaction",
+	CodeSystemMalfunction:    "system malfunction",
+}
+
+// Error is an error response of the simulated core banking system.
+type Error struct {
+	Code    string
+	Message string
+	// err is the domain error the response code stands for.
+	err error
+}
+
+// NewError returns the error response with the code,
+// use it to inject a specific response code as a fault.
+func NewError(code string) *Error {
+	return &Error{
+		Code:    code,
+		Message: codeMessages[code],
+	}
+}
+
+func newError(code string, err error) *Error {
+	e := NewError(code)
+	e.err = err
+	return e
+}
+
+func (e *Error) Error() string {
+	return "cbs response " + e.Code + ": " + e.Message
+}
+
+// Unwrap returns the domain error of the response code,
+// so callers match simulator errors with errors.Is like the errors of the other adapters.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+var (
+	errAccountNotFound   = newError(CodeInvalidAccount, account.ErrNotFound)
+	errHoldNotFound      = newError(CodeRecordNotFound, account.ErrHoldNotFound)
+	errTransferNotFound  = newError(CodeRecordNotFound, transfer.ErrTransferNotFound)
+	errInsufficientFunds = newError(CodeInsufficientFunds, account.ErrInsufficientBalance)
+	errInvalidAmount     = NewError(CodeInvalidAmount)
+	errInvalidAccount    = NewError(CodeInvalidTransaction)
+	errBalanceNotZero    = newError(CodeInvalidTransaction, account.ErrBalanceNotZero)
+	errAccountClosed     = newError(CodeRestrictedAccount, account.ErrRestricted)
+	errAccountRestricted = newError(CodeRestrictedAccount, account.ErrRestricted)
+	errSystemUnavailable = NewError(CodeSystemUnavailable)
+	errDuplicateHold     = NewError(CodeDuplicateTransaction)
+)
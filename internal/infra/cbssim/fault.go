@@ -0,0 +1,178 @@
+package cbssim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Operations of the simulator, faults are injected per operation.
+const (
+	OpGetAccount        = "get_account"
+	OpListAccounts      = "list_accounts"
+	OpCreateCustomer    = "create_customer"
+	OpOpenAccount       = "open_account"
+	OpRenameAccount     = "rename_account"
+	OpCloseAccount      = "close_account"
+	OpSetAccountStatus  = "set_account_status"
+	OpPlaceHold         = "place_hold"
+	OpCaptureHold       = "capture_hold"
+	OpReleaseHold       = "release_hold"
+	OpGetStatus         = "get_status"
+	OpTransfer          = "transfer"
+	OpGetTransferStatus = "get_transfer_status"
+	OpReverse           = "reverse"
+	OpChargeFee         = "charge_fee"
+	OpCreditInterest    = "credit_interest"
+)
+
+var operations = []string{
+	OpGetAccount,
+	OpListAccounts,
+	OpCreateCustomer,
+	OpOpenAccount,
+	OpRenameAccount,
+	OpCloseAccount,
+	OpSetAccountStatus,
+	OpPlaceHold,
+	OpCaptureHold,
+	OpReleaseHold,
+	OpGetStatus,
+	OpTransfer,
+	OpGetTransferStatus,
+	OpReverse,
+	OpChargeFee,
+	OpCreditInterest,
+}
+
+var (
+	errUnknownOperation = errors.New("unknown operation")
+	errUnknownCode      = errors.New("unknown response code")
+	errAmbiguousFault   = errors.New("fault with both a response code and a timeout")
+)
+
+// Fault is a failure injected into an operation of the simulator.
+type Fault struct {
+	// Latency delays the operation, a context deadline shorter than it times the call out.
+	Latency time.Duration
+	// Err is returned instead of the result, e.g. NewError(CodeSystemMalfunction)
+	// or context.DeadlineExceeded to simulate a timeout.
+	Err error
+	// Applied executes the operation before Err is returned,
+	// like a timeout after the core banking system committed the transfer.
+	Applied bool
+	// Times limits the fault to the next calls, zero injects it until the faults are cleared.
+	Times int
+}
+
+// FaultSpec describes a fault by its response code, so it can be given by the configuration or a request.
+type FaultSpec struct {
+	Latency time.Duration
+	// Code is the response code returned instead of the result.
+	Code string
+	// Timeout returns context.DeadlineExceeded instead of the result.
+	Timeout bool
+	Applied bool
+	Times   int
+}
+
+// Fault returns the fault of the spec, or an error when the response code is unknown.
+func (spec FaultSpec) Fault() (Fault, error) {
+	f := Fault{
+		Latency: spec.Latency,
+		Applied: spec.Applied,
+		Times:   spec.Times,
+	}
+	switch {
+	case spec.Code != "" && spec.Timeout:
+		return Fault{}, errAmbiguousFault
+	case spec.Timeout:
+		f.Err = context.DeadlineExceeded
+	case spec.Code != "":
+		if _, ok := codeMessages[spec.Code]; !ok {
+			return Fault{}, fmt.Errorf("%w: %s", errUnknownCode, spec.Code)
+		}
+		f.Err = NewError(spec.Code)
+	}
+	return f, nil
+}
+
+// InjectFaultSpec injects the fault of the spec into the operation, it fails for an unknown operation or response code.
+func (s *Simulator) InjectFaultSpec(op string, spec FaultSpec) error {
+	if !slices.Contains(operations, op) {
+		return fmt.Errorf("%w: %s", errUnknownOperation, op)
+	}
+	f, err := spec.Fault()
+	if err != nil {
+		return err
+	}
+	s.InjectFault(op, f)
+	return nil
+}
+
+// InjectFault injects the fault into the operation, replacing the previous fault of the operation.
+func (s *Simulator) InjectFault(op string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[op] = &f
+}
+
+// ClearFaults removes the faults of every operation.
+func (s *Simulator) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[string]*Fault)
+}
+
+// takeFault returns the fault of the operation and waits out its latency.
+func (s *Simulator) takeFault(ctx context.Context, op string) (Fault, error) {
+	s.mu.Lock()
+	var f Fault
+	if injected, ok := s.faults[op]; ok {
+		f = *injected
+		if injected.Times > 0 {
+			injected.Times--
+			if injected.Times == 0 {
+				delete(s.faults, op)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if f.Latency <= 0 {
+		return f, ctx.Err()
+	}
+	timer := time.NewTimer(f.Latency)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return Fault{}, ctx.Err()
+	case <-timer.C:
+		return f, nil
+	}
+}
+
+// run executes the operation under the simulator lock with its injected fault.
+func run[T any](ctx context.Context, s *Simulator, op string, fn func() (T, error)) (T, error) {
+	var zero T
+	f, err := s.takeFault(ctx, op)
+	if err != nil {
+		return zero, err
+	}
+	if f.Err != nil && !f.Applied {
+		return zero, f.Err
+	}
+
+	s.mu.Lock()
+	res, err := fn()
+	s.mu.Unlock()
+	if err != nil {
+		return zero, err
+	}
+	if f.Err != nil {
+		return zero, f.Err
+	}
+	return res, nil
+}
@@ -0,0 +1,61 @@
+package cbssim
+
+import "time"
+
+// General ledger accounts on the other side of the postings that do not move money between customers.
+const (
+	// GLCash is debited by the opening balances.
+	GLCash = "GL-CASH"
+	// GLFeeIncome is credited with the fees of transfers and debited by the fees refunded with a reversal.
+	GLFeeIncome = "GL-FEE-INCOME"
+	// GLInterestExpense is debited by the interest of time deposits.
+	GLInterestExpense = "GL-INTEREST-EXPENSE"
+	// GLTaxPayable is credited with the tax withheld from the interest.
+	GLTaxPayable = "GL-TAX-PAYABLE"
+)
+
+// Entry is a line of the double-entry journal, every posting adds a debit and a credit line
+// of the same amount under the same reference.
+type Entry struct {
+	Reference     string
+	AccountNumber string
+	Debit         int64
+	Credit        int64
+	Remark        string
+	PostedAt      time.Time
+}
+
+// Journal returns a copy of the journal entries, oldest first.
+func (s *Simulator) Journal() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Entry(nil), s.journal...)
+}
+
+// post moves the amount from the debited to the credited account and records both entries.
+// Balances of the general ledger accounts are not tracked.
+func (s *Simulator) post(reference, debitAccount, creditAccount string, amount int64, remark string) {
+	now := time.Now()
+	if acc, ok := s.accounts[debitAccount]; ok {
+		acc.ledger -= amount
+	}
+	if acc, ok := s.accounts[creditAccount]; ok {
+		acc.ledger += amount
+	}
+	s.journal = append(s.journal,
+		Entry{
+			Reference:     reference,
+			AccountNumber: debitAccount,
+			Debit:         amount,
+			Remark:        remark,
+			PostedAt:      now,
+		},
+		Entry{
+			Reference:     reference,
+			AccountNumber: creditAccount,
+			Credit:        amount,
+			Remark:        remark,
+			PostedAt:      now,
+		},
+	)
+}
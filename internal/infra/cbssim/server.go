@@ -0,0 +1,226 @@
+package cbssim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+)
+
+type openAccountRequest struct {
+	CIF            string `json:"cif"`
+	FullName       string `json:"full_name"`
+	OpeningBalance int64  `json:"opening_balance"`
+}
+
+type standInRequest struct {
+	On bool `json:"on"`
+}
+
+type holdRequest struct {
+	AccountNumber string `json:"account_number"`
+	Reference     string `json:"reference"`
+	Amount        int64  `json:"amount"`
+}
+
+type transferRequest struct {
+	SourceAccount      string `json:"source_account"`
+	DestinationAccount string `json:"destination_account"`
+	Amount             int64  `json:"amount"`
+	Remark             string `json:"remark"`
+}
+
+type reversalRequest struct {
+	TransactionReference string `json:"transaction_reference"`
+	SourceAccount        string `json:"source_account"`
+	DestinationAccount   string `json:"destination_account"`
+	Amount               int64  `json:"amount"`
+	Fee                  int64  `json:"fee"`
+	Remark               string `json:"remark"`
+}
+
+type feeRequest struct {
+	AccountNumber string `json:"account_number"`
+	Fee           int64  `json:"fee"`
+	Remark        string `json:"remark"`
+}
+
+type faultRequest struct {
+	// Latency is a duration like 2s.
+	Latency string `json:"latency"`
+	Code    string `json:"code"`
+	Timeout bool   `json:"timeout"`
+	Applied bool   `json:"applied"`
+	Times   int    `json:"times"`
+}
+
+type errorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServer returns the HTTP API of the simulator for cmd/cbs-sim. Next to the operations of the
+// core banking system it seeds accounts, drives the end of day and injects faults at runtime.
+func NewServer(s *Simulator) *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+
+	e.GET("/status", func(c echo.Context) error {
+		res, err := s.GetStatus(c.Request().Context())
+		return respond(c, res, err)
+	})
+	e.POST("/eod/start", func(c echo.Context) error {
+		s.StartEOD()
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.POST("/eod/end", func(c echo.Context) error {
+		s.EndEOD()
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.PUT("/stand-in", func(c echo.Context) error {
+		var req standInRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		s.SetStandIn(req.On)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	e.POST("/accounts", func(c echo.Context) error {
+		var req openAccountRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		if req.CIF == "" || req.OpeningBalance < 0 {
+			return c.JSON(http.StatusBadRequest, errorResponse{Code: CodeInvalidTransaction, Message: codeMessages[CodeInvalidTransaction]})
+		}
+		return c.JSON(http.StatusCreated, s.OpenAccount(req.CIF, req.FullName, req.OpeningBalance))
+	})
+	e.GET("/accounts/:number", func(c echo.Context) error {
+		res, err := s.Get(c.Request().Context(), c.Param("number"))
+		return respond(c, res, err)
+	})
+	e.GET("/journal", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, s.Journal())
+	})
+
+	e.POST("/holds", func(c echo.Context) error {
+		var req holdRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		return respondErr(c, s.PlaceHold(c.Request().Context(), req.AccountNumber, req.Reference, req.Amount))
+	})
+	e.POST("/holds/:reference/capture", func(c echo.Context) error {
+		return respondErr(c, s.CaptureHold(c.Request().Context(), c.Param("reference")))
+	})
+	e.DELETE("/holds/:reference", func(c echo.Context) error {
+		return respondErr(c, s.ReleaseHold(c.Request().Context(), c.Param("reference")))
+	})
+
+	e.POST("/transfers", func(c echo.Context) error {
+		var req transferRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		res, err := s.Transfer(c.Request().Context(), req.SourceAccount, req.DestinationAccount, req.Amount, req.Remark)
+		return respond(c, res, err)
+	})
+	e.GET("/transfers/:remark", func(c echo.Context) error {
+		res, err := s.GetTransferStatus(c.Request().Context(), c.Param("remark"))
+		return respond(c, res, err)
+	})
+	e.POST("/reversals", func(c echo.Context) error {
+		var req reversalRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		res, err := s.Reverse(c.Request().Context(), transfer.Reversal{
+			TransactionReference: req.TransactionReference,
+			SourceAccount:        req.SourceAccount,
+			DestinationAccount:   req.DestinationAccount,
+			Amount:               req.Amount,
+			Fee:                  req.Fee,
+			Remark:               req.Remark,
+		})
+		return respond(c, res, err)
+	})
+
+	e.POST("/fees", func(c echo.Context) error {
+		var req feeRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		return respondErr(c, s.ChargeFee(c.Request().Context(), req.AccountNumber, req.Fee, req.Remark))
+	})
+
+	e.PUT("/faults/:op", func(c echo.Context) error {
+		var req faultRequest
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		spec := FaultSpec{
+			Code:    req.Code,
+			Timeout: req.Timeout,
+			Applied: req.Applied,
+			Times:   req.Times,
+		}
+		if req.Latency != "" {
+			latency, err := time.ParseDuration(req.Latency)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, errorResponse{Message: err.Error()})
+			}
+			spec.Latency = latency
+		}
+		err := s.InjectFaultSpec(c.Param("op"), spec)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, errorResponse{Message: err.Error()})
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.DELETE("/faults", func(c echo.Context) error {
+		s.ClearFaults()
+		return c.NoContent(http.StatusNoContent)
+	})
+	return e
+}
+
+// respond writes the result of an operation, or its error response.
+func respond[T any](c echo.Context, res T, err error) error {
+	if err != nil {
+		return respondErr(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// respondErr writes the error response of an operation, or no content when it succeeded.
+func respondErr(c echo.Context, err error) error {
+	if err == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return c.JSON(statusOf(e.Code), errorResponse{Code: e.Code, Message: e.Message})
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return c.JSON(http.StatusGatewayTimeout, errorResponse{Message: err.Error()})
+	}
+	return err
+}
+
+// statusOf returns the HTTP status of a response code.
+func statusOf(code string) int {
+	switch code {
+	case CodeInvalidAccount, CodeRecordNotFound:
+		return http.StatusNotFound
+	case CodeSystemUnavailable:
+		return http.StatusServiceUnavailable
+	case CodeSystemMalfunction:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
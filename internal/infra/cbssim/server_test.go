@@ -0,0 +1,133 @@
+package cbssim
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+)
+
+func serve(t *testing.T, s *Simulator, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	NewServer(s).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Transfer(t *testing.T) {
+	s := New(&config.Configs{})
+
+	rec := serve(t, s, http.MethodPost, "/accounts", `{"cif":"0000000001","full_name":"John Doe","opening_balance":10000}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /accounts status = %d, want 201", rec.Code)
+	}
+	var src account.Account
+	_ = json.Unmarshal(rec.Body.Bytes(), &src)
+	dest := s.OpenAccount("0000000002", "Jane Doe", 0)
+
+	tests := []struct {
+		name     string
+		amount   string
+		status   int
+		wantCode string
+	}{
+		{name: "success", amount: "4000", status: http.StatusOK},
+		{name: "insufficient_funds", amount: "99000", status: http.StatusUnprocessableEntity, wantCode: CodeInsufficientFunds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, s, http.MethodPost, "/transfers",
+				`{"source_account":"`+src.AccountNumber+`","destination_account":"`+dest.AccountNumber+`","amount":`+tt.amount+`,"remark":"`+tt.name+`"}`)
+
+			if rec.Code != tt.status {
+				t.Errorf("POST /transfers status = %d, want %d", rec.Code, tt.status)
+			}
+			var res errorResponse
+			_ = json.Unmarshal(rec.Body.Bytes(), &res)
+			if res.Code != tt.wantCode {
+				t.Errorf("POST /transfers code = %q, want %q", res.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	tests := []struct {
+		name   string
+		op     string
+		body   string
+		status int
+		after  int
+	}{
+		{
+			name:   "response_code",
+			op:     OpGetStatus,
+			body:   `{"code":"91"}`,
+			status: http.StatusNoContent,
+			after:  http.StatusServiceUnavailable,
+		},
+		{
+			name:   "timeout",
+			op:     OpGetStatus,
+			body:   `{"timeout":true}`,
+			status: http.StatusNoContent,
+			after:  http.StatusGatewayTimeout,
+		},
+		{
+			name:   "latency",
+			op:     OpGetStatus,
+			body:   `{"latency":"1ms"}`,
+			status: http.StatusNoContent,
+			after:  http.StatusOK,
+		},
+		{
+			name:   "invalid_latency",
+			op:     OpGetStatus,
+			body:   `{"latency":"soon"}`,
+			status: http.StatusBadRequest,
+			after:  http.StatusOK,
+		},
+		{
+			name:   "unknown_operation",
+			op:     "get_balance",
+			body:   `{"code":"91"}`,
+			status: http.StatusBadRequest,
+			after:  http.StatusOK,
+		},
+		{
+			name:   "unknown_code",
+			op:     OpGetStatus,
+			body:   `{"code":"00"}`,
+			status: http.StatusBadRequest,
+			after:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Configs{})
+
+			rec := serve(t, s, http.MethodPut, "/faults/"+tt.op, tt.body)
+			if rec.Code != tt.status {
+				t.Errorf("PUT /faults/%s status = %d, want %d", tt.op, rec.Code, tt.status)
+			}
+			if rec := serve(t, s, http.MethodGet, "/status", ""); rec.Code != tt.after {
+				t.Errorf("GET /status status = %d, want %d", rec.Code, tt.after)
+			}
+
+			if rec := serve(t, s, http.MethodDelete, "/faults", ""); rec.Code != http.StatusNoContent {
+				t.Errorf("DELETE /faults status = %d, want 204", rec.Code)
+			}
+			if rec := serve(t, s, http.MethodGet, "/status", ""); rec.Code != http.StatusOK {
+				t.Errorf("GET /status after clearing the faults status = %d, want 200", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,519 @@
+// Package cbssim contains an in-memory core banking system simulator for local development and tests.
+// It keeps accounts, holds and a double-entry journal, so flows can be run end to end
+// without the core banking system.
+package cbssim
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+)
+
+const systemDateLayout = "2006-01-02"
+
+type simAccount struct {
+	account.Account
+	ledger int64
+}
+
+type hold struct {
+	accountNumber string
+	amount        int64
+}
+
+// Simulator is an in-memory core banking system.
+// It implements account.Repository, cbs.Service, transfer.Service and deposit.Service.
+type Simulator struct {
+	mu             sync.Mutex
+	openingBalance int64
+	systemDate     time.Time
+	isEOD          bool
+	isStandIn      bool
+	lastCIF        int64
+	lastAccount    int64
+	lastReference  int64
+	accounts       map[string]*simAccount
+	holds          map[string]hold
+	transfers      map[string]transfer.Transfer
+	reversals      map[string]transfer.Transfer
+	fees           map[string]bool
+	interests      map[string]bool
+	journal        []Entry
+	faults         map[string]*Fault
+}
+
+// New creates a simulator without accounts on today's system date, with the faults of the configuration.
+// It panics on a fault of an unknown operation or response code, like the loading of the configuration.
+func New(cfg *config.Configs) *Simulator {
+	now := time.Now()
+	s := &Simulator{
+		openingBalance: cfg.CBS.SimulatorOpeningBalance,
+		systemDate:     time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		accounts:       make(map[string]*simAccount),
+		holds:          make(map[string]hold),
+		transfers:      make(map[string]transfer.Transfer),
+		reversals:      make(map[string]transfer.Transfer),
+		fees:           make(map[string]bool),
+		interests:      make(map[string]bool),
+		faults:         make(map[string]*Fault),
+	}
+	for op, f := range cfg.CBS.SimulatorFaults {
+		err := s.InjectFaultSpec(op, FaultSpec{
+			Latency: f.Latency,
+			Code:    f.Code,
+			Timeout: f.Timeout,
+			Applied: f.Applied,
+			Times:   f.Times,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("cbssim: fault of %s: %v", op, err))
+		}
+	}
+	return s
+}
+
+// OpenAccount opens a savings account for the customer and credits the opening balance
+// from the cash account. It seeds the simulator for local development and tests.
+func (s *Simulator) OpenAccount(cif, fullName string, openingBalance int64) account.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.open(account.Account{
+		CIF:      cif,
+		FullName: fullName,
+		Type:     account.TypeSavings,
+	}, openingBalance)
+}
+
+// StartEOD starts the end of day, transactions are refused unless stand-in is on.
+func (s *Simulator) StartEOD() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.isEOD = true
+}
+
+// EndEOD ends the end of day and moves the system date to the next day.
+func (s *Simulator) EndEOD() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.isEOD = false
+	s.systemDate = s.systemDate.AddDate(0, 0, 1)
+}
+
+// SetStandIn turns the stand-in processing during the end of day on or off.
+func (s *Simulator) SetStandIn(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.isStandIn = on
+}
+
+func (s *Simulator) Get(ctx context.Context, accountNumber string) (account.Account, error) {
+	return run(ctx, s, OpGetAccount, func() (account.Account, error) {
+		acc, ok := s.accounts[accountNumber]
+		if !ok {
+			return account.Account{}, errAccountNotFound
+		}
+		return s.toAccount(acc), nil
+	})
+}
+
+// ListByCIF returns the accounts of the customer ordered by account number.
+func (s *Simulator) ListByCIF(ctx context.Context, cif string) ([]account.Account, error) {
+	return run(ctx, s, OpListAccounts, func() ([]account.Account, error) {
+		var res []account.Account
+		for _, acc := range s.accounts {
+			if acc.CIF == cif && !acc.IsClosed() {
+				res = append(res, s.toAccount(acc))
+			}
+		}
+		slices.SortFunc(res, func(a, b account.Account) int {
+			return strings.Compare(a.AccountNumber, b.AccountNumber)
+		})
+		return res, nil
+	})
+}
+
+// Create allocates the CIF of a new customer.
+func (s *Simulator) Create(ctx context.Context, username string) (account.Account, error) {
+	return run(ctx, s, OpCreateCustomer, func() (account.Account, error) {
+		s.lastCIF++
+		return account.Account{
+			CIF:      fmt.Sprintf("%010d", s.lastCIF),
+			FullName: username,
+		}, nil
+	})
+}
+
+// Open opens the account, savings and current accounts are credited with the configured opening balance.
+func (s *Simulator) Open(ctx context.Context, acc account.Account) (account.Account, error) {
+	return run(ctx, s, OpOpenAccount, func() (account.Account, error) {
+		if acc.CIF == "" {
+			return account.Account{}, errInvalidAccount
+		}
+		switch acc.Type {
+		case account.TypeSavings, account.TypeCurrent:
+			return s.open(acc, s.openingBalance), nil
+		case account.TypeDeposit:
+			return s.open(acc, 0), nil
+		case account.TypePocket:
+			parent, ok := s.accounts[acc.ParentAccount]
+			if !ok || parent.IsClosed() || parent.IsPocket() || parent.CIF != acc.CIF {
+				return account.Account{}, errInvalidAccount
+			}
+			return s.open(acc, 0), nil
+		default:
+			return account.Account{}, errInvalidAccount
+		}
+	})
+}
+
+func (s *Simulator) Rename(ctx context.Context, accountNumber, name string) error {
+	_, err := run(ctx, s, OpRenameAccount, func() (struct{}, error) {
+		acc, err := s.getActive(accountNumber)
+		if err != nil {
+			return struct{}{}, err
+		}
+		acc.Name = name
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// Close closes an account without money, holds or open pockets.
+func (s *Simulator) Close(ctx context.Context, accountNumber string) error {
+	_, err := run(ctx, s, OpCloseAccount, func() (struct{}, error) {
+		acc, err := s.getActive(accountNumber)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if acc.ledger != 0 || s.available(acc) != 0 {
+			return struct{}{}, errBalanceNotZero
+		}
+		for _, pocket := range s.accounts {
+			if pocket.ParentAccount == accountNumber && !pocket.IsClosed() {
+				return struct{}{}, errBalanceNotZero
+			}
+		}
+		acc.Status = account.StatusClosed
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// SetStatus changes the status of an open account, closing goes through Close.
+func (s *Simulator) SetStatus(ctx context.Context, accountNumber, status string) error {
+	_, err := run(ctx, s, OpSetAccountStatus, func() (struct{}, error) {
+		acc, err := s.getActive(accountNumber)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if status == account.StatusClosed || !acc.CanChangeStatus(status) {
+			return struct{}{}, errInvalidAccount
+		}
+		acc.Status = status
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// PlaceHold earmarks the amount on the account. Holds are accepted during the end of day
+// so transfers queued for the stand-in keep their funds reserved.
+func (s *Simulator) PlaceHold(ctx context.Context, accountNumber, reference string, amount int64) error {
+	_, err := run(ctx, s, OpPlaceHold, func() (struct{}, error) {
+		if amount <= 0 {
+			return struct{}{}, errInvalidAmount
+		}
+		acc, err := s.getActive(accountNumber)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if !acc.CanDebit() {
+			return struct{}{}, errAccountRestricted
+		}
+		if h, ok := s.holds[reference]; ok {
+			if h.accountNumber != accountNumber || h.amount != amount {
+				return struct{}{}, errDuplicateHold
+			}
+			return struct{}{}, nil
+		}
+		if s.available(acc) < amount {
+			return struct{}{}, errInsufficientFunds
+		}
+		s.holds[reference] = hold{
+			accountNumber: accountNumber,
+			amount:        amount,
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// CaptureHold drops the hold once the transfer debited the held amount from the ledger.
+func (s *Simulator) CaptureHold(ctx context.Context, reference string) error {
+	_, err := run(ctx, s, OpCaptureHold, func() (struct{}, error) {
+		return struct{}{}, s.dropHold(reference)
+	})
+	return err
+}
+
+func (s *Simulator) ReleaseHold(ctx context.Context, reference string) error {
+	_, err := run(ctx, s, OpReleaseHold, func() (struct{}, error) {
+		return struct{}{}, s.dropHold(reference)
+	})
+	return err
+}
+
+func (s *Simulator) GetStatus(ctx context.Context) (cbs.Status, error) {
+	return run(ctx, s, OpGetStatus, func() (cbs.Status, error) {
+		return cbs.Status{
+			SystemDate: s.systemDate.Format(systemDateLayout),
+			IsEOD:      s.isEOD,
+			IsStandIn:  s.isStandIn,
+		}, nil
+	})
+}
+
+// Transfer posts the amount from the source to the destination account.
+// Transfers are deduplicated by their remark, and checked against the ledger balance
+// because the amount is held beforehand. The status of both accounts must allow the posting.
+func (s *Simulator) Transfer(ctx context.Context, srcAccountNumber, destAccountNumber string, amount int64, remark string) (transfer.Transfer, error) {
+	return run(ctx, s, OpTransfer, func() (transfer.Transfer, error) {
+		if tf, ok := s.transfers[remark]; ok {
+			return tf, nil
+		}
+		if err := s.checkReady(); err != nil {
+			return transfer.Transfer{}, err
+		}
+		if amount <= 0 {
+			return transfer.Transfer{}, errInvalidAmount
+		}
+		src, err := s.getActive(srcAccountNumber)
+		if err != nil {
+			return transfer.Transfer{}, err
+		}
+		dest, err := s.getActive(destAccountNumber)
+		if err != nil {
+			return transfer.Transfer{}, err
+		}
+		if !src.CanDebit() || !dest.CanCredit() {
+			return transfer.Transfer{}, errAccountRestricted
+		}
+		if src.ledger < amount {
+			return transfer.Transfer{}, errInsufficientFunds
+		}
+
+		reference := s.nextReference()
+		s.post(reference, srcAccountNumber, destAccountNumber, amount, remark)
+		tf := transfer.Transfer{
+			SourceAccount:        srcAccountNumber,
+			DestinationAccount:   destAccountNumber,
+			Amount:               amount,
+			Status:               transfer.StatusSuccess,
+			Notes:                remark,
+			TransactionID:        reference,
+			TransactionReference: reference,
+		}
+		s.transfers[remark] = tf
+		return tf, nil
+	})
+}
+
+func (s *Simulator) GetTransferStatus(ctx context.Context, remark string) (transfer.Transfer, error) {
+	return run(ctx, s, OpGetTransferStatus, func() (transfer.Transfer, error) {
+		tf, ok := s.transfers[remark]
+		if !ok {
+			return transfer.Transfer{}, errTransferNotFound
+		}
+		return tf, nil
+	})
+}
+
+// Reverse posts the amount back from the destination to the source account
+// and refunds the fee from the fee income account.
+func (s *Simulator) Reverse(ctx context.Context, rv transfer.Reversal) (transfer.Transfer, error) {
+	return run(ctx, s, OpReverse, func() (transfer.Transfer, error) {
+		if tf, ok := s.reversals[rv.Remark]; ok {
+			return tf, nil
+		}
+		if err := s.checkReady(); err != nil {
+			return transfer.Transfer{}, err
+		}
+		if !s.hasTransfer(rv.TransactionReference) {
+			return transfer.Transfer{}, errTransferNotFound
+		}
+		dest, err := s.getActive(rv.DestinationAccount)
+		if err != nil {
+			return transfer.Transfer{}, err
+		}
+		if _, err := s.getActive(rv.SourceAccount); err != nil {
+			return transfer.Transfer{}, err
+		}
+		if dest.ledger < rv.Amount {
+			return transfer.Transfer{}, errInsufficientFunds
+		}
+
+		reference := s.nextReference()
+		s.post(reference, rv.DestinationAccount, rv.SourceAccount, rv.Amount, rv.Remark)
+		if rv.Fee > 0 {
+			s.post(reference, GLFeeIncome, rv.SourceAccount, rv.Fee, rv.Remark)
+		}
+		tf := transfer.Transfer{
+			SourceAccount:        rv.DestinationAccount,
+			DestinationAccount:   rv.SourceAccount,
+			Amount:               rv.Amount + rv.Fee,
+			Status:               transfer.StatusSuccess,
+			Notes:                rv.Remark,
+			TransactionID:        reference,
+			TransactionReference: reference,
+		}
+		s.reversals[rv.Remark] = tf
+		return tf, nil
+	})
+}
+
+// ChargeFee posts the fee from the account to the fee income account.
+// The fee is checked against the ledger balance because it is held with the transfer amount.
+func (s *Simulator) ChargeFee(ctx context.Context, accountNumber string, fee int64, remark string) error {
+	_, err := run(ctx, s, OpChargeFee, func() (struct{}, error) {
+		if s.fees[remark] {
+			return struct{}{}, nil
+		}
+		if err := s.checkReady(); err != nil {
+			return struct{}{}, err
+		}
+		if fee <= 0 {
+			return struct{}{}, errInvalidAmount
+		}
+		acc, err := s.getActive(accountNumber)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if acc.ledger < fee {
+			return struct{}{}, errInsufficientFunds
+		}
+
+		s.post(s.nextReference(), accountNumber, GLFeeIncome, fee, "Fee "+remark)
+		s.fees[remark] = true
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// CreditInterest posts the interest from the interest expense account to the account,
+// the tax is withheld to the tax payable account.
+func (s *Simulator) CreditInterest(ctx context.Context, accountNumber string, gross, tax int64, reference string) error {
+	_, err := run(ctx, s, OpCreditInterest, func() (struct{}, error) {
+		if s.interests[reference] {
+			return struct{}{}, nil
+		}
+		if err := s.checkReady(); err != nil {
+			return struct{}{}, err
+		}
+		if gross <= 0 || tax < 0 || tax > gross {
+			return struct{}{}, errInvalidAmount
+		}
+		if _, err := s.getActive(accountNumber); err != nil {
+			return struct{}{}, err
+		}
+
+		s.post(reference, GLInterestExpense, accountNumber, gross-tax, "Interest "+reference)
+		if tax > 0 {
+			s.post(reference, GLInterestExpense, GLTaxPayable, tax, "Interest tax "+reference)
+		}
+		s.interests[reference] = true
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// open opens the account and credits the opening balance, the caller holds the lock.
+func (s *Simulator) open(opened account.Account, openingBalance int64) account.Account {
+	opened.AccountNumber = s.nextAccountNumber()
+	opened.Status = account.StatusActive
+	opened.OpenedAt = time.Now()
+	acc := &simAccount{Account: opened}
+	s.accounts[acc.AccountNumber] = acc
+	if openingBalance > 0 {
+		s.post(s.nextReference(), GLCash, acc.AccountNumber, openingBalance, "OPENING BALANCE")
+	}
+	return s.toAccount(acc)
+}
+
+// getActive returns the account unless it does not exist or is closed.
+func (s *Simulator) getActive(accountNumber string) (*simAccount, error) {
+	acc, ok := s.accounts[accountNumber]
+	if !ok {
+		return nil, errAccountNotFound
+	}
+	if acc.IsClosed() {
+		return nil, errAccountClosed
+	}
+	return acc, nil
+}
+
+func (s *Simulator) toAccount(acc *simAccount) account.Account {
+	res := acc.Account
+	res.Balance = acc.ledger
+	res.AvailableBalance = s.available(acc)
+	return res
+}
+
+// available returns the ledger balance minus the holds of the account.
+func (s *Simulator) available(acc *simAccount) int64 {
+	available := acc.ledger
+	for _, h := range s.holds {
+		if h.accountNumber == acc.AccountNumber {
+			available -= h.amount
+		}
+	}
+	return available
+}
+
+func (s *Simulator) dropHold(reference string) error {
+	if _, ok := s.holds[reference]; !ok {
+		return errHoldNotFound
+	}
+	delete(s.holds, reference)
+	return nil
+}
+
+func (s *Simulator) hasTransfer(reference string) bool {
+	for _, tf := range s.transfers {
+		if tf.TransactionReference == reference {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReady refuses money movements during the end of day without stand-in.
+func (s *Simulator) checkReady() error {
+	if s.isEOD && !s.isStandIn {
+		return errSystemUnavailable
+	}
+	return nil
+}
+
+// nextAccountNumber returns the account number of the next sequence with a check digit.
+// A sequence without a check digit is never followed by another one, so one is skipped at most.
+func (s *Simulator) nextAccountNumber() string {
+	for range 2 {
+		s.lastAccount++
+		if number, ok := account.NewNumber(s.lastAccount); ok {
+			return number
+		}
+	}
+	panic("cbssim: account number sequence exhausted")
+}
+
+func (s *Simulator) nextReference() string {
+	s.lastReference++
+	return fmt.Sprintf("SIM%s%06d", s.systemDate.Format("20060102"), s.lastReference)
+}
@@ -0,0 +1,823 @@
+package cbssim
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+)
+
+// code returns the response code of a simulator error, or an empty string.
+func code(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+func balances(t *testing.T, s *Simulator, accountNumber string) (int64, int64) {
+	t.Helper()
+
+	acc, err := s.Get(context.Background(), accountNumber)
+	if err != nil {
+		t.Fatalf("Get(%s) error = %v", accountNumber, err)
+	}
+	return acc.Balance, acc.AvailableBalance
+}
+
+func TestPlaceHold(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(s *Simulator, acc string)
+		account   string
+		reference string
+		amount    int64
+		wantCode  string
+		wantErr   error
+		available int64
+	}{
+		{
+			name:      "success",
+			reference: "TX1",
+			amount:    4000,
+			available: 6000,
+		},
+		{
+			name:      "whole_balance",
+			reference: "TX1",
+			amount:    10000,
+			available: 0,
+		},
+		{
+			name: "insufficient_available_balance",
+			setup: func(s *Simulator, acc string) {
+				_ = s.PlaceHold(context.Background(), acc, "TX0", 7000)
+			},
+			reference: "TX1",
+			amount:    4000,
+			wantCode:  CodeInsufficientFunds,
+			wantErr:   account.ErrInsufficientBalance,
+			available: 3000,
+		},
+		{
+			name: "same_hold_twice",
+			setup: func(s *Simulator, acc string) {
+				_ = s.PlaceHold(context.Background(), acc, "TX1", 4000)
+			},
+			reference: "TX1",
+			amount:    4000,
+			available: 6000,
+		},
+		{
+			name: "duplicate_reference_with_another_amount",
+			setup: func(s *Simulator, acc string) {
+				_ = s.PlaceHold(context.Background(), acc, "TX1", 4000)
+			},
+			reference: "TX1",
+			amount:    5000,
+			wantCode:  CodeDuplicateTransaction,
+			available: 6000,
+		},
+		{
+			name:      "zero_amount",
+			reference: "TX1",
+			wantCode:  CodeInvalidAmount,
+			available: 10000,
+		},
+		{
+			name:      "unknown_account",
+			account:   "1000000999",
+			reference: "TX1",
+			amount:    4000,
+			wantCode:  CodeInvalidAccount,
+			wantErr:   account.ErrNotFound,
+			available: 10000,
+		},
+		{
+			name: "dormant_account",
+			setup: func(s *Simulator, acc string) {
+				_ = s.SetStatus(context.Background(), acc, account.StatusDormant)
+			},
+			reference: "TX1",
+			amount:    4000,
+			wantCode:  CodeRestrictedAccount,
+			wantErr:   account.ErrRestricted,
+			available: 10000,
+		},
+		{
+			name: "frozen_debit_account",
+			setup: func(s *Simulator, acc string) {
+				_ = s.SetStatus(context.Background(), acc, account.StatusFrozenDebit)
+			},
+			reference: "TX1",
+			amount:    4000,
+			wantCode:  CodeRestrictedAccount,
+			wantErr:   account.ErrRestricted,
+			available: 10000,
+		},
+		{
+			name: "during_end_of_day",
+			setup: func(s *Simulator, acc string) {
+				s.StartEOD()
+			},
+			reference: "TX1",
+			amount:    4000,
+			available: 6000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Configs{})
+			acc := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+			if tt.setup != nil {
+				tt.setup(s, acc)
+			}
+			target := acc
+			if tt.account != "" {
+				target = tt.account
+			}
+
+			err := s.PlaceHold(context.Background(), target, tt.reference, tt.amount)
+
+			if code(err) != tt.wantCode {
+				t.Errorf("PlaceHold() error = %v, want code %q", err, tt.wantCode)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("PlaceHold() error = %v, want %v", err, tt.wantErr)
+			}
+			ledger, available := balances(t, s, acc)
+			if ledger != 10000 {
+				t.Errorf("ledger balance = %d, want 10000", ledger)
+			}
+			if available != tt.available {
+				t.Errorf("available balance = %d, want %d", available, tt.available)
+			}
+		})
+	}
+}
+
+func TestDropHold(t *testing.T) {
+	tests := []struct {
+		name      string
+		drop      func(s *Simulator, ctx context.Context, reference string) error
+		reference string
+		wantErr   error
+		available int64
+	}{
+		{
+			name:      "capture",
+			drop:      (*Simulator).CaptureHold,
+			reference: "TX1",
+			available: 10000,
+		},
+		{
+			name:      "release",
+			drop:      (*Simulator).ReleaseHold,
+			reference: "TX1",
+			available: 10000,
+		},
+		{
+			name:      "capture_unknown_hold",
+			drop:      (*Simulator).CaptureHold,
+			reference: "TX2",
+			wantErr:   account.ErrHoldNotFound,
+			available: 6000,
+		},
+		{
+			name:      "release_unknown_hold",
+			drop:      (*Simulator).ReleaseHold,
+			reference: "TX2",
+			wantErr:   account.ErrHoldNotFound,
+			available: 6000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Configs{})
+			acc := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+			if err := s.PlaceHold(context.Background(), acc, "TX1", 4000); err != nil {
+				t.Fatalf("PlaceHold() error = %v", err)
+			}
+
+			err := tt.drop(s, context.Background(), tt.reference)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if _, available := balances(t, s, acc); available != tt.available {
+				t.Errorf("available balance = %d, want %d", available, tt.available)
+			}
+		})
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(s *Simulator, src, dest string)
+		amount   int64
+		wantCode string
+		wantErr  error
+		src      int64
+		dest     int64
+	}{
+		{
+			name:   "success",
+			amount: 4000,
+			src:    6000,
+			dest:   4000,
+		},
+		{
+			name: "same_remark_twice",
+			setup: func(s *Simulator, src, dest string) {
+				_, _ = s.Transfer(context.Background(), src, dest, 4000, "TX1")
+			},
+			amount: 4000,
+			src:    6000,
+			dest:   4000,
+		},
+		{
+			name:     "insufficient_ledger_balance",
+			amount:   12000,
+			wantCode: CodeInsufficientFunds,
+			wantErr:  account.ErrInsufficientBalance,
+			src:      10000,
+		},
+		{
+			name:     "zero_amount",
+			wantCode: CodeInvalidAmount,
+			src:      10000,
+		},
+		{
+			name: "dormant_source",
+			setup: func(s *Simulator, src, dest string) {
+				_ = s.SetStatus(context.Background(), src, account.StatusDormant)
+			},
+			amount:   4000,
+			wantCode: CodeRestrictedAccount,
+			wantErr:  account.ErrRestricted,
+			src:      10000,
+		},
+		{
+			name: "dormant_destination",
+			setup: func(s *Simulator, src, dest string) {
+				_ = s.SetStatus(context.Background(), dest, account.StatusDormant)
+			},
+			amount: 4000,
+			src:    6000,
+			dest:   4000,
+		},
+		{
+			name: "frozen_credit_destination",
+			setup: func(s *Simulator, src, dest string) {
+				_ = s.SetStatus(context.Background(), dest, account.StatusFrozenCredit)
+			},
+			amount:   4000,
+			wantCode: CodeRestrictedAccount,
+			wantErr:  account.ErrRestricted,
+			src:      10000,
+		},
+		{
+			name: "closed_destination",
+			setup: func(s *Simulator, src, dest string) {
+				_ = s.Close(context.Background(), dest)
+			},
+			amount:   4000,
+			wantCode: CodeRestrictedAccount,
+			wantErr:  account.ErrRestricted,
+			src:      10000,
+		},
+		{
+			name: "end_of_day",
+			setup: func(s *Simulator, src, dest string) {
+				s.StartEOD()
+			},
+			amount:   4000,
+			wantCode: CodeSystemUnavailable,
+			src:      10000,
+		},
+		{
+			name: "end_of_day_with_stand_in",
+			setup: func(s *Simulator, src, dest string) {
+				s.StartEOD()
+				s.SetStandIn(true)
+			},
+			amount: 4000,
+			src:    6000,
+			dest:   4000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Configs{})
+			src := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+			dest := s.OpenAccount("0000000002", "Jane Doe", 0).AccountNumber
+			if tt.setup != nil {
+				tt.setup(s, src, dest)
+			}
+
+			tf, err := s.Transfer(context.Background(), src, dest, tt.amount, "TX1")
+
+			if code(err) != tt.wantCode {
+				t.Errorf("Transfer() error = %v, want code %q", err, tt.wantCode)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Transfer() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !tf.Succeeded() {
+				t.Errorf("Transfer() status = %s, want success", tf.Status)
+			}
+			if ledger, _ := balances(t, s, src); ledger != tt.src {
+				t.Errorf("source ledger balance = %d, want %d", ledger, tt.src)
+			}
+			s.mu.Lock()
+			destLedger := s.accounts[dest].ledger
+			s.mu.Unlock()
+			if destLedger != tt.dest {
+				t.Errorf("destination ledger balance = %d, want %d", destLedger, tt.dest)
+			}
+		})
+	}
+}
+
+func TestTransfer_HeldAmount(t *testing.T) {
+	ctx := context.Background()
+	s := New(&config.Configs{})
+	src := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+	dest := s.OpenAccount("0000000002", "Jane Doe", 0).AccountNumber
+
+	if err := s.PlaceHold(ctx, src, "TX1", 4000); err != nil {
+		t.Fatalf("PlaceHold() error = %v", err)
+	}
+	if _, err := s.Transfer(ctx, src, dest, 4000, "TX1"); err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+
+	// The transfer debits the ledger, the amount stays off the available balance until the hold is captured.
+	ledger, available := balances(t, s, src)
+	if ledger != 6000 || available != 2000 {
+		t.Errorf("balances before capture = %d/%d, want 6000/2000", ledger, available)
+	}
+	if err := s.CaptureHold(ctx, "TX1"); err != nil {
+		t.Fatalf("CaptureHold() error = %v", err)
+	}
+	ledger, available = balances(t, s, src)
+	if ledger != 6000 || available != 6000 {
+		t.Errorf("balances after capture = %d/%d, want 6000/6000", ledger, available)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(s *Simulator, src, dest string)
+		reference func(tf transfer.Transfer) string
+		fee       int64
+		wantCode  string
+		wantErr   error
+		src       int64
+		dest      int64
+	}{
+		{
+			name: "success",
+			src:  10000,
+		},
+		{
+			name: "with_fee",
+			fee:  500,
+			src:  10500,
+		},
+		{
+			name: "same_remark_twice",
+			setup: func(s *Simulator, src, dest string) {
+				_, _ = s.Reverse(context.Background(), transfer.Reversal{
+					TransactionReference: s.transfers["TX1"].TransactionReference,
+					SourceAccount:        src,
+					DestinationAccount:   dest,
+					Amount:               4000,
+					Remark:               "RV1",
+				})
+			},
+			src: 10000,
+		},
+		{
+			name: "unknown_transfer",
+			reference: func(transfer.Transfer) string {
+				return "SIM0000"
+			},
+			wantCode: CodeRecordNotFound,
+			wantErr:  transfer.ErrTransferNotFound,
+			src:      6000,
+			dest:     4000,
+		},
+		{
+			name: "destination_spent_the_amount",
+			setup: func(s *Simulator, src, dest string) {
+				_, _ = s.Transfer(context.Background(), dest, src, 3000, "TX2")
+			},
+			wantCode: CodeInsufficientFunds,
+			wantErr:  account.ErrInsufficientBalance,
+			src:      9000,
+			dest:     1000,
+		},
+		{
+			name: "end_of_day",
+			setup: func(s *Simulator, src, dest string) {
+				s.StartEOD()
+			},
+			wantCode: CodeSystemUnavailable,
+			src:      6000,
+			dest:     4000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := New(&config.Configs{})
+			src := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+			dest := s.OpenAccount("0000000002", "Jane Doe", 0).AccountNumber
+			tf, err := s.Transfer(ctx, src, dest, 4000, "TX1")
+			if err != nil {
+				t.Fatalf("Transfer() error = %v", err)
+			}
+			if tt.setup != nil {
+				tt.setup(s, src, dest)
+			}
+			reference := tf.TransactionReference
+			if tt.reference != nil {
+				reference = tt.reference(tf)
+			}
+
+			rv, err := s.Reverse(ctx, transfer.Reversal{
+				TransactionReference: reference,
+				SourceAccount:        src,
+				DestinationAccount:   dest,
+				Amount:               4000,
+				Fee:                  tt.fee,
+				Remark:               "RV1",
+			})
+
+			if code(err) != tt.wantCode {
+				t.Errorf("Reverse() error = %v, want code %q", err, tt.wantCode)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Reverse() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && rv.Amount != 4000+tt.fee {
+				t.Errorf("Reverse() amount = %d, want %d", rv.Amount, 4000+tt.fee)
+			}
+			if ledger, _ := balances(t, s, src); ledger != tt.src {
+				t.Errorf("source ledger balance = %d, want %d", ledger, tt.src)
+			}
+			if ledger, _ := balances(t, s, dest); ledger != tt.dest {
+				t.Errorf("destination ledger balance = %d, want %d", ledger, tt.dest)
+			}
+		})
+	}
+}
+
+func TestChargeFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(s *Simulator, src string)
+		fee      int64
+		wantCode string
+		src      int64
+	}{
+		{
+			name: "success",
+			fee:  2500,
+			src:  7500,
+		},
+		{
+			name: "same_remark_twice",
+			setup: func(s *Simulator, src string) {
+				_ = s.ChargeFee(context.Background(), src, 2500, "TX1")
+			},
+			fee: 2500,
+			src: 7500,
+		},
+		{
+			name:     "more_than_the_ledger",
+			fee:      20000,
+			wantCode: CodeInsufficientFunds,
+			src:      10000,
+		},
+		{
+			name: "end_of_day",
+			setup: func(s *Simulator, src string) {
+				s.StartEOD()
+			},
+			fee:      2500,
+			wantCode: CodeSystemUnavailable,
+			src:      10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Configs{})
+			src := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+			if tt.setup != nil {
+				tt.setup(s, src)
+			}
+
+			err := s.ChargeFee(context.Background(), src, tt.fee, "TX1")
+
+			if code(err) != tt.wantCode {
+				t.Errorf("ChargeFee() error = %v, want code %q", err, tt.wantCode)
+			}
+			if ledger, _ := balances(t, s, src); ledger != tt.src {
+				t.Errorf("source ledger balance = %d, want %d", ledger, tt.src)
+			}
+			if err == nil {
+				last := s.Journal()[len(s.Journal())-1]
+				if last.AccountNumber != GLFeeIncome || last.Credit != tt.fee {
+					t.Errorf("last journal entry = %+v, want a credit of %d to %s", last, tt.fee, GLFeeIncome)
+				}
+			}
+		})
+	}
+}
+
+func TestJournal(t *testing.T) {
+	ctx := context.Background()
+	s := New(&config.Configs{})
+	src := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+	dest := s.OpenAccount("0000000002", "Jane Doe", 0).AccountNumber
+	tf, err := s.Transfer(ctx, src, dest, 4000, "TX1")
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+	_, err = s.Reverse(ctx, transfer.Reversal{
+		TransactionReference: tf.TransactionReference,
+		SourceAccount:        src,
+		DestinationAccount:   dest,
+		Amount:               4000,
+		Fee:                  500,
+		Remark:               "RV1",
+	})
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	// A failed transfer posts nothing.
+	_, _ = s.Transfer(ctx, src, dest, 99000, "TX2")
+
+	journal := s.Journal()
+	if len(journal) != 8 {
+		t.Fatalf("len(Journal()) = %d, want 8", len(journal))
+	}
+	sums := make(map[string]int64)
+	for _, e := range journal {
+		sums[e.Reference] += e.Debit - e.Credit
+	}
+	for reference, sum := range sums {
+		if sum != 0 {
+			t.Errorf("entries of %s are off by %d", reference, sum)
+		}
+	}
+
+	// The balance of an account is the sum of its entries.
+	for _, number := range []string{src, dest} {
+		var want int64
+		for _, e := range journal {
+			if e.AccountNumber == number {
+				want += e.Credit - e.Debit
+			}
+		}
+		if ledger, _ := balances(t, s, number); ledger != want {
+			t.Errorf("ledger balance of %s = %d, want %d from the journal", number, ledger, want)
+		}
+	}
+	last := journal[len(journal)-2:]
+	if last[0].AccountNumber != GLFeeIncome || last[1].AccountNumber != src || last[1].Credit != 500 {
+		t.Errorf("fee refund entries = %+v, want %s debited and the source credited with 500", last, GLFeeIncome)
+	}
+}
+
+func TestFault(t *testing.T) {
+	tests := []struct {
+		name     string
+		fault    Fault
+		ctx      func() (context.Context, context.CancelFunc)
+		wantCode string
+		wantErr  error
+		posted   bool
+		second   bool
+	}{
+		{
+			name:     "response_code",
+			fault:    Fault{Err: NewError(CodeSystemMalfunction)},
+			wantCode: CodeSystemMalfunction,
+		},
+		{
+			name:     "applied_before_the_error",
+			fault:    Fault{Err: NewError(CodeSystemMalfunction), Applied: true},
+			wantCode: CodeSystemMalfunction,
+			posted:   true,
+		},
+		{
+			name:    "timeout",
+			fault:   Fault{Err: context.DeadlineExceeded},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:  "latency_within_the_deadline",
+			fault: Fault{Latency: time.Millisecond},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Second)
+			},
+			posted: true,
+			second: true,
+		},
+		{
+			name:  "latency_beyond_the_deadline",
+			fault: Fault{Latency: time.Second, Times: 1},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Millisecond)
+			},
+			wantErr: context.DeadlineExceeded,
+			second:  true,
+		},
+		{
+			name:     "next_call_only",
+			fault:    Fault{Err: NewError(CodeSystemUnavailable), Times: 1},
+			wantCode: CodeSystemUnavailable,
+			second:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Configs{})
+			src := s.OpenAccount("0000000001", "John Doe", 10000).AccountNumber
+			dest := s.OpenAccount("0000000002", "Jane Doe", 0).AccountNumber
+			s.InjectFault(OpTransfer, tt.fault)
+			ctx, cancel := context.Background(), context.CancelFunc(func() {})
+			if tt.ctx != nil {
+				ctx, cancel = tt.ctx()
+			}
+			defer cancel()
+
+			_, err := s.Transfer(ctx, src, dest, 4000, "TX1")
+
+			if code(err) != tt.wantCode {
+				t.Errorf("Transfer() error = %v, want code %q", err, tt.wantCode)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Transfer() error = %v, want %v", err, tt.wantErr)
+			}
+			want := int64(10000)
+			if tt.posted {
+				want = 6000
+			}
+			if ledger, _ := balances(t, s, src); ledger != want {
+				t.Errorf("source ledger balance = %d, want %d", ledger, want)
+			}
+
+			_, err = s.Transfer(context.Background(), src, dest, 1000, "TX2")
+			if (err == nil) != tt.second {
+				t.Errorf("second Transfer() error = %v, want success %v", err, tt.second)
+			}
+		})
+	}
+}
+
+func TestClearFaults(t *testing.T) {
+	s := New(&config.Configs{})
+	s.InjectFault(OpGetStatus, Fault{Err: NewError(CodeSystemMalfunction)})
+
+	s.ClearFaults()
+
+	if _, err := s.GetStatus(context.Background()); err != nil {
+		t.Errorf("GetStatus() error = %v, want nil", err)
+	}
+}
+
+func TestInjectFaultSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		spec     FaultSpec
+		wantErr  error
+		wantCode string
+	}{
+		{
+			name:     "response_code",
+			op:       OpGetStatus,
+			spec:     FaultSpec{Code: CodeSystemUnavailable},
+			wantCode: CodeSystemUnavailable,
+		},
+		{
+			name:    "timeout",
+			op:      OpGetStatus,
+			spec:    FaultSpec{Timeout: true},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "unknown_operation",
+			op:      "get_balance",
+			spec:    FaultSpec{Code: CodeSystemUnavailable},
+			wantErr: errUnknownOperation,
+		},
+		{
+			name:    "unknown_code",
+			op:      OpGetStatus,
+			spec:    FaultSpec{Code: "00"},
+			wantErr: errUnknownCode,
+		},
+		{
+			name:    "code_and_timeout",
+			op:      OpGetStatus,
+			spec:    FaultSpec{Code: CodeSystemUnavailable, Timeout: true},
+			wantErr: errAmbiguousFault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&config.Configs{})
+
+			err := s.InjectFaultSpec(tt.op, tt.spec)
+			if err != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("InjectFaultSpec() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			_, err = s.GetStatus(context.Background())
+			if code(err) != tt.wantCode {
+				t.Errorf("GetStatus() error = %v, want code %q", err, tt.wantCode)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetStatus() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew_ConfiguredFaults(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	err := v.ReadConfig(strings.NewReader(`
+cbs:
+  simulatorfaults:
+    get_status:
+      code: "91"
+      times: 1
+    transfer:
+      latency: 1s
+`))
+	if err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	var cfg config.Configs
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	s := New(&cfg)
+
+	_, err = s.GetStatus(context.Background())
+	if code(err) != CodeSystemUnavailable {
+		t.Errorf("first GetStatus() error = %v, want code %q", err, CodeSystemUnavailable)
+	}
+	if _, err := s.GetStatus(context.Background()); err != nil {
+		t.Errorf("second GetStatus() error = %v, want nil", err)
+	}
+	if f := s.faults[OpTransfer]; f == nil || f.Latency != time.Second {
+		t.Errorf("transfer fault = %+v, want a latency of 1s", f)
+	}
+}
+
+func TestNew_UnknownConfiguredFault(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	_ = v.ReadConfig(strings.NewReader(`
+cbs:
+  simulatorfaults:
+    get_balance:
+      code: "91"
+`))
+	var cfg config.Configs
+	_ = v.Unmarshal(&cfg)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New() did not panic on a fault of an unknown operation")
+		}
+	}()
+	New(&cfg)
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/account"
+)
+
+type AccountHandler struct {
+	va *validation.Validator
+	uc *account.Usecase
+}
+
+func NewAccountHandler(va *validation.Validator, uc *account.Usecase) *AccountHandler {
+	return &AccountHandler{
+		va: va,
+		uc: uc,
+	}
+}
+
+// GetAccounts swaggo annotation.
+//
+//	@Summary		Get accounts
+//	@Description	Get the accounts of the logged in user with their available and ledger balances
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Success		200				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/accounts [get]
+func (h *AccountHandler) GetAccounts(ctx echo.Context) error {
+	resp, err := h.uc.GetAccounts(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetAccount swaggo annotation.
+//
+//	@Summary		Get account
+//	@Description	Get an account of the logged in user with its available and ledger balances
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			number			path		string	true	"Account number"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/accounts/{number} [get]
+func (h *AccountHandler) GetAccount(ctx echo.Context) error {
+	req := new(account.GetAccountRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.GetAccount(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Open swaggo annotation.
+//
+//	@Summary		Open account
+//	@Description	Open a savings or current account under the CIF of the logged in user
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				true	"Authorization token"
+//	@Param			request			body		account.OpenRequest	true	"Open account request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/accounts [post]
+func (h *AccountHandler) Open(ctx echo.Context) error {
+	req := new(account.OpenRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Open(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// CreatePocket swaggo annotation.
+//
+//	@Summary		Create pocket
+//	@Description	Open a pocket with its own balance under a savings or current account of the logged in user
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			number			path		string						true	"Parent account number"
+//	@Param			request			body		account.CreatePocketRequest	true	"Create pocket request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/accounts/{number}/pockets [post]
+func (h *AccountHandler) CreatePocket(ctx echo.Context) error {
+	req := new(account.CreatePocketRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.CreatePocket(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// RenamePocket swaggo annotation.
+//
+//	@Summary		Rename pocket
+//	@Description	Rename a pocket of the logged in user
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			number			path		string						true	"Pocket account number"
+//	@Param			request			body		account.RenamePocketRequest	true	"Rename pocket request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/pockets/{number} [patch]
+func (h *AccountHandler) RenamePocket(ctx echo.Context) error {
+	req := new(account.RenamePocketRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.RenamePocket(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// ClosePocket swaggo annotation.
+//
+//	@Summary		Close pocket
+//	@Description	Move the balance of a pocket of the logged in user back to its parent account and close it
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			number			path		string	true	"Pocket account number"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/pockets/{number} [delete]
+func (h *AccountHandler) ClosePocket(ctx echo.Context) error {
+	req := new(account.ClosePocketRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.ClosePocket(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Move swaggo annotation.
+//
+//	@Summary		Move money
+//	@Description	Move money between the accounts and pockets of the logged in user
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				true	"Authorization token"
+//	@Param			request			body		account.MoveRequest	true	"Move request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/pockets/move [post]
+func (h *AccountHandler) Move(ctx echo.Context) error {
+	req := new(account.MoveRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Move(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// ChangeStatus swaggo annotation.
+//
+//	@Summary		Change account status
+//	@Description	Freeze, reactivate, flag as dormant or close an account with a reason, for ops users
+//	@Tags			ops
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			number			path		string						true	"Account number"
+//	@Param			request			body		account.ChangeStatusRequest	true	"Change status request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/ops/accounts/{number}/status [put]
+func (h *AccountHandler) ChangeStatus(ctx echo.Context) error {
+	req := new(account.ChangeStatusRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.ChangeStatus(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/beneficiary"
+)
+
+type BeneficiaryHandler struct {
+	va *validation.Validator
+	uc *beneficiary.Usecase
+}
+
+func NewBeneficiaryHandler(va *validation.Validator, uc *beneficiary.Usecase) *BeneficiaryHandler {
+	return &BeneficiaryHandler{
+		va: va,
+		uc: uc,
+	}
+}
+
+// RequestOTP swaggo annotation.
+//
+//	@Summary		Request beneficiary OTP
+//	@Description	Send an OTP to confirm saving a new beneficiary
+//	@Tags			beneficiaries
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Success		200				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/beneficiaries/otp [post]
+func (h *BeneficiaryHandler) RequestOTP(ctx echo.Context) error {
+	resp, err := h.uc.RequestOTP(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Create swaggo annotation.
+//
+//	@Summary		Create beneficiary
+//	@Description	Save an account to the address book of the logged in user
+//	@Tags			beneficiaries
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			CreateRequest	body		beneficiary.CreateRequest	true	"Create Beneficiary Request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/beneficiaries [post]
+func (h *BeneficiaryHandler) Create(ctx echo.Context) error {
+	req := new(beneficiary.CreateRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Create(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetBeneficiaries swaggo annotation.
+//
+//	@Summary		Get beneficiaries
+//	@Description	Get the address book of the logged in user, favourites first
+//	@Tags			beneficiaries
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Success		200				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/beneficiaries [get]
+func (h *BeneficiaryHandler) GetBeneficiaries(ctx echo.Context) error {
+	resp, err := h.uc.GetBeneficiaries(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Update swaggo annotation.
+//
+//	@Summary		Update beneficiary
+//	@Description	Update the nickname or favourite flag of a beneficiary
+//	@Tags			beneficiaries
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			uuid			path		string						true	"Beneficiary UUID"
+//	@Param			UpdateRequest	body		beneficiary.UpdateRequest	true	"Update Beneficiary Request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/beneficiaries/{uuid} [patch]
+func (h *BeneficiaryHandler) Update(ctx echo.Context) error {
+	req := new(beneficiary.UpdateRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Update(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Delete swaggo annotation.
+//
+//	@Summary		Delete beneficiary
+//	@Description	Remove a beneficiary from the address book
+//	@Tags			beneficiaries
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Beneficiary UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/beneficiaries/{uuid} [delete]
+func (h *BeneficiaryHandler) Delete(ctx echo.Context) error {
+	req := new(beneficiary.DeleteRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Delete(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/bulktransfer"
+)
+
+type BulkTransferHandler struct {
+	va *validation.Validator
+	uc *bulktransfer.Usecase
+}
+
+func NewBulkTransferHandler(va *validation.Validator, uc *bulktransfer.Usecase) *BulkTransferHandler {
+	return &BulkTransferHandler{
+		va: va,
+		uc: uc,
+	}
+}
+
+// Create swaggo annotation.
+//
+//	@Summary		Create bulk transfer
+//	@Description	Upload a batch of transfers as a CSV file or JSON rows
+//	@Tags			transfers
+//	@Accept			json,mpfd,text/csv
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			CreateRequest	body		bulktransfer.CreateRequest	false	"Create Bulk Transfer Request"
+//	@Param			source_account	formData	string						false	"Source account of a CSV upload"
+//	@Param			file			formData	file						false	"CSV file with destination_bank_code, destination_account, amount and note columns"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Failure		503				{object}	response.Response
+//	@Router			/transfers/bulk [post]
+func (h *BulkTransferHandler) Create(ctx echo.Context) error {
+	req := new(bulktransfer.CreateRequest)
+	err := h.bindCreateRequest(ctx, req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.ValidateRows(req.Rows)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Create(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// bindCreateRequest reads the rows from a multipart CSV file, a CSV body or a JSON body.
+func (h *BulkTransferHandler) bindCreateRequest(ctx echo.Context, req *bulktransfer.CreateRequest) error {
+	contentType := ctx.Request().Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEMultipartForm):
+		req.SourceAccount = ctx.FormValue("source_account")
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			return err
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		req.Rows, err = bulktransfer.ParseCSV(file)
+		return err
+	case strings.HasPrefix(contentType, "text/csv"):
+		req.SourceAccount = ctx.QueryParam("source_account")
+		rows, err := bulktransfer.ParseCSV(ctx.Request().Body)
+		req.Rows = rows
+		return err
+	default:
+		return ctx.Bind(req)
+	}
+}
+
+// GetBatch swaggo annotation.
+//
+//	@Summary		Get bulk transfer
+//	@Description	Get a bulk transfer with the status of each row
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Bulk transfer UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/bulk/{uuid} [get]
+func (h *BulkTransferHandler) GetBatch(ctx echo.Context) error {
+	req := new(bulktransfer.GetRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.GetBatch(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Report swaggo annotation.
+//
+//	@Summary		Download bulk transfer report
+//	@Description	Download the outcome of each row of a bulk transfer as a CSV file
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		text/csv
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Bulk transfer UUID"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/bulk/{uuid}/report [get]
+func (h *BulkTransferHandler) Report(ctx echo.Context) error {
+	req := new(bulktransfer.GetRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	report, err := h.uc.Report(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=bulk-transfer-"+req.UUID+".csv")
+	return ctx.Blob(http.StatusOK, "text/csv", report)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/deposit"
+)
+
+type DepositHandler struct {
+	va *validation.Validator
+	uc *deposit.Usecase
+}
+
+func NewDepositHandler(va *validation.Validator, uc *deposit.Usecase) *DepositHandler {
+	return &DepositHandler{
+		va: va,
+		uc: uc,
+	}
+}
+
+// GetProducts swaggo annotation.
+//
+//	@Summary		Get deposit products
+//	@Description	Get the terms and annual rates of time deposits
+//	@Tags			deposits
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Success		200				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Router			/deposits/products [get]
+func (h *DepositHandler) GetProducts(ctx echo.Context) error {
+	resp, err := h.uc.GetProducts(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Place swaggo annotation.
+//
+//	@Summary		Place time deposit
+//	@Description	Move an amount from a savings account to a time deposit for a term, rolled over or credited back at maturity
+//	@Tags			deposits
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			PlaceRequest	body		deposit.PlaceRequest	true	"Place Deposit Request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Failure		503				{object}	response.Response
+//	@Router			/deposits [post]
+func (h *DepositHandler) Place(ctx echo.Context) error {
+	req := new(deposit.PlaceRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Place(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetDeposits swaggo annotation.
+//
+//	@Summary		Get time deposits
+//	@Description	Get the time deposits of the logged in user with the interest of their current term
+//	@Tags			deposits
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Success		200				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/deposits [get]
+func (h *DepositHandler) GetDeposits(ctx echo.Context) error {
+	resp, err := h.uc.GetDeposits(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetDeposit swaggo annotation.
+//
+//	@Summary		Get time deposit
+//	@Description	Get a time deposit of the logged in user with the interest of its current term
+//	@Tags			deposits
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Deposit UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/deposits/{uuid} [get]
+func (h *DepositHandler) GetDeposit(ctx echo.Context) error {
+	req := new(deposit.GetRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.GetDeposit(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/resilience"
+)
+
+type HealthHandler struct {
+	registry *resilience.Registry
+}
+
+func NewHealthHandler(registry *resilience.Registry) *HealthHandler {
+	return &HealthHandler{
+		registry: registry,
+	}
+}
+
+// Health swaggo annotation.
+//
+//	@Summary		Get service health
+//	@Description	Get the circuit breaker state of every outbound service, the status is degraded while any breaker is not closed
+//	@Tags			system
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Router			/health [get]
+func (h *HealthHandler) Health(ctx echo.Context) error {
+	return ctx.JSON(response.Success(h.registry.Health()))
+}
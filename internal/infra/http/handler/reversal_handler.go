@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/reversal"
+)
+
+type ReversalHandler struct {
+	va *validation.Validator
+	uc *reversal.Usecase
+}
+
+func NewReversalHandler(va *validation.Validator, uc *reversal.Usecase) *ReversalHandler {
+	return &ReversalHandler{
+		va: va,
+		uc: uc,
+	}
+}
+
+// Reverse swaggo annotation.
+//
+//	@Summary		Reverse transaction
+//	@Description	Return the money of a completed transfer or TapMoney payment, for ops users
+//	@Tags			ops
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@Param			uuid			path		string					true	"Transaction UUID"
+//	@Param			ReverseRequest	body		reversal.ReverseRequest	true	"Reverse Transaction Request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/ops/transactions/{uuid}/reverse [post]
+func (h *ReversalHandler) Reverse(ctx echo.Context) error {
+	req := new(reversal.ReverseRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Reverse(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/savingsgoal"
+)
+
+type SavingsGoalHandler struct {
+	va *validation.Validator
+	uc *savingsgoal.Usecase
+}
+
+func NewSavingsGoalHandler(va *validation.Validator, uc *savingsgoal.Usecase) *SavingsGoalHandler {
+	return &SavingsGoalHandler{
+		va: va,
+		uc: uc,
+	}
+}
+
+// Create swaggo annotation.
+//
+//	@Summary		Create savings goal
+//	@Description	Set up a savings goal on a pocket, swept from its parent account on a schedule or by round-ups
+//	@Tags			savings-goals
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			CreateRequest	body		savingsgoal.CreateRequest	true	"Create Savings Goal Request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/savings-goals [post]
+func (h *SavingsGoalHandler) Create(ctx echo.Context) error {
+	req := new(savingsgoal.CreateRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Create(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetGoals swaggo annotation.
+//
+//	@Summary		Get savings goals
+//	@Description	Get the savings goals of the logged in user with their progress
+//	@Tags			savings-goals
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Success		200				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/savings-goals [get]
+func (h *SavingsGoalHandler) GetGoals(ctx echo.Context) error {
+	resp, err := h.uc.GetGoals(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetGoal swaggo annotation.
+//
+//	@Summary		Get savings goal
+//	@Description	Get a savings goal of the logged in user with its progress
+//	@Tags			savings-goals
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Savings goal UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/savings-goals/{uuid} [get]
+func (h *SavingsGoalHandler) GetGoal(ctx echo.Context) error {
+	req := new(savingsgoal.GetRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.GetGoal(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetSweeps swaggo annotation.
+//
+//	@Summary		Get savings goal sweeps
+//	@Description	Get the sweeps into the pocket of a savings goal of the logged in user, latest first
+//	@Tags			savings-goals
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Savings goal UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/savings-goals/{uuid}/sweeps [get]
+func (h *SavingsGoalHandler) GetSweeps(ctx echo.Context) error {
+	req := new(savingsgoal.GetRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.GetSweeps(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Cancel swaggo annotation.
+//
+//	@Summary		Cancel savings goal
+//	@Description	Stop the sweeps of a savings goal of the logged in user, the money stays in the pocket
+//	@Tags			savings-goals
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Savings goal UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/savings-goals/{uuid} [delete]
+func (h *SavingsGoalHandler) Cancel(ctx echo.Context) error {
+	req := new(savingsgoal.CancelRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Cancel(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
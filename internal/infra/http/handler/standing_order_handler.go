@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/validation"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/standingorder"
+)
+
+type StandingOrderHandler struct {
+	va *validation.Validator
+	uc *standingorder.Usecase
+}
+
+func NewStandingOrderHandler(va *validation.Validator, uc *standingorder.Usecase) *StandingOrderHandler {
+	return &StandingOrderHandler{
+		va: va,
+		uc: uc,
+	}
+}
+
+// Create swaggo annotation.
+//
+//	@Summary		Create scheduled transfer
+//	@Description	Schedule a transfer for a future date or set up a recurring one
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			CreateRequest	body		standingorder.CreateRequest	true	"Create Scheduled Transfer Request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/scheduled [post]
+func (h *StandingOrderHandler) Create(ctx echo.Context) error {
+	req := new(standingorder.CreateRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Create(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetStandingOrders swaggo annotation.
+//
+//	@Summary		Get scheduled transfers
+//	@Description	Get scheduled transfers of the logged in user
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Success		200				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/scheduled [get]
+func (h *StandingOrderHandler) GetStandingOrders(ctx echo.Context) error {
+	resp, err := h.uc.GetStandingOrders(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetStandingOrder swaggo annotation.
+//
+//	@Summary		Get scheduled transfer by UUID
+//	@Description	Get scheduled transfer by UUID
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Scheduled transfer UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/scheduled/{uuid} [get]
+func (h *StandingOrderHandler) GetStandingOrder(ctx echo.Context) error {
+	req := new(standingorder.GetRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.GetStandingOrder(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Update swaggo annotation.
+//
+//	@Summary		Update scheduled transfer
+//	@Description	Update amount, note or end date of a scheduled transfer
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Authorization token"
+//	@Param			uuid			path		string						true	"Scheduled transfer UUID"
+//	@Param			UpdateRequest	body		standingorder.UpdateRequest	true	"Update Scheduled Transfer Request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/scheduled/{uuid} [patch]
+func (h *StandingOrderHandler) Update(ctx echo.Context) error {
+	req := new(standingorder.UpdateRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Update(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Delete swaggo annotation.
+//
+//	@Summary		Cancel scheduled transfer
+//	@Description	Cancel a scheduled transfer
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Scheduled transfer UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/scheduled/{uuid} [delete]
+func (h *StandingOrderHandler) Delete(ctx echo.Context) error {
+	req := new(standingorder.DeleteRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Delete(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/system"
+)
+
+type SystemHandler struct {
+	uc *system.Usecase
+}
+
+func NewSystemHandler(uc *system.Usecase) *SystemHandler {
+	return &SystemHandler{
+		uc: uc,
+	}
+}
+
+// GetStatus swaggo annotation.
+//
+//	@Summary		Get system status
+//	@Description	Get whether transactions are accepted and the next core banking system end of day window
+//	@Tags			system
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/system/status [get]
+func (h *SystemHandler) GetStatus(ctx echo.Context) error {
+	resp, err := h.uc.GetStatus(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
@@ -32,6 +32,7 @@ func NewTapMoneyHandler(va *validation.Validator, uc *tapmoney.Usecase) *TapMone
 //	@Failure		400				{object}	response.Response
 //	@Failure		404				{object}	response.Response
 //	@Failure		500				{object}	response.Response
+//	@Failure		503				{object}	response.Response
 //	@Router			/tapmoney/init [post]
 func (h *TapMoneyHandler) Initiate(ctx echo.Context) error {
 	req := new(tapmoney.InitiateRequest)
@@ -63,6 +64,7 @@ func (h *TapMoneyHandler) Initiate(ctx echo.Context) error {
 //	@Failure		400				{object}	response.Response
 //	@Failure		404				{object}	response.Response
 //	@Failure		500				{object}	response.Response
+//	@Failure		503				{object}	response.Response
 //	@Router			/tapmoney/{uuid}/process [post]
 func (h *TapMoneyHandler) Process(ctx echo.Context) error {
 	req := new(tapmoney.ProcessRequest)
@@ -80,3 +82,71 @@ func (h *TapMoneyHandler) Process(ctx echo.Context) error {
 	}
 	return ctx.JSON(response.Success(resp))
 }
+
+// Cancel swaggo annotation.
+//
+//	@Summary		Cancel TapMoney transaction
+//	@Description	Cancel an initiated TapMoney transaction before it is processed
+//	@Tags			tapmoney
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Transaction UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/tapmoney/{uuid}/cancel [post]
+func (h *TapMoneyHandler) Cancel(ctx echo.Context) error {
+	req := new(tapmoney.CancelRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Cancel(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Callback swaggo annotation.
+//
+//	@Summary		Settle TapMoney transaction
+//	@Description	Settle a pending TapMoney transaction with the outcome signed by the TapMoney service
+//	@Tags			tapmoney
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Key-ID		header		string						true	"Signing key ID"
+//	@Param			X-Timestamp		header		string						true	"Signing time in Unix seconds"
+//	@Param			X-Nonce			header		string						true	"Unique request nonce"
+//	@Param			X-Signature		header		string						true	"Hex encoded HMAC-SHA256 of the request"
+//	@Param			CallbackRequest	body		tapmoney.CallbackRequest	true	"Callback request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/callbacks/tapmoney [post]
+func (h *TapMoneyHandler) Callback(ctx echo.Context) error {
+	req := new(tapmoney.CallbackRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Callback(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
@@ -84,3 +84,34 @@ func (h *TransactionHandler) GetTransaction(ctx echo.Context) error {
 	}
 	return ctx.JSON(response.Success(resp))
 }
+
+// GetHistory swaggo annotation.
+//
+//	@Summary		Get transaction status history
+//	@Description	Get the status changes of a transaction, oldest first
+//	@Tags			transactions
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Transaction UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transactions/{uuid}/history [get]
+func (h *TransactionHandler) GetHistory(ctx echo.Context) error {
+	req := new(transaction.GetTransactionRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.GetHistory(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
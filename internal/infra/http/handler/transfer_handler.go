@@ -32,6 +32,7 @@ func NewTransferHandler(va *validation.Validator, uc *transfer.Usecase) *Transfe
 //	@Failure		400				{object}	response.Response
 //	@Failure		404				{object}	response.Response
 //	@Failure		500				{object}	response.Response
+//	@Failure		503				{object}	response.Response
 //	@Router			/transfers/init [post]
 func (h *TransferHandler) Initiate(ctx echo.Context) error {
 	req := new(transfer.InitiateRequest)
@@ -63,6 +64,7 @@ func (h *TransferHandler) Initiate(ctx echo.Context) error {
 //	@Failure		400				{object}	response.Response
 //	@Failure		404				{object}	response.Response
 //	@Failure		500				{object}	response.Response
+//	@Failure		503				{object}	response.Response
 //	@Router			/transfers/{uuid}/process [post]
 func (h *TransferHandler) Process(ctx echo.Context) error {
 	req := new(transfer.ProcessRequest)
@@ -80,3 +82,66 @@ func (h *TransferHandler) Process(ctx echo.Context) error {
 	}
 	return ctx.JSON(response.Success(resp))
 }
+
+// Detail swaggo annotation.
+//
+//	@Summary		Transfer detail
+//	@Description	Get the status of a transfer, pending transfers are settled by the reconciliation
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Transfer UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/{uuid} [get]
+func (h *TransferHandler) Detail(ctx echo.Context) error {
+	req := new(transfer.DetailRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Detail(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
+
+// Cancel swaggo annotation.
+//
+//	@Summary		Cancel transfer
+//	@Description	Cancel an initiated transfer before it is processed
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Authorization token"
+//	@Param			uuid			path		string	true	"Transfer UUID"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfers/{uuid}/cancel [post]
+func (h *TransferHandler) Cancel(ctx echo.Context) error {
+	req := new(transfer.CancelRequest)
+	err := ctx.Bind(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	err = h.va.Validate(req)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	resp, err := h.uc.Cancel(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(resp))
+}
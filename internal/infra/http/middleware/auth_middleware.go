@@ -64,10 +64,13 @@ func userFromToken(token *jwt.Token) user.User {
 	if err != nil {
 		lastLogin = time.Time{}
 	}
+	// Tokens issued before roles existed have no role claim.
+	role, _ := claims["role"].(string)
 	return user.User{
 		Username:    claims["sub"].(string),
 		Email:       claims["email"].(string),
 		PhoneNumber: claims["phone_number"].(string),
+		Role:        role,
 		LastLogin:   lastLogin,
 	}
 }
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/system"
+)
+
+// RequireCBSReady returns a middleware function that answers 503 while the core banking system
+// runs its end of day. Routes that queue their requests for the stand-in pass standIn
+// and are let through, their usecase reads the status from the request context.
+// Every 503 of the route carries a Retry-After header estimated from the end of day schedule.
+func RequireCBSReady(uc *system.Usecase, standIn bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			r, err := uc.CheckReady(ctx.Request().Context(), standIn)
+			if r != nil && r.RetryAfter > 0 {
+				ctx.Response().Before(func() {
+					if ctx.Response().Status == http.StatusServiceUnavailable {
+						response.RetryAfter(ctx, r.RetryAfter)
+					}
+				})
+			}
+			if err != nil {
+				return ctx.JSON(response.Error(err))
+			}
+			c := ContextWithCBSStatus(ctx.Request().Context(), r.Status)
+			ctx.SetRequest(ctx.Request().WithContext(c))
+			return next(ctx)
+		}
+	}
+}
+
+// ContextWithCBSStatus set the core banking system status to the ctx context.
+func ContextWithCBSStatus(ctx context.Context, s cbs.Status) context.Context {
+	return context.WithValue(ctx, cbs.ContextKey, s)
+}
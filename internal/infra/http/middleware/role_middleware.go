@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+)
+
+// RequireRole returns a middleware function that only lets users with one of the roles through.
+// It must run after AuthorizeUser.
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			usr, err := user.FromContext(ctx.Request().Context())
+			if err != nil {
+				return ctx.JSON(response.Unauthorized(&authorizationError{
+					Message: "Invalid token",
+				}))
+			}
+			for _, role := range roles {
+				if usr.HasRole(role) {
+					return next(ctx)
+				}
+			}
+			return ctx.JSON(response.Forbidden(&authorizationError{
+				Message: "Insufficient role",
+			}))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/response"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/pkg/signature"
+)
+
+// defaultClockSkew is how far the timestamp of a callback may be from the server time when none is configured.
+const defaultClockSkew = 5 * time.Minute
+
+// VerifySignature returns a middleware function that rejects the partner callbacks
+// not signed with one of the partner keys, signed too long ago or already received.
+func VerifySignature(cfg *config.Configs, nonces signature.NonceStore) echo.MiddlewareFunc {
+	clockSkew := cfg.Callback.ClockSkew
+	if clockSkew <= 0 {
+		clockSkew = defaultClockSkew
+	}
+	v := signature.NewVerifier(cfg.Callback.Keys, clockSkew, nonces)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			l := log.WithContext(ctx.Request().Context(), "VerifySignature")
+
+			err := v.Verify(ctx.Request())
+			if errors.Is(err, signature.ErrInvalid) {
+				l.Warn().Err(err).Str("key_id", ctx.Request().Header.Get(signature.HeaderKeyID)).Msg("Rejected partner callback")
+				return ctx.JSON(response.Unauthorized(&authorizationError{
+					Message: "Invalid signature",
+				}))
+			}
+			if err != nil {
+				l.Error().Err(err).Msg("Failed to verify partner callback signature")
+				return ctx.JSON(response.Error(pkgerror.InternalServerError()))
+			}
+			return next(ctx)
+		}
+	}
+}
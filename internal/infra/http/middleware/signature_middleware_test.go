@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/pkg/signature"
+)
+
+// memoryNonces is a NonceStore in memory, it fails every call when err is set.
+type memoryNonces struct {
+	used map[string]bool
+	err  error
+}
+
+func (m *memoryNonces) Use(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.used[keyID+nonce] {
+		return false, nil
+	}
+	m.used[keyID+nonce] = true
+	return true, nil
+}
+
+func signedRequest(t *testing.T, secret, body string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/callbacks/tapmoney", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	err := signature.NewSigner("tapmoney", secret).Sign(req)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return req
+}
+
+func TestVerifySignature(t *testing.T) {
+	log.Configure("test")
+
+	tests := []struct {
+		name      string
+		clockSkew time.Duration
+		nonceErr  error
+		request   func(t *testing.T) *http.Request
+		status    int
+	}{
+		{
+			name: "signed",
+			request: func(t *testing.T) *http.Request {
+				return signedRequest(t, "secret", `{"transaction_id":"pay-123","status":"success"}`)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "signed_within_configured_clock_skew",
+			clockSkew: time.Minute,
+			request: func(t *testing.T) *http.Request {
+				return signedRequest(t, "secret", `{"transaction_id":"pay-123","status":"success"}`)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "unsigned",
+			request: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/v1/callbacks/tapmoney", strings.NewReader(`{}`))
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "signed_with_another_secret",
+			request: func(t *testing.T) *http.Request {
+				return signedRequest(t, "other", `{"transaction_id":"pay-123","status":"success"}`)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered_body",
+			request: func(t *testing.T) *http.Request {
+				req := signedRequest(t, "secret", `{"transaction_id":"pay-123","status":"failed"}`)
+				tampered := httptest.NewRequest(http.MethodPost, "/v1/callbacks/tapmoney", strings.NewReader(`{"transaction_id":"pay-123","status":"success"}`))
+				tampered.Header = req.Header
+				return tampered
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "signed_too_long_ago",
+			request: func(t *testing.T) *http.Request {
+				req := signedRequest(t, "secret", `{}`)
+				req.Header.Set(signature.HeaderTimestamp, strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+				return req
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:     "nonce_store_failed",
+			nonceErr: errors.New("redis is down"),
+			request: func(t *testing.T) *http.Request {
+				return signedRequest(t, "secret", `{}`)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := new(config.Configs)
+			cfg.Callback.Keys = map[string]string{"tapmoney": "secret"}
+			cfg.Callback.ClockSkew = tt.clockSkew
+			e := echo.New()
+			e.POST("/v1/callbacks/tapmoney", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, VerifySignature(cfg, &memoryNonces{used: map[string]bool{}, err: tt.nonceErr}))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, tt.request(t))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestVerifySignature_Replayed(t *testing.T) {
+	log.Configure("test")
+
+	cfg := new(config.Configs)
+	cfg.Callback.Keys = map[string]string{"tapmoney": "secret"}
+	var received []string
+	e := echo.New()
+	e.POST("/v1/callbacks/tapmoney", func(c echo.Context) error {
+		body := new(struct {
+			TransactionID string `json:"transaction_id"`
+		})
+		if err := c.Bind(body); err != nil {
+			return err
+		}
+		received = append(received, body.TransactionID)
+		return c.NoContent(http.StatusOK)
+	}, VerifySignature(cfg, &memoryNonces{used: map[string]bool{}}))
+
+	body := `{"transaction_id":"pay-123","status":"success"}`
+	req := signedRequest(t, "secret", body)
+	replay := httptest.NewRequest(http.MethodPost, "/v1/callbacks/tapmoney", strings.NewReader(body))
+	replay.Header = req.Header.Clone()
+
+	first := httptest.NewRecorder()
+	e.ServeHTTP(first, req)
+	second := httptest.NewRecorder()
+	e.ServeHTTP(second, replay)
+
+	if first.Code != http.StatusOK || second.Code != http.StatusUnauthorized {
+		t.Errorf("statuses = %d and %d, want 200 and 401", first.Code, second.Code)
+	}
+	if len(received) != 1 || received[0] != "pay-123" {
+		t.Errorf("handler received %v, want the body once", received)
+	}
+}
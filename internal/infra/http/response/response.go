@@ -2,8 +2,12 @@ package response
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/labstack/echo/v4"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/codes"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
 )
@@ -38,6 +42,8 @@ func Error(err error) (int, Response) {
 			return BadRequest(err)
 		case codes.Conflict:
 			return Conflict(err)
+		case codes.Unavailable:
+			return ServiceUnavailable(err)
 		}
 	}
 	return InternalServerError(err)
@@ -96,3 +102,19 @@ func InternalServerError(err error) (int, Response) {
 		Errors: err,
 	}
 }
+
+// ServiceUnavailable returns status code 503 and error response.
+// Use RetryAfter to tell the client when to try again.
+func ServiceUnavailable(err error) (int, Response) {
+	return http.StatusServiceUnavailable, Response{
+		Title:  "Service Unavailable",
+		Detail: "The service is temporarily unavailable.",
+		Errors: err,
+	}
+}
+
+// RetryAfter sets the Retry-After header to the delay in whole seconds, rounded up.
+func RetryAfter(ctx echo.Context, d time.Duration) {
+	seconds := int64(math.Ceil(d.Seconds()))
+	ctx.Response().Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
@@ -1,6 +1,9 @@
 package server
 
-import "go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/middleware"
+import (
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/middleware"
+)
 
 func (hs *HTTPServer) registerRoutes() {
 	v1 := hs.router.Group("/v1")
@@ -9,16 +12,69 @@ func (hs *HTTPServer) registerRoutes() {
 
 	v1.POST("/users", hs.uh.Create)
 
+	v1.GET("/system/status", hs.syh.GetStatus)
+	v1.GET("/health", hs.hh.Health)
+
+	callbacks := v1.Group("/callbacks", middleware.VerifySignature(hs.cfg, hs.nonces))
+
+	callbacks.POST("/tapmoney", hs.tmh.Callback)
+
 	withAuth := v1.Group("", middleware.AuthorizeUser(hs.cfg))
+	cbsReady := middleware.RequireCBSReady(hs.sys, false)
+	cbsStandIn := middleware.RequireCBSReady(hs.sys, true)
+
+	withAuth.GET("/accounts", hs.ach.GetAccounts)
+	withAuth.GET("/accounts/:number", hs.ach.GetAccount)
+	withAuth.POST("/accounts", hs.ach.Open)
+	withAuth.POST("/accounts/:number/pockets", hs.ach.CreatePocket)
+	withAuth.POST("/pockets/move", hs.ach.Move)
+	withAuth.PATCH("/pockets/:number", hs.ach.RenamePocket)
+	withAuth.DELETE("/pockets/:number", hs.ach.ClosePocket)
+
+	withAuth.POST("/tapmoney/init", hs.tmh.Initiate, cbsReady)
+	withAuth.POST("/tapmoney/:uuid/process", hs.tmh.Process, cbsReady)
+	withAuth.POST("/tapmoney/:uuid/cancel", hs.tmh.Cancel)
+
+	withAuth.POST("/transfers/init", hs.tfh.Initiate, cbsStandIn)
+	withAuth.POST("/transfers/:uuid/process", hs.tfh.Process, cbsStandIn)
+	withAuth.POST("/transfers/:uuid/cancel", hs.tfh.Cancel)
+	withAuth.GET("/transfers/:uuid", hs.tfh.Detail)
 
-	withAuth.POST("/tapmoney/init", hs.tmh.Initiate)
-	withAuth.POST("/tapmoney/:uuid/process", hs.tmh.Process)
+	withAuth.POST("/transfers/bulk", hs.bth.Create, cbsReady)
+	withAuth.GET("/transfers/bulk/:uuid", hs.bth.GetBatch)
+	withAuth.GET("/transfers/bulk/:uuid/report", hs.bth.Report)
 
-	withAuth.POST("/transfers/init", hs.tfh.Initiate)
-	withAuth.POST("/transfers/:uuid/process", hs.tfh.Process)
+	withAuth.POST("/transfers/scheduled", hs.soh.Create)
+	withAuth.GET("/transfers/scheduled", hs.soh.GetStandingOrders)
+	withAuth.GET("/transfers/scheduled/:uuid", hs.soh.GetStandingOrder)
+	withAuth.PATCH("/transfers/scheduled/:uuid", hs.soh.Update)
+	withAuth.DELETE("/transfers/scheduled/:uuid", hs.soh.Delete)
+
+	withAuth.POST("/savings-goals", hs.sgh.Create)
+	withAuth.GET("/savings-goals", hs.sgh.GetGoals)
+	withAuth.GET("/savings-goals/:uuid", hs.sgh.GetGoal)
+	withAuth.GET("/savings-goals/:uuid/sweeps", hs.sgh.GetSweeps)
+	withAuth.DELETE("/savings-goals/:uuid", hs.sgh.Cancel)
+
+	withAuth.GET("/deposits/products", hs.dph.GetProducts)
+	withAuth.POST("/deposits", hs.dph.Place, cbsReady)
+	withAuth.GET("/deposits", hs.dph.GetDeposits)
+	withAuth.GET("/deposits/:uuid", hs.dph.GetDeposit)
+
+	withAuth.GET("/beneficiaries", hs.bh.GetBeneficiaries)
+	withAuth.POST("/beneficiaries", hs.bh.Create)
+	withAuth.POST("/beneficiaries/otp", hs.bh.RequestOTP)
+	withAuth.PATCH("/beneficiaries/:uuid", hs.bh.Update)
+	withAuth.DELETE("/beneficiaries/:uuid", hs.bh.Delete)
 
 	withAuth.GET("/transactions", hs.txh.GetTransactions)
 	withAuth.GET("/transactions/:uuid", hs.txh.GetTransaction)
+	withAuth.GET("/transactions/:uuid/history", hs.txh.GetHistory)
 
 	withAuth.GET("/users/me", hs.uh.GetByUsername)
+
+	ops := withAuth.Group("/ops", middleware.RequireRole(user.RoleOps))
+
+	ops.POST("/transactions/:uuid/reverse", hs.rvh.Reverse)
+	ops.PUT("/accounts/:number/status", hs.ach.ChangeStatus)
 }
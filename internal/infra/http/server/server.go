@@ -10,6 +10,8 @@ import (
 	"github.com/rs/zerolog/log"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/handler"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/system"
+	"go.bankkrud.com/bankkrud/backend/krudapp/pkg/signature"
 )
 
 // HTTPServer represents the main server struct managing configuration, logging, and routing.
@@ -21,6 +23,17 @@ type HTTPServer struct {
 	ah     *handler.AuthenticationHandler
 	uh     *handler.UserHandler
 	txh    *handler.TransactionHandler
+	soh    *handler.StandingOrderHandler
+	bth    *handler.BulkTransferHandler
+	bh     *handler.BeneficiaryHandler
+	rvh    *handler.ReversalHandler
+	ach    *handler.AccountHandler
+	sgh    *handler.SavingsGoalHandler
+	dph    *handler.DepositHandler
+	syh    *handler.SystemHandler
+	hh     *handler.HealthHandler
+	sys    *system.Usecase
+	nonces signature.NonceStore
 }
 
 // NewHTTP returns new Router.
@@ -32,6 +45,17 @@ func NewHTTP(
 	ah *handler.AuthenticationHandler,
 	uh *handler.UserHandler,
 	txh *handler.TransactionHandler,
+	soh *handler.StandingOrderHandler,
+	bth *handler.BulkTransferHandler,
+	bh *handler.BeneficiaryHandler,
+	rvh *handler.ReversalHandler,
+	ach *handler.AccountHandler,
+	sgh *handler.SavingsGoalHandler,
+	dph *handler.DepositHandler,
+	syh *handler.SystemHandler,
+	hh *handler.HealthHandler,
+	sys *system.Usecase,
+	nonces signature.NonceStore,
 ) *HTTPServer {
 	return &HTTPServer{
 		cfg:    cfg,
@@ -41,6 +65,17 @@ func NewHTTP(
 		ah:     ah,
 		uh:     uh,
 		txh:    txh,
+		soh:    soh,
+		bth:    bth,
+		bh:     bh,
+		rvh:    rvh,
+		ach:    ach,
+		sgh:    sgh,
+		dph:    dph,
+		syh:    syh,
+		hh:     hh,
+		sys:    sys,
+		nonces: nonces,
 	}
 }
 
@@ -2,31 +2,73 @@ package infra
 
 import (
 	"github.com/google/wire"
-	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
-	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
-	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/audit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/bulktransfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/otp"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/savingsgoal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standingorder"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/api"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/broker"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/cbssim"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/handler"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/http/server"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/resilience"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/service"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/repo"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/worker"
+	"go.bankkrud.com/bankkrud/backend/krudapp/pkg/signature"
 )
 
 var ProviderSet = wire.NewSet(
-	api.NewCBSAccountAPI, wire.Bind(new(account.Repository), new(*api.CBSAccountAPI)),
-	api.NewCBSStatusAPI, wire.Bind(new(cbs.Service), new(*api.CBSStatusAPI)),
-	api.NewCBSTransferAPI, wire.Bind(new(transfer.Service), new(*api.CBSTransferAPI)),
-	api.NewPaymentGateway, wire.Bind(new(payment.Service), new(*api.PaymentGateway)),
+	cbssim.New,
+	resilience.NewRegistry,
+	api.NewAccountRepository,
+	api.NewCBSService,
+	api.NewTransferService,
+	api.NewDepositService,
+	api.NewBIFastTransferAPI, wire.Bind(new(transfer.BIFastService), new(*api.BIFastTransferAPI)),
+	api.NewSKNTransferAPI, wire.Bind(new(transfer.SKNService), new(*api.SKNTransferAPI)),
+	api.NewRTGSTransferAPI, wire.Bind(new(transfer.RTGSService), new(*api.RTGSTransferAPI)),
+	api.NewPaymentService,
+	api.NewNotificationAPI, wire.Bind(new(notification.Service), new(*api.NotificationAPI)),
 	repo.NewTransactionRepo, wire.Bind(new(transaction.Repository), new(*repo.TransactionRepo)),
 	repo.NewUserRepo, wire.Bind(new(user.Repository), new(*repo.UserRepo)),
+	repo.NewStandingOrderRepo, wire.Bind(new(standingorder.Repository), new(*repo.StandingOrderRepo)),
+	repo.NewBulkTransferRepo, wire.Bind(new(bulktransfer.Repository), new(*repo.BulkTransferRepo)),
+	repo.NewSavingsGoalRepo, wire.Bind(new(savingsgoal.Repository), new(*repo.SavingsGoalRepo)),
+	repo.NewDepositRepo, wire.Bind(new(deposit.Repository), new(*repo.DepositRepo)),
+	repo.NewStandInRepo, wire.Bind(new(standin.Repository), new(*repo.StandInRepo)),
+	repo.NewBeneficiaryRepo, wire.Bind(new(beneficiary.Repository), new(*repo.BeneficiaryRepo)),
+	repo.NewOTPRepo, wire.Bind(new(otp.Repository), new(*repo.OTPRepo)),
+	repo.NewAuditRepo, wire.Bind(new(audit.Repository), new(*repo.AuditRepo)),
+	repo.NewUnitOfWork, wire.Bind(new(uow.UnitOfWork), new(*repo.UnitOfWork)),
+	repo.NewOutboxRepo, wire.Bind(new(outbox.Repository), new(*repo.OutboxRepo)),
+	repo.NewNonceRepo, wire.Bind(new(signature.NonceStore), new(*repo.NonceRepo)),
+	broker.NewPublisher,
 	service.NewAuthService, wire.Bind(new(user.AuthService), new(*service.AuthService)),
 	handler.NewTransferHandler,
 	handler.NewTapMoneyHandler,
 	handler.NewAuthenticationHandler,
 	handler.NewUserHandler,
 	handler.NewTransactionHandler,
+	handler.NewStandingOrderHandler,
+	handler.NewBulkTransferHandler,
+	handler.NewBeneficiaryHandler,
+	handler.NewReversalHandler,
+	handler.NewAccountHandler,
+	handler.NewSavingsGoalHandler,
+	handler.NewDepositHandler,
+	handler.NewSystemHandler,
+	handler.NewHealthHandler,
 	server.NewHTTP,
+	worker.NewWorker,
 )
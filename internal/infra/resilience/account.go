@@ -0,0 +1,89 @@
+package resilience
+
+import (
+	"context"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/breaker"
+)
+
+// AccountRepository calls an account repository through a circuit breaker,
+// retrying the reads and the idempotent writes.
+type AccountRepository struct {
+	next    account.Repository
+	breaker *breaker.Breaker
+}
+
+func NewAccountRepository(next account.Repository, registry *Registry) *AccountRepository {
+	return &AccountRepository{
+		next: next,
+		breaker: registry.Breaker("cbs_account",
+			account.ErrNotFound,
+			account.ErrInsufficientBalance,
+			account.ErrHoldNotFound,
+			account.ErrBalanceNotZero,
+			account.ErrRestricted,
+		),
+	}
+}
+
+func (ar *AccountRepository) Get(ctx context.Context, accountNumber string) (account.Account, error) {
+	return breaker.Call(ctx, ar.breaker, true, func(ctx context.Context) (account.Account, error) {
+		return ar.next.Get(ctx, accountNumber)
+	})
+}
+
+func (ar *AccountRepository) ListByCIF(ctx context.Context, cif string) ([]account.Account, error) {
+	return breaker.Call(ctx, ar.breaker, true, func(ctx context.Context) ([]account.Account, error) {
+		return ar.next.ListByCIF(ctx, cif)
+	})
+}
+
+func (ar *AccountRepository) Create(ctx context.Context, username string) (account.Account, error) {
+	return breaker.Call(ctx, ar.breaker, false, func(ctx context.Context) (account.Account, error) {
+		return ar.next.Create(ctx, username)
+	})
+}
+
+func (ar *AccountRepository) Open(ctx context.Context, acc account.Account) (account.Account, error) {
+	return breaker.Call(ctx, ar.breaker, false, func(ctx context.Context) (account.Account, error) {
+		return ar.next.Open(ctx, acc)
+	})
+}
+
+func (ar *AccountRepository) Rename(ctx context.Context, accountNumber, name string) error {
+	return breaker.Do(ctx, ar.breaker, false, func(ctx context.Context) error {
+		return ar.next.Rename(ctx, accountNumber, name)
+	})
+}
+
+func (ar *AccountRepository) Close(ctx context.Context, accountNumber string) error {
+	return breaker.Do(ctx, ar.breaker, false, func(ctx context.Context) error {
+		return ar.next.Close(ctx, accountNumber)
+	})
+}
+
+func (ar *AccountRepository) SetStatus(ctx context.Context, accountNumber, status string) error {
+	return breaker.Do(ctx, ar.breaker, false, func(ctx context.Context) error {
+		return ar.next.SetStatus(ctx, accountNumber, status)
+	})
+}
+
+// PlaceHold is retried since placing a hold twice under the same reference is a no-op.
+func (ar *AccountRepository) PlaceHold(ctx context.Context, accountNumber, reference string, amount int64) error {
+	return breaker.Do(ctx, ar.breaker, true, func(ctx context.Context) error {
+		return ar.next.PlaceHold(ctx, accountNumber, reference, amount)
+	})
+}
+
+func (ar *AccountRepository) CaptureHold(ctx context.Context, reference string) error {
+	return breaker.Do(ctx, ar.breaker, false, func(ctx context.Context) error {
+		return ar.next.CaptureHold(ctx, reference)
+	})
+}
+
+func (ar *AccountRepository) ReleaseHold(ctx context.Context, reference string) error {
+	return breaker.Do(ctx, ar.breaker, false, func(ctx context.Context) error {
+		return ar.next.ReleaseHold(ctx, reference)
+	})
+}
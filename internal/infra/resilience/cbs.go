@@ -0,0 +1,94 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/breaker"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// CBSService calls the core banking system status service through a circuit breaker.
+type CBSService struct {
+	next    cbs.Service
+	breaker *breaker.Breaker
+}
+
+func NewCBSService(next cbs.Service, registry *Registry) *CBSService {
+	return &CBSService{
+		next:    next,
+		breaker: registry.Breaker("cbs_status"),
+	}
+}
+
+func (cs *CBSService) GetStatus(ctx context.Context) (cbs.Status, error) {
+	return breaker.Call(ctx, cs.breaker, true, cs.next.GetStatus)
+}
+
+// StatusCache caches the status of the core banking system for the TTL.
+// A status older than the TTL is still served for another TTL while a single background call refreshes it,
+// past that the callers wait for a single call to the service.
+type StatusCache struct {
+	next  cbs.Service
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu        sync.RWMutex
+	status    cbs.Status
+	fetchedAt time.Time
+}
+
+func NewStatusCache(next cbs.Service, ttl time.Duration) *StatusCache {
+	return &StatusCache{
+		next: next,
+		ttl:  ttl,
+	}
+}
+
+func (sc *StatusCache) GetStatus(ctx context.Context) (cbs.Status, error) {
+	if sc.ttl <= 0 {
+		return sc.next.GetStatus(ctx)
+	}
+
+	sc.mu.RLock()
+	status, fetchedAt := sc.status, sc.fetchedAt
+	sc.mu.RUnlock()
+
+	age := time.Since(fetchedAt)
+	switch {
+	case age < sc.ttl:
+		return status, nil
+	case age < 2*sc.ttl:
+		sc.group.DoChan("status", func() (any, error) {
+			return sc.refresh(context.WithoutCancel(ctx))
+		})
+		return status, nil
+	}
+
+	res, err, _ := sc.group.Do("status", func() (any, error) {
+		return sc.refresh(ctx)
+	})
+	if err != nil {
+		return cbs.Status{}, err
+	}
+	return res.(cbs.Status), nil
+}
+
+func (sc *StatusCache) refresh(ctx context.Context) (cbs.Status, error) {
+	l := log.WithContext(ctx, "StatusCache.refresh")
+
+	status, err := sc.next.GetStatus(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to Get CBS status")
+		return cbs.Status{}, err
+	}
+
+	sc.mu.Lock()
+	sc.status, sc.fetchedAt = status, time.Now()
+	sc.mu.Unlock()
+
+	return status, nil
+}
@@ -0,0 +1,46 @@
+package resilience
+
+import (
+	"context"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/breaker"
+)
+
+// PaymentService calls a payment service through a circuit breaker.
+// None of the calls is retried since the gateway does not deduplicate them.
+type PaymentService struct {
+	next    payment.Service
+	breaker *breaker.Breaker
+}
+
+func NewPaymentService(next payment.Service, registry *Registry) *PaymentService {
+	return &PaymentService{
+		next:    next,
+		breaker: registry.Breaker("payment"),
+	}
+}
+
+func (ps *PaymentService) Inquiry(ctx context.Context, channel payment.Channel, bill payment.Bill) (payment.Payment, error) {
+	return breaker.Call(ctx, ps.breaker, false, func(ctx context.Context) (payment.Payment, error) {
+		return ps.next.Inquiry(ctx, channel, bill)
+	})
+}
+
+func (ps *PaymentService) Payment(ctx context.Context, bill payment.Bill) (payment.Payment, error) {
+	return breaker.Call(ctx, ps.breaker, false, func(ctx context.Context) (payment.Payment, error) {
+		return ps.next.Payment(ctx, bill)
+	})
+}
+
+func (ps *PaymentService) CancelInquiry(ctx context.Context, paymentID string) error {
+	return breaker.Do(ctx, ps.breaker, false, func(ctx context.Context) error {
+		return ps.next.CancelInquiry(ctx, paymentID)
+	})
+}
+
+func (ps *PaymentService) Reverse(ctx context.Context, rv payment.Reversal) (payment.Payment, error) {
+	return breaker.Call(ctx, ps.breaker, false, func(ctx context.Context) (payment.Payment, error) {
+		return ps.next.Reverse(ctx, rv)
+	})
+}
@@ -0,0 +1,81 @@
+// Package resilience decorates the outbound domain services with circuit breakers, retries and caching.
+package resilience
+
+import (
+	"errors"
+	"sync"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/breaker"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+)
+
+// Registry holds the circuit breakers of the outbound services so their states can be reported.
+type Registry struct {
+	cfg breaker.Config
+
+	mu       sync.Mutex
+	breakers []*breaker.Breaker
+}
+
+func NewRegistry(cfg *config.Configs) *Registry {
+	return &Registry{
+		cfg: breaker.Config{
+			FailureThreshold: cfg.Resilience.FailureThreshold,
+			OpenTimeout:      cfg.Resilience.OpenTimeout,
+			RetryAttempts:    cfg.Resilience.RetryAttempts,
+			RetryBackoff:     cfg.Resilience.RetryBackoff,
+		},
+	}
+}
+
+// Breaker registers a new breaker under the name. The business errors are answers of a healthy service,
+// e.g. an account that is not found, so they do not count as failures.
+func (r *Registry) Breaker(name string, businessErrs ...error) *breaker.Breaker {
+	b := breaker.New(name, r.cfg, func(err error) bool {
+		for _, businessErr := range businessErrs {
+			if errors.Is(err, businessErr) {
+				return false
+			}
+		}
+		return true
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.breakers = append(r.breakers, b)
+	return b
+}
+
+// States returns the current state of every breaker by its name.
+func (r *Registry) States() map[string]breaker.State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	states := make(map[string]breaker.State, len(r.breakers))
+	for _, b := range r.breakers {
+		states[b.Name()] = b.State()
+	}
+	return states
+}
+
+// Health is the state of the outbound services.
+type Health struct {
+	// Status is ok when every breaker is closed, degraded otherwise.
+	Status   string                   `json:"status"`
+	Breakers map[string]breaker.State `json:"breakers"`
+}
+
+// Health returns the states of the breakers and whether any of them is not closed.
+func (r *Registry) Health() Health {
+	h := Health{
+		Status:   "ok",
+		Breakers: r.States(),
+	}
+	for _, state := range h.Breakers {
+		if state != breaker.StateClosed {
+			h.Status = "degraded"
+		}
+	}
+	return h
+}
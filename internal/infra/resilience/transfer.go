@@ -0,0 +1,53 @@
+package resilience
+
+import (
+	"context"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/breaker"
+)
+
+// TransferService calls a transfer service through a circuit breaker,
+// retrying the status reads, the reversals and the fees, which are deduplicated by their remark.
+type TransferService struct {
+	next    transfer.Service
+	breaker *breaker.Breaker
+}
+
+func NewTransferService(next transfer.Service, registry *Registry) *TransferService {
+	return &TransferService{
+		next: next,
+		breaker: registry.Breaker("cbs_transfer",
+			transfer.ErrTransferNotFound,
+			account.ErrNotFound,
+			account.ErrInsufficientBalance,
+			account.ErrRestricted,
+		),
+	}
+}
+
+func (ts *TransferService) Transfer(ctx context.Context, srcAccountNumber, destAccountNumber string, amount int64, remark string) (transfer.Transfer, error) {
+	return breaker.Call(ctx, ts.breaker, false, func(ctx context.Context) (transfer.Transfer, error) {
+		return ts.next.Transfer(ctx, srcAccountNumber, destAccountNumber, amount, remark)
+	})
+}
+
+func (ts *TransferService) GetTransferStatus(ctx context.Context, remark string) (transfer.Transfer, error) {
+	return breaker.Call(ctx, ts.breaker, true, func(ctx context.Context) (transfer.Transfer, error) {
+		return ts.next.GetTransferStatus(ctx, remark)
+	})
+}
+
+func (ts *TransferService) Reverse(ctx context.Context, rv transfer.Reversal) (transfer.Transfer, error) {
+	return breaker.Call(ctx, ts.breaker, true, func(ctx context.Context) (transfer.Transfer, error) {
+		return ts.next.Reverse(ctx, rv)
+	})
+}
+
+func (ts *TransferService) ChargeFee(ctx context.Context, accountNumber string, fee int64, remark string) error {
+	_, err := breaker.Call(ctx, ts.breaker, true, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, ts.next.ChargeFee(ctx, accountNumber, fee, remark)
+	})
+	return err
+}
@@ -54,6 +54,7 @@ func (s *AuthService) GenerateToken(u user.User) (user.Token, error) {
 		"address":       u.Address,
 		"date_of_birth": u.DateOfBirth,
 		"last_login":    u.LastLogin,
+		"role":          u.Role,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
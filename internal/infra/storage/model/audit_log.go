@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+type AuditLog struct {
+	ID           int64 `gorm:"primaryKey"`
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceUUID string
+	Detail       string
+	CreatedAt    time.Time
+}
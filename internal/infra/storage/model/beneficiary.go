@@ -0,0 +1,14 @@
+package model
+
+import "gorm.io/gorm"
+
+type Beneficiary struct {
+	gorm.Model
+	UUID          string `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex"`
+	UserUsername  string
+	BankCode      string
+	AccountNumber string
+	Nickname      string
+	VerifiedName  string
+	Favourite     bool
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BulkTransfer struct {
+	gorm.Model
+	UUID          string `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex"`
+	UserUsername  string
+	SourceAccount string
+	TotalRows     int
+	TotalAmount   int64
+	Status        string
+	CompletedAt   *time.Time
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Deposit struct {
+	gorm.Model
+	UUID                   string `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex"`
+	UserUsername           string
+	SourceAccount          string
+	DepositAccount         string
+	Principal              int64
+	TermMonths             int
+	RateBps                int64
+	StartDate              time.Time
+	MaturityDate           time.Time
+	Instruction            string
+	Status                 string
+	FundingTransactionUUID string
+	Rollovers              int
+	GrossInterest          int64
+	Tax                    int64
+	NetInterest            int64
+}
@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+type OTP struct {
+	CodeHash  string    `json:"code_hash"`
+	Attempts  int       `json:"attempts"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
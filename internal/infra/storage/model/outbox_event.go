@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+)
+
+type OutboxEvent struct {
+	ID            int64 `gorm:"primaryKey"`
+	AggregateType string
+	AggregateUUID string
+	EventType     string
+	Payload       []byte `gorm:"type:jsonb"`
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+// TransactionEvent is the payload of transaction outbox events.
+type TransactionEvent struct {
+	UUID                 string    `json:"uuid"`
+	TransactionType      string    `json:"transaction_type"`
+	Status               string    `json:"status"`
+	StatusReason         string    `json:"status_reason,omitempty"`
+	SourceAccount        string    `json:"source_account,omitempty"`
+	DestinationBankCode  string    `json:"destination_bank_code,omitempty"`
+	DestinationAccount   string    `json:"destination_account,omitempty"`
+	Rail                 string    `json:"rail,omitempty"`
+	Amount               int64     `json:"amount,omitempty"`
+	Fee                  int64     `json:"fee,omitempty"`
+	TransactionReference string    `json:"transaction_reference,omitempty"`
+	Username             string    `json:"username,omitempty"`
+	OccurredAt           time.Time `json:"occurred_at"`
+}
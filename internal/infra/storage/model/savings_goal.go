@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SavingsGoal struct {
+	gorm.Model
+	UUID          string `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex"`
+	UserUsername  string
+	Name          string
+	SourceAccount string
+	PocketAccount string
+	TargetAmount  int64
+	Deadline      time.Time
+	SweepRule     string
+	SweepAmount   int64
+	Frequency     string
+	NextSweepDate *time.Time
+	LastSweptAt   time.Time
+	Status        string
+	Attempts      int
+	ClaimedUntil  *time.Time
+}
+
+type SavingsGoalSweep struct {
+	gorm.Model
+	SavingsGoalUUID string
+	TransactionUUID string
+	Amount          int64
+	Status          string
+	Reason          string
+	SweptAt         time.Time
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+type StandInEntry struct {
+	ID                 int64 `gorm:"primaryKey"`
+	TransactionUUID    string
+	SourceAccount      string
+	DestinationAccount string
+	Amount             int64
+	Status             string
+	Reason             string
+	Attempts           int
+	CreatedAt          time.Time
+	ForwardedAt        *time.Time
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StandingOrder struct {
+	gorm.Model
+	UUID                string `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex"`
+	UserUsername        string
+	SourceAccount       string
+	DestinationBankCode string
+	DestinationAccount  string
+	Amount              int64
+	Note                string
+	Frequency           string
+	StartDate           time.Time
+	EndDate             *time.Time
+	NextRunDate         time.Time
+	Status              string
+	Attempts            int
+	ClaimedUntil        *time.Time
+}
+
+type StandingOrderExecution struct {
+	gorm.Model
+	StandingOrderUUID string
+	TransactionUUID   string
+	Status            string
+	Reason            string
+	Attempt           int
+	ExecutedAt        time.Time
+}
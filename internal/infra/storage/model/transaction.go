@@ -8,12 +8,20 @@ type Transaction struct {
 	gorm.Model
 	UUID                 string `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex"`
 	SourceAccount        string
+	DestinationBankCode  string
 	DestinationAccount   string
 	TransactionType      string
+	BatchUUID            string
+	Origin               string
+	Rail                 string
 	TransactionReference string
 	Status               string
+	StatusReason         string
+	PaymentID            string
+	ReversalOf           string
 	Note                 string
 	Amount               int64
 	Fee                  int64
 	UserUsername         string
+	Version              int64
 }
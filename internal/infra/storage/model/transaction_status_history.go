@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+type TransactionStatusHistory struct {
+	ID              int64 `gorm:"primaryKey"`
+	TransactionUUID string
+	FromStatus      string
+	ToStatus        string
+	Reason          string
+	CreatedAt       time.Time
+}
+
+func (TransactionStatusHistory) TableName() string {
+	return "transaction_status_history"
+}
@@ -20,6 +20,7 @@ type User struct {
 	DateOfBirth  time.Time
 	LastLogin    time.Time
 	Status       string
+	Role         string
 }
 
 func (u *User) MarshalBinary() ([]byte, error) {
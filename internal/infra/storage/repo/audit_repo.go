@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"context"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/audit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+)
+
+type AuditRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditRepo(db *gorm.DB) *AuditRepo {
+	return &AuditRepo{
+		db: db,
+	}
+}
+
+func (r *AuditRepo) Create(ctx context.Context, e audit.Entry) error {
+	return conn(ctx, r.db).Create(&model.AuditLog{
+		Actor:        e.Actor,
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		ResourceUUID: e.ResourceUUID,
+		Detail:       e.Detail,
+	}).Error
+}
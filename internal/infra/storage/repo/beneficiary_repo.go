@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+)
+
+type BeneficiaryRepo struct {
+	db *gorm.DB
+}
+
+func NewBeneficiaryRepo(db *gorm.DB) *BeneficiaryRepo {
+	return &BeneficiaryRepo{
+		db: db,
+	}
+}
+
+func (r *BeneficiaryRepo) Create(ctx context.Context, b beneficiary.Beneficiary) error {
+	err := conn(ctx, r.db).Create(&model.Beneficiary{
+		UUID:          b.UUID,
+		UserUsername:  b.Username,
+		BankCode:      b.BankCode,
+		AccountNumber: b.AccountNumber,
+		Nickname:      b.Nickname,
+		VerifiedName:  b.VerifiedName,
+		Favourite:     b.Favourite,
+	}).Error
+	var pgconnErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgconnErr) && pgconnErr.Code == duplicateKeyErrCode {
+		return beneficiary.ErrDuplicate
+	}
+	return err
+}
+
+func (r *BeneficiaryRepo) GetByUUID(ctx context.Context, uuid string) (beneficiary.Beneficiary, error) {
+	var m model.Beneficiary
+	err := conn(ctx, r.db).
+		Where("uuid = ?", uuid).
+		First(&m).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return beneficiary.Beneficiary{}, beneficiary.ErrNotFound
+	}
+	if err != nil {
+		return beneficiary.Beneficiary{}, err
+	}
+	return beneficiaryFromModel(m), nil
+}
+
+func (r *BeneficiaryRepo) GetByUsername(ctx context.Context, username string) ([]beneficiary.Beneficiary, error) {
+	var models []model.Beneficiary
+	err := conn(ctx, r.db).
+		Where("user_username = ?", username).
+		Order("favourite DESC, nickname").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	beneficiaries := make([]beneficiary.Beneficiary, 0, len(models))
+	for _, m := range models {
+		beneficiaries = append(beneficiaries, beneficiaryFromModel(m))
+	}
+	return beneficiaries, nil
+}
+
+func (r *BeneficiaryRepo) Update(ctx context.Context, b beneficiary.Beneficiary) error {
+	return conn(ctx, r.db).Model(&model.Beneficiary{}).
+		Where("uuid = ?", b.UUID).
+		Select("nickname", "favourite").
+		Updates(&model.Beneficiary{
+			Nickname:  b.Nickname,
+			Favourite: b.Favourite,
+		}).Error
+}
+
+func (r *BeneficiaryRepo) Delete(ctx context.Context, uuid string) error {
+	return conn(ctx, r.db).
+		Where("uuid = ?", uuid).
+		Delete(&model.Beneficiary{}).Error
+}
+
+func beneficiaryFromModel(m model.Beneficiary) beneficiary.Beneficiary {
+	return beneficiary.Beneficiary{
+		UUID:          m.UUID,
+		Username:      m.UserUsername,
+		BankCode:      m.BankCode,
+		AccountNumber: m.AccountNumber,
+		Nickname:      m.Nickname,
+		VerifiedName:  m.VerifiedName,
+		Favourite:     m.Favourite,
+		CreatedAt:     m.CreatedAt,
+	}
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/bulktransfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+)
+
+type BulkTransferRepo struct {
+	db *gorm.DB
+}
+
+func NewBulkTransferRepo(db *gorm.DB) *BulkTransferRepo {
+	return &BulkTransferRepo{
+		db: db,
+	}
+}
+
+func (r *BulkTransferRepo) Create(ctx context.Context, batch bulktransfer.Batch) error {
+	return conn(ctx, r.db).Create(&model.BulkTransfer{
+		UUID:          batch.UUID,
+		UserUsername:  batch.Username,
+		SourceAccount: batch.SourceAccount,
+		TotalRows:     batch.TotalRows,
+		TotalAmount:   batch.TotalAmount,
+		Status:        batch.Status,
+	}).Error
+}
+
+func (r *BulkTransferRepo) GetByUUID(ctx context.Context, uuid string) (bulktransfer.Batch, error) {
+	var m model.BulkTransfer
+	err := conn(ctx, r.db).
+		Where("uuid = ?", uuid).
+		First(&m).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return bulktransfer.Batch{}, bulktransfer.ErrNotFound
+	}
+	if err != nil {
+		return bulktransfer.Batch{}, err
+	}
+	return bulkTransferFromModel(m), nil
+}
+
+func (r *BulkTransferRepo) GetByStatus(ctx context.Context, status string) ([]bulktransfer.Batch, error) {
+	var ms []model.BulkTransfer
+	err := conn(ctx, r.db).
+		Where("status = ?", status).
+		Order("created_at").
+		Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]bulktransfer.Batch, 0, len(ms))
+	for _, m := range ms {
+		batches = append(batches, bulkTransferFromModel(m))
+	}
+	return batches, nil
+}
+
+func (r *BulkTransferRepo) Update(ctx context.Context, batch bulktransfer.Batch) error {
+	m := model.BulkTransfer{
+		Status: batch.Status,
+	}
+	if !batch.CompletedAt.IsZero() {
+		m.CompletedAt = &batch.CompletedAt
+	}
+	return conn(ctx, r.db).Model(&model.BulkTransfer{}).
+		Where("uuid = ?", batch.UUID).
+		Updates(&m).Error
+}
+
+func bulkTransferFromModel(m model.BulkTransfer) bulktransfer.Batch {
+	batch := bulktransfer.Batch{
+		UUID:          m.UUID,
+		Username:      m.UserUsername,
+		SourceAccount: m.SourceAccount,
+		TotalRows:     m.TotalRows,
+		TotalAmount:   m.TotalAmount,
+		Status:        m.Status,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.CompletedAt != nil {
+		batch.CompletedAt = *m.CompletedAt
+	}
+	return batch
+}
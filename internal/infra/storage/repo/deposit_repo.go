@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+)
+
+type DepositRepo struct {
+	db *gorm.DB
+}
+
+func NewDepositRepo(db *gorm.DB) *DepositRepo {
+	return &DepositRepo{
+		db: db,
+	}
+}
+
+func (r *DepositRepo) Create(ctx context.Context, d deposit.Deposit) error {
+	m := depositToModel(d)
+	return conn(ctx, r.db).Create(&m).Error
+}
+
+func (r *DepositRepo) GetByUUID(ctx context.Context, uuid string) (deposit.Deposit, error) {
+	var m model.Deposit
+	err := conn(ctx, r.db).
+		Where("uuid = ?", uuid).
+		First(&m).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return deposit.Deposit{}, deposit.ErrNotFound
+	}
+	if err != nil {
+		return deposit.Deposit{}, err
+	}
+	return depositFromModel(m), nil
+}
+
+func (r *DepositRepo) GetByUsername(ctx context.Context, username string) ([]deposit.Deposit, error) {
+	return r.find(conn(ctx, r.db).
+		Where("user_username = ?", username).
+		Order("created_at DESC"))
+}
+
+func (r *DepositRepo) GetDue(ctx context.Context, date time.Time) ([]deposit.Deposit, error) {
+	return r.find(conn(ctx, r.db).
+		Where("status = ? AND maturity_date <= ?", deposit.StatusActive, date).
+		Order("maturity_date"))
+}
+
+func (r *DepositRepo) GetPending(ctx context.Context) ([]deposit.Deposit, error) {
+	return r.find(conn(ctx, r.db).
+		Where("status = ?", deposit.StatusPending).
+		Order("id"))
+}
+
+func (r *DepositRepo) Update(ctx context.Context, d deposit.Deposit) error {
+	m := depositToModel(d)
+	return conn(ctx, r.db).Model(&model.Deposit{}).
+		Where("uuid = ?", d.UUID).
+		Select("principal", "rate_bps", "start_date", "maturity_date", "status", "rollovers",
+			"gross_interest", "tax", "net_interest").
+		Updates(&m).Error
+}
+
+func (r *DepositRepo) find(db *gorm.DB) ([]deposit.Deposit, error) {
+	var models []model.Deposit
+	err := db.Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	deposits := make([]deposit.Deposit, 0, len(models))
+	for _, m := range models {
+		deposits = append(deposits, depositFromModel(m))
+	}
+	return deposits, nil
+}
+
+func depositToModel(d deposit.Deposit) model.Deposit {
+	return model.Deposit{
+		UUID:                   d.UUID,
+		UserUsername:           d.Username,
+		SourceAccount:          d.SourceAccount,
+		DepositAccount:         d.DepositAccount,
+		Principal:              d.Principal,
+		TermMonths:             d.TermMonths,
+		RateBps:                d.RateBps,
+		StartDate:              d.StartDate,
+		MaturityDate:           d.MaturityDate,
+		Instruction:            d.Instruction,
+		Status:                 d.Status,
+		FundingTransactionUUID: d.FundingTransactionUUID,
+		Rollovers:              d.Rollovers,
+		GrossInterest:          d.GrossInterest,
+		Tax:                    d.Tax,
+		NetInterest:            d.NetInterest,
+	}
+}
+
+func depositFromModel(m model.Deposit) deposit.Deposit {
+	return deposit.Deposit{
+		UUID:                   m.UUID,
+		Username:               m.UserUsername,
+		SourceAccount:          m.SourceAccount,
+		DepositAccount:         m.DepositAccount,
+		Principal:              m.Principal,
+		TermMonths:             m.TermMonths,
+		RateBps:                m.RateBps,
+		StartDate:              m.StartDate,
+		MaturityDate:           m.MaturityDate,
+		Instruction:            m.Instruction,
+		Status:                 m.Status,
+		FundingTransactionUUID: m.FundingTransactionUUID,
+		Rollovers:              m.Rollovers,
+		GrossInterest:          m.GrossInterest,
+		Tax:                    m.Tax,
+		NetInterest:            m.NetInterest,
+		CreatedAt:              m.CreatedAt,
+	}
+}
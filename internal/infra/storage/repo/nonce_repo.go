@@ -0,0 +1,27 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const signatureNonceKey = "signature:%s:nonce:%s"
+
+// NonceRepo remembers the nonces of the signed partner callbacks.
+type NonceRepo struct {
+	rdb *redis.Client
+}
+
+func NewNonceRepo(rdb *redis.Client) *NonceRepo {
+	return &NonceRepo{
+		rdb: rdb,
+	}
+}
+
+func (r *NonceRepo) Use(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	redisKey := fmt.Sprintf(signatureNonceKey, keyID, nonce)
+	return r.rdb.SetNX(ctx, redisKey, 1, ttl).Result()
+}
@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/otp"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+)
+
+const userOTPKey = "user:%s:otp:%s"
+
+type OTPRepo struct {
+	rdb *redis.Client
+}
+
+func NewOTPRepo(rdb *redis.Client) *OTPRepo {
+	return &OTPRepo{
+		rdb: rdb,
+	}
+}
+
+func (r *OTPRepo) Save(ctx context.Context, o otp.OTP) error {
+	b, err := json.Marshal(model.OTP{
+		CodeHash:  o.CodeHash,
+		Attempts:  o.Attempts,
+		ExpiresAt: o.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	redisKey := fmt.Sprintf(userOTPKey, o.Username, o.Purpose)
+	return r.rdb.Set(ctx, redisKey, string(b), time.Until(o.ExpiresAt)).Err()
+}
+
+func (r *OTPRepo) Get(ctx context.Context, username, purpose string) (otp.OTP, error) {
+	redisKey := fmt.Sprintf(userOTPKey, username, purpose)
+	s, err := r.rdb.Get(ctx, redisKey).Result()
+	if err != nil && errors.Is(err, redis.Nil) {
+		return otp.OTP{}, otp.ErrNotFound
+	}
+	if err != nil {
+		return otp.OTP{}, err
+	}
+	var m model.OTP
+	err = json.Unmarshal([]byte(s), &m)
+	if err != nil {
+		return otp.OTP{}, err
+	}
+	return otp.OTP{
+		Username:  username,
+		Purpose:   purpose,
+		CodeHash:  m.CodeHash,
+		Attempts:  m.Attempts,
+		ExpiresAt: m.ExpiresAt,
+	}, nil
+}
+
+func (r *OTPRepo) Delete(ctx context.Context, username, purpose string) error {
+	redisKey := fmt.Sprintf(userOTPKey, username, purpose)
+	return r.rdb.Del(ctx, redisKey).Err()
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+)
+
+// outboxRelayLock is the key of the advisory lock held by the running relay.
+const outboxRelayLock int64 = 0x6f7574626f78
+
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepo(db *gorm.DB) *OutboxRepo {
+	return &OutboxRepo{
+		db: db,
+	}
+}
+
+func (r *OutboxRepo) Lock(ctx context.Context) (bool, error) {
+	var locked bool
+	err := conn(ctx, r.db).
+		Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLock).
+		Scan(&locked).Error
+	return locked, err
+}
+
+func (r *OutboxRepo) GetUnpublished(ctx context.Context, limit int) ([]outbox.Event, error) {
+	var models []model.OutboxEvent
+	err := conn(ctx, r.db).
+		Where("published_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	events := make([]outbox.Event, 0, len(models))
+	for _, m := range models {
+		events = append(events, outbox.Event{
+			ID:            m.ID,
+			AggregateType: m.AggregateType,
+			AggregateUUID: m.AggregateUUID,
+			Type:          m.EventType,
+			Payload:       m.Payload,
+			CreatedAt:     m.CreatedAt,
+		})
+	}
+	return events, nil
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&model.OutboxEvent{}).
+		Where("id = ?", id).
+		UpdateColumn("published_at", time.Now()).Error
+}
+
+// insertOutboxEvent records an event with the JSON payload using db,
+// which must be the database transaction of the state change.
+func insertOutboxEvent(db *gorm.DB, aggregateType, aggregateUUID, eventType string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return db.Create(&model.OutboxEvent{
+		AggregateType: aggregateType,
+		AggregateUUID: aggregateUUID,
+		EventType:     eventType,
+		Payload:       b,
+	}).Error
+}
@@ -0,0 +1,188 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/savingsgoal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SavingsGoalRepo struct {
+	db *gorm.DB
+}
+
+func NewSavingsGoalRepo(db *gorm.DB) *SavingsGoalRepo {
+	return &SavingsGoalRepo{
+		db: db,
+	}
+}
+
+func (r *SavingsGoalRepo) Create(ctx context.Context, goal savingsgoal.Goal) error {
+	m := savingsGoalToModel(goal)
+	return conn(ctx, r.db).Create(&m).Error
+}
+
+func (r *SavingsGoalRepo) GetByUUID(ctx context.Context, uuid string) (savingsgoal.Goal, error) {
+	var m model.SavingsGoal
+	err := conn(ctx, r.db).
+		Where("uuid = ?", uuid).
+		First(&m).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return savingsgoal.Goal{}, savingsgoal.ErrNotFound
+	}
+	if err != nil {
+		return savingsgoal.Goal{}, err
+	}
+	return savingsGoalFromModel(m), nil
+}
+
+func (r *SavingsGoalRepo) GetByUsername(ctx context.Context, username string) ([]savingsgoal.Goal, error) {
+	var models []model.SavingsGoal
+	err := conn(ctx, r.db).
+		Where("user_username = ?", username).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	goals := make([]savingsgoal.Goal, 0, len(models))
+	for _, m := range models {
+		goals = append(goals, savingsGoalFromModel(m))
+	}
+	return goals, nil
+}
+
+func (r *SavingsGoalRepo) ClaimActive(ctx context.Context, at time.Time, lease time.Duration) ([]savingsgoal.Goal, error) {
+	var goals []savingsgoal.Goal
+	err := conn(ctx, r.db).Transaction(func(db *gorm.DB) error {
+		now := time.Now()
+		var models []model.SavingsGoal
+		err := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", savingsgoal.StatusActive).
+			Where("sweep_rule = ? OR (sweep_rule = ? AND next_sweep_date <= ?)",
+				savingsgoal.SweepRoundUp, savingsgoal.SweepScheduled, at).
+			Where("claimed_until IS NULL OR claimed_until < ?", now).
+			Order("id").
+			Find(&models).Error
+		if err != nil || len(models) == 0 {
+			return err
+		}
+		ids := make([]uint, 0, len(models))
+		for _, m := range models {
+			ids = append(ids, m.ID)
+		}
+		claimedUntil := now.Add(lease)
+		err = db.Model(&model.SavingsGoal{}).
+			Where("id IN ?", ids).
+			Update("claimed_until", claimedUntil).Error
+		if err != nil {
+			return err
+		}
+		for _, m := range models {
+			m.ClaimedUntil = &claimedUntil
+			goals = append(goals, savingsGoalFromModel(m))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *SavingsGoalRepo) Update(ctx context.Context, goal savingsgoal.Goal) error {
+	m := savingsGoalToModel(goal)
+	return conn(ctx, r.db).Model(&model.SavingsGoal{}).
+		Where("uuid = ?", goal.UUID).
+		Select("next_sweep_date", "last_swept_at", "status", "attempts", "claimed_until").
+		Updates(&m).Error
+}
+
+func (r *SavingsGoalRepo) CreateSweep(ctx context.Context, sweep savingsgoal.Sweep) error {
+	return conn(ctx, r.db).Create(&model.SavingsGoalSweep{
+		SavingsGoalUUID: sweep.GoalUUID,
+		TransactionUUID: sweep.TransactionUUID,
+		Amount:          sweep.Amount,
+		Status:          sweep.Status,
+		Reason:          sweep.Reason,
+		SweptAt:         sweep.SweptAt,
+	}).Error
+}
+
+func (r *SavingsGoalRepo) GetSweeps(ctx context.Context, goalUUID string) ([]savingsgoal.Sweep, error) {
+	var models []model.SavingsGoalSweep
+	err := conn(ctx, r.db).
+		Where("savings_goal_uuid = ?", goalUUID).
+		Order("swept_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	sweeps := make([]savingsgoal.Sweep, 0, len(models))
+	for _, m := range models {
+		sweeps = append(sweeps, savingsgoal.Sweep{
+			GoalUUID:        m.SavingsGoalUUID,
+			TransactionUUID: m.TransactionUUID,
+			Amount:          m.Amount,
+			Status:          m.Status,
+			Reason:          m.Reason,
+			SweptAt:         m.SweptAt,
+		})
+	}
+	return sweeps, nil
+}
+
+func savingsGoalToModel(goal savingsgoal.Goal) model.SavingsGoal {
+	m := model.SavingsGoal{
+		UUID:          goal.UUID,
+		UserUsername:  goal.Username,
+		Name:          goal.Name,
+		SourceAccount: goal.SourceAccount,
+		PocketAccount: goal.PocketAccount,
+		TargetAmount:  goal.TargetAmount,
+		Deadline:      goal.Deadline,
+		SweepRule:     goal.SweepRule,
+		SweepAmount:   goal.SweepAmount,
+		Frequency:     goal.Frequency,
+		LastSweptAt:   goal.LastSweptAt,
+		Status:        goal.Status,
+		Attempts:      goal.Attempts,
+	}
+	if !goal.NextSweepDate.IsZero() {
+		m.NextSweepDate = &goal.NextSweepDate
+	}
+	if !goal.ClaimedUntil.IsZero() {
+		m.ClaimedUntil = &goal.ClaimedUntil
+	}
+	return m
+}
+
+func savingsGoalFromModel(m model.SavingsGoal) savingsgoal.Goal {
+	goal := savingsgoal.Goal{
+		UUID:          m.UUID,
+		Username:      m.UserUsername,
+		Name:          m.Name,
+		SourceAccount: m.SourceAccount,
+		PocketAccount: m.PocketAccount,
+		TargetAmount:  m.TargetAmount,
+		Deadline:      m.Deadline,
+		SweepRule:     m.SweepRule,
+		SweepAmount:   m.SweepAmount,
+		Frequency:     m.Frequency,
+		LastSweptAt:   m.LastSweptAt,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.NextSweepDate != nil {
+		goal.NextSweepDate = *m.NextSweepDate
+	}
+	if m.ClaimedUntil != nil {
+		goal.ClaimedUntil = *m.ClaimedUntil
+	}
+	return goal
+}
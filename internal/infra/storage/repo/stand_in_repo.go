@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"context"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+)
+
+type StandInRepo struct {
+	db *gorm.DB
+}
+
+func NewStandInRepo(db *gorm.DB) *StandInRepo {
+	return &StandInRepo{
+		db: db,
+	}
+}
+
+func (r *StandInRepo) Enqueue(ctx context.Context, e standin.Entry) error {
+	m := standInEntryToModel(e)
+	return conn(ctx, r.db).Create(&m).Error
+}
+
+func (r *StandInRepo) GetQueued(ctx context.Context) ([]standin.Entry, error) {
+	var models []model.StandInEntry
+	err := conn(ctx, r.db).
+		Where("status = ?", standin.StatusQueued).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]standin.Entry, 0, len(models))
+	for _, m := range models {
+		entries = append(entries, standInEntryFromModel(m))
+	}
+	return entries, nil
+}
+
+func (r *StandInRepo) GetQueuedAmount(ctx context.Context, sourceAccount string) (int64, error) {
+	var amount int64
+	err := conn(ctx, r.db).Model(&model.StandInEntry{}).
+		Where("source_account = ? AND status = ?", sourceAccount, standin.StatusQueued).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&amount).Error
+	return amount, err
+}
+
+func (r *StandInRepo) Update(ctx context.Context, e standin.Entry) error {
+	m := standInEntryToModel(e)
+	return conn(ctx, r.db).Model(&model.StandInEntry{}).
+		Where("transaction_uuid = ?", e.TransactionUUID).
+		Select("status", "reason", "attempts", "forwarded_at").
+		Updates(&m).Error
+}
+
+func standInEntryToModel(e standin.Entry) model.StandInEntry {
+	m := model.StandInEntry{
+		ID:                 e.Sequence,
+		TransactionUUID:    e.TransactionUUID,
+		SourceAccount:      e.SourceAccount,
+		DestinationAccount: e.DestinationAccount,
+		Amount:             e.Amount,
+		Status:             e.Status,
+		Reason:             e.Reason,
+		Attempts:           e.Attempts,
+		CreatedAt:          e.CreatedAt,
+	}
+	if !e.ForwardedAt.IsZero() {
+		m.ForwardedAt = &e.ForwardedAt
+	}
+	return m
+}
+
+func standInEntryFromModel(m model.StandInEntry) standin.Entry {
+	e := standin.Entry{
+		Sequence:           m.ID,
+		TransactionUUID:    m.TransactionUUID,
+		SourceAccount:      m.SourceAccount,
+		DestinationAccount: m.DestinationAccount,
+		Amount:             m.Amount,
+		Status:             m.Status,
+		Reason:             m.Reason,
+		Attempts:           m.Attempts,
+		CreatedAt:          m.CreatedAt,
+	}
+	if m.ForwardedAt != nil {
+		e.ForwardedAt = *m.ForwardedAt
+	}
+	return e
+}
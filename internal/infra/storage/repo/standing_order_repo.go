@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standingorder"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StandingOrderRepo struct {
+	db *gorm.DB
+}
+
+func NewStandingOrderRepo(db *gorm.DB) *StandingOrderRepo {
+	return &StandingOrderRepo{
+		db: db,
+	}
+}
+
+func (r *StandingOrderRepo) Create(ctx context.Context, order standingorder.StandingOrder) error {
+	m := standingOrderToModel(order)
+	return conn(ctx, r.db).Create(&m).Error
+}
+
+func (r *StandingOrderRepo) GetByUUID(ctx context.Context, uuid string) (standingorder.StandingOrder, error) {
+	var m model.StandingOrder
+	err := conn(ctx, r.db).
+		Where("uuid = ?", uuid).
+		First(&m).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return standingorder.StandingOrder{}, standingorder.ErrNotFound
+	}
+	if err != nil {
+		return standingorder.StandingOrder{}, err
+	}
+	return standingOrderFromModel(m), nil
+}
+
+func (r *StandingOrderRepo) GetByUsername(ctx context.Context, username string) ([]standingorder.StandingOrder, error) {
+	var models []model.StandingOrder
+	err := conn(ctx, r.db).
+		Where("user_username = ?", username).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]standingorder.StandingOrder, 0, len(models))
+	for _, m := range models {
+		orders = append(orders, standingOrderFromModel(m))
+	}
+	return orders, nil
+}
+
+func (r *StandingOrderRepo) ClaimDue(ctx context.Context, at time.Time, lease time.Duration) ([]standingorder.StandingOrder, error) {
+	var orders []standingorder.StandingOrder
+	err := conn(ctx, r.db).Transaction(func(db *gorm.DB) error {
+		now := time.Now()
+		var models []model.StandingOrder
+		err := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_run_date <= ?", standingorder.StatusActive, at).
+			Where("claimed_until IS NULL OR claimed_until < ?", now).
+			Order("next_run_date").
+			Find(&models).Error
+		if err != nil || len(models) == 0 {
+			return err
+		}
+		ids := make([]uint, 0, len(models))
+		for _, m := range models {
+			ids = append(ids, m.ID)
+		}
+		claimedUntil := now.Add(lease)
+		err = db.Model(&model.StandingOrder{}).
+			Where("id IN ?", ids).
+			Update("claimed_until", claimedUntil).Error
+		if err != nil {
+			return err
+		}
+		for _, m := range models {
+			m.ClaimedUntil = &claimedUntil
+			orders = append(orders, standingOrderFromModel(m))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *StandingOrderRepo) Update(ctx context.Context, order standingorder.StandingOrder) error {
+	m := standingOrderToModel(order)
+	return conn(ctx, r.db).Model(&model.StandingOrder{}).
+		Where("uuid = ?", order.UUID).
+		Select("amount", "note", "end_date", "next_run_date", "status", "attempts", "claimed_until").
+		Updates(&m).Error
+}
+
+func (r *StandingOrderRepo) CreateExecution(ctx context.Context, execution standingorder.Execution) error {
+	return conn(ctx, r.db).Create(&model.StandingOrderExecution{
+		StandingOrderUUID: execution.OrderUUID,
+		TransactionUUID:   execution.TransactionUUID,
+		Status:            execution.Status,
+		Reason:            execution.Reason,
+		Attempt:           execution.Attempt,
+		ExecutedAt:        execution.ExecutedAt,
+	}).Error
+}
+
+func standingOrderToModel(order standingorder.StandingOrder) model.StandingOrder {
+	m := model.StandingOrder{
+		UUID:                order.UUID,
+		UserUsername:        order.Username,
+		SourceAccount:       order.SourceAccount,
+		DestinationBankCode: order.DestinationBankCode,
+		DestinationAccount:  order.DestinationAccount,
+		Amount:              order.Amount,
+		Note:                order.Note,
+		Frequency:           order.Frequency,
+		StartDate:           order.StartDate,
+		NextRunDate:         order.NextRunDate,
+		Status:              order.Status,
+		Attempts:            order.Attempts,
+	}
+	if !order.EndDate.IsZero() {
+		m.EndDate = &order.EndDate
+	}
+	if !order.ClaimedUntil.IsZero() {
+		m.ClaimedUntil = &order.ClaimedUntil
+	}
+	return m
+}
+
+func standingOrderFromModel(m model.StandingOrder) standingorder.StandingOrder {
+	order := standingorder.StandingOrder{
+		UUID:                m.UUID,
+		Username:            m.UserUsername,
+		SourceAccount:       m.SourceAccount,
+		DestinationBankCode: m.DestinationBankCode,
+		DestinationAccount:  m.DestinationAccount,
+		Amount:              m.Amount,
+		Note:                m.Note,
+		Frequency:           m.Frequency,
+		StartDate:           m.StartDate,
+		NextRunDate:         m.NextRunDate,
+		Status:              m.Status,
+		Attempts:            m.Attempts,
+		CreatedAt:           m.CreatedAt,
+	}
+	if m.EndDate != nil {
+		order.EndDate = *m.EndDate
+	}
+	if m.ClaimedUntil != nil {
+		order.ClaimedUntil = *m.ClaimedUntil
+	}
+	return order
+}
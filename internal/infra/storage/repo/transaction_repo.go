@@ -2,11 +2,16 @@ package repo
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/storage/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TransactionRepo struct {
@@ -25,7 +30,7 @@ func (r *TransactionRepo) GetByUUID(ctx context.Context, tfuuid string) (transac
 	if err != nil {
 		return transaction.Transaction{}, err
 	}
-	res := r.db.WithContext(ctx).
+	res := conn(ctx, r.db).
 		Where("uuid = ?", id).
 		First(&m)
 	if res.Error != nil {
@@ -34,20 +39,31 @@ func (r *TransactionRepo) GetByUUID(ctx context.Context, tfuuid string) (transac
 	return transaction.Transaction{
 		UUID:                 m.UUID,
 		SourceAccount:        m.SourceAccount,
+		DestinationBankCode:  m.DestinationBankCode,
 		DestinationAccount:   m.DestinationAccount,
 		TransactionType:      m.TransactionType,
+		Rail:                 m.Rail,
+		BatchUUID:            m.BatchUUID,
+		Origin:               m.Origin,
+		PaymentID:            m.PaymentID,
+		ReversalOf:           m.ReversalOf,
 		TransactionReference: m.TransactionReference,
 		Status:               m.Status,
+		StatusReason:         m.StatusReason,
 		Note:                 m.Note,
 		Amount:               m.Amount,
 		Fee:                  m.Fee,
+		Username:             m.UserUsername,
 		ProcessedAt:          m.CreatedAt,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+		Version:              m.Version,
 	}, nil
 }
 
 func (r *TransactionRepo) GetByParams(ctx context.Context, params map[string]any) ([]transaction.Transaction, error) {
 	var models []model.Transaction
-	res := r.db.WithContext(ctx).
+	res := conn(ctx, r.db).
 		Where(params).
 		Find(&models)
 	if res.Error != nil {
@@ -58,47 +74,366 @@ func (r *TransactionRepo) GetByParams(ctx context.Context, params map[string]any
 		transactions = append(transactions, transaction.Transaction{
 			UUID:                 m.UUID,
 			SourceAccount:        m.SourceAccount,
+			DestinationBankCode:  m.DestinationBankCode,
 			DestinationAccount:   m.DestinationAccount,
 			TransactionType:      m.TransactionType,
+			Rail:                 m.Rail,
+			BatchUUID:            m.BatchUUID,
+			Origin:               m.Origin,
+			PaymentID:            m.PaymentID,
+			ReversalOf:           m.ReversalOf,
 			TransactionReference: m.TransactionReference,
 			Status:               m.Status,
+			StatusReason:         m.StatusReason,
 			Note:                 m.Note,
 			Amount:               m.Amount,
 			Fee:                  m.Fee,
+			Username:             m.UserUsername,
 			ProcessedAt:          m.CreatedAt,
+			CreatedAt:            m.CreatedAt,
+			UpdatedAt:            m.UpdatedAt,
+			Version:              m.Version,
 		})
 	}
 	return transactions, nil
 }
 
 func (r *TransactionRepo) Create(ctx context.Context, tx transaction.Transaction) error {
-	res := r.db.WithContext(ctx).Create(&model.Transaction{
-		UUID:                 tx.UUID,
-		SourceAccount:        tx.SourceAccount,
-		DestinationAccount:   tx.DestinationAccount,
-		TransactionType:      tx.TransactionType,
-		TransactionReference: tx.TransactionReference,
-		Status:               tx.Status,
-		Note:                 tx.Note,
-		Amount:               tx.Amount,
-		Fee:                  tx.Fee,
-		UserUsername:         tx.Username,
-	})
-	return res.Error
-}
-
-func (r *TransactionRepo) Update(ctx context.Context, tx transaction.Transaction) error {
-	res := r.db.WithContext(ctx).Model(&model.Transaction{}).
-		Where("uuid = ?", tx.UUID).
-		Updates(&model.Transaction{
+	err := conn(ctx, r.db).Transaction(func(db *gorm.DB) error {
+		err := db.Create(&model.Transaction{
+			UUID:                 tx.UUID,
 			SourceAccount:        tx.SourceAccount,
+			DestinationBankCode:  tx.DestinationBankCode,
 			DestinationAccount:   tx.DestinationAccount,
 			TransactionType:      tx.TransactionType,
+			Rail:                 tx.Rail,
+			BatchUUID:            tx.BatchUUID,
+			Origin:               tx.Origin,
+			PaymentID:            tx.PaymentID,
+			ReversalOf:           tx.ReversalOf,
 			TransactionReference: tx.TransactionReference,
 			Status:               tx.Status,
+			StatusReason:         tx.StatusReason,
 			Note:                 tx.Note,
 			Amount:               tx.Amount,
 			Fee:                  tx.Fee,
+			UserUsername:         tx.Username,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = insertStatusChange(db, tx.UUID, "", tx.Status, tx.StatusReason)
+		if err != nil {
+			return err
+		}
+		return insertOutboxEvent(db, outbox.AggregateTransaction, tx.UUID,
+			outbox.EventTransactionCreated, transactionEvent(tx))
+	})
+	var pgconnErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgconnErr) && pgconnErr.Code == duplicateKeyErrCode {
+		return transaction.ErrDuplicate
+	}
+	return err
+}
+
+func (r *TransactionRepo) Update(ctx context.Context, tx transaction.Transaction) error {
+	return conn(ctx, r.db).Transaction(func(db *gorm.DB) error {
+		var current model.Transaction
+		err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("status", "version").
+			Where("uuid = ?", tx.UUID).
+			First(&current).Error
+		if err != nil {
+			return err
+		}
+		if current.Version != tx.Version {
+			return transaction.ErrConflict
+		}
+		statusChanged := current.Status != tx.Status
+		if statusChanged && !transaction.CanTransition(current.Status, tx.Status) {
+			return &transaction.TransitionError{From: current.Status, To: tx.Status}
+		}
+
+		// Select the columns so zero values, e.g. a cleared status reason, are written too.
+		err = db.Model(&model.Transaction{}).
+			Where("uuid = ? AND version = ?", tx.UUID, tx.Version).
+			Select("source_account", "destination_bank_code", "destination_account", "transaction_type",
+				"rail", "batch_uuid", "transaction_reference", "status", "status_reason", "payment_id", "note",
+				"amount", "fee", "version").
+			Updates(&model.Transaction{
+				SourceAccount:        tx.SourceAccount,
+				DestinationBankCode:  tx.DestinationBankCode,
+				DestinationAccount:   tx.DestinationAccount,
+				TransactionType:      tx.TransactionType,
+				Rail:                 tx.Rail,
+				BatchUUID:            tx.BatchUUID,
+				Origin:               tx.Origin,
+				PaymentID:            tx.PaymentID,
+				ReversalOf:           tx.ReversalOf,
+				TransactionReference: tx.TransactionReference,
+				Status:               tx.Status,
+				StatusReason:         tx.StatusReason,
+				Note:                 tx.Note,
+				Amount:               tx.Amount,
+				Fee:                  tx.Fee,
+				Version:              tx.Version + 1,
+			}).Error
+		if err != nil {
+			return err
+		}
+		if statusChanged {
+			err = insertStatusChange(db, tx.UUID, current.Status, tx.Status, tx.StatusReason)
+			if err != nil {
+				return err
+			}
+		}
+		return insertOutboxEvent(db, outbox.AggregateTransaction, tx.UUID,
+			outbox.EventTransactionUpdated, transactionEvent(tx))
+	})
+}
+
+func (r *TransactionRepo) Claim(ctx context.Context, tfuuid, reason string) (transaction.Transaction, error) {
+	var m model.Transaction
+	err := conn(ctx, r.db).Transaction(func(db *gorm.DB) error {
+		res := db.Model(&m).
+			Clauses(clause.Returning{}).
+			Where("uuid = ? AND status = ?", tfuuid, transaction.StatusInitiated).
+			Updates(map[string]any{
+				"status":        transaction.StatusPending,
+				"status_reason": reason,
+				"version":       gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return transaction.ErrConflict
+		}
+		err := insertStatusChange(db, m.UUID, transaction.StatusInitiated, m.Status, reason)
+		if err != nil {
+			return err
+		}
+		return insertOutboxEvent(db, outbox.AggregateTransaction, m.UUID,
+			outbox.EventTransactionUpdated, transactionEventFromModel(m))
+	})
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	return transaction.Transaction{
+		UUID:                 m.UUID,
+		SourceAccount:        m.SourceAccount,
+		DestinationBankCode:  m.DestinationBankCode,
+		DestinationAccount:   m.DestinationAccount,
+		TransactionType:      m.TransactionType,
+		Rail:                 m.Rail,
+		BatchUUID:            m.BatchUUID,
+		Origin:               m.Origin,
+		PaymentID:            m.PaymentID,
+		ReversalOf:           m.ReversalOf,
+		TransactionReference: m.TransactionReference,
+		Status:               m.Status,
+		StatusReason:         m.StatusReason,
+		Note:                 m.Note,
+		Amount:               m.Amount,
+		Fee:                  m.Fee,
+		Username:             m.UserUsername,
+		ProcessedAt:          m.CreatedAt,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+		Version:              m.Version,
+	}, nil
+}
+
+func (r *TransactionRepo) ExpireInitiated(ctx context.Context, createdBefore time.Time, reason string) ([]transaction.Transaction, error) {
+	var expired []transaction.Transaction
+	err := conn(ctx, r.db).Transaction(func(db *gorm.DB) error {
+		var models []model.Transaction
+		err := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND created_at < ?", transaction.StatusInitiated, createdBefore).
+			Find(&models).Error
+		if err != nil || len(models) == 0 {
+			return err
+		}
+		ids := make([]uint, 0, len(models))
+		for _, m := range models {
+			ids = append(ids, m.ID)
+		}
+		res := db.Model(&model.Transaction{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"status":        transaction.StatusExpired,
+				"status_reason": reason,
+				"version":       gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		for _, m := range models {
+			err = insertStatusChange(db, m.UUID, m.Status, transaction.StatusExpired, reason)
+			if err != nil {
+				return err
+			}
+			m.Status = transaction.StatusExpired
+			m.StatusReason = reason
+			err = insertOutboxEvent(db, outbox.AggregateTransaction, m.UUID,
+				outbox.EventTransactionUpdated, transactionEventFromModel(m))
+			if err != nil {
+				return err
+			}
+			expired = append(expired, transaction.Transaction{
+				UUID:                 m.UUID,
+				SourceAccount:        m.SourceAccount,
+				DestinationBankCode:  m.DestinationBankCode,
+				DestinationAccount:   m.DestinationAccount,
+				TransactionType:      m.TransactionType,
+				Rail:                 m.Rail,
+				BatchUUID:            m.BatchUUID,
+				Origin:               m.Origin,
+				PaymentID:            m.PaymentID,
+				ReversalOf:           m.ReversalOf,
+				TransactionReference: m.TransactionReference,
+				Status:               m.Status,
+				StatusReason:         m.StatusReason,
+				Note:                 m.Note,
+				Amount:               m.Amount,
+				Fee:                  m.Fee,
+				Username:             m.UserUsername,
+				ProcessedAt:          m.CreatedAt,
+				CreatedAt:            m.CreatedAt,
+				UpdatedAt:            m.UpdatedAt,
+				Version:              m.Version + 1,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+func (r *TransactionRepo) GetCompletedDebits(ctx context.Context, accountNumber string, updatedAfter time.Time) ([]transaction.Transaction, error) {
+	var models []model.Transaction
+	err := conn(ctx, r.db).
+		Where("source_account = ? AND status = ? AND updated_at > ?",
+			accountNumber, transaction.StatusCompleted, updatedAfter).
+		Order("updated_at").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]transaction.Transaction, 0, len(models))
+	for _, m := range models {
+		transactions = append(transactions, transaction.Transaction{
+			UUID:                 m.UUID,
+			SourceAccount:        m.SourceAccount,
+			DestinationBankCode:  m.DestinationBankCode,
+			DestinationAccount:   m.DestinationAccount,
+			TransactionType:      m.TransactionType,
+			Rail:                 m.Rail,
+			BatchUUID:            m.BatchUUID,
+			Origin:               m.Origin,
+			PaymentID:            m.PaymentID,
+			ReversalOf:           m.ReversalOf,
+			TransactionReference: m.TransactionReference,
+			Status:               m.Status,
+			StatusReason:         m.StatusReason,
+			Note:                 m.Note,
+			Amount:               m.Amount,
+			Fee:                  m.Fee,
+			Username:             m.UserUsername,
+			ProcessedAt:          m.CreatedAt,
+			CreatedAt:            m.CreatedAt,
+			UpdatedAt:            m.UpdatedAt,
+			Version:              m.Version,
 		})
-	return res.Error
+	}
+	return transactions, nil
+}
+
+func (r *TransactionRepo) GetLastDebitAt(ctx context.Context, accountNumber string) (time.Time, error) {
+	var m model.Transaction
+	err := conn(ctx, r.db).
+		Select("created_at").
+		Where("source_account = ? AND status = ?", accountNumber, transaction.StatusCompleted).
+		Where("reversal_of IS NULL OR reversal_of = ''").
+		Where("origin IS NULL OR origin = ''").
+		Order("created_at DESC").
+		Limit(1).
+		Find(&m).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+	return m.CreatedAt, nil
+}
+
+func (r *TransactionRepo) GetHistory(ctx context.Context, tfuuid string) ([]transaction.StatusChange, error) {
+	var models []model.TransactionStatusHistory
+	err := conn(ctx, r.db).
+		Where("transaction_uuid = ?", tfuuid).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	history := make([]transaction.StatusChange, 0, len(models))
+	for _, m := range models {
+		history = append(history, transaction.StatusChange{
+			TransactionUUID: m.TransactionUUID,
+			From:            m.FromStatus,
+			To:              m.ToStatus,
+			Reason:          m.Reason,
+			ChangedAt:       m.CreatedAt,
+		})
+	}
+	return history, nil
+}
+
+// insertStatusChange records a status change of the transaction using db,
+// which must be the database transaction of the change.
+func insertStatusChange(db *gorm.DB, tfuuid, from, to, reason string) error {
+	return db.Create(&model.TransactionStatusHistory{
+		TransactionUUID: tfuuid,
+		FromStatus:      from,
+		ToStatus:        to,
+		Reason:          reason,
+	}).Error
+}
+
+// transactionEvent returns the outbox payload of the transaction.
+func transactionEvent(tx transaction.Transaction) model.TransactionEvent {
+	return model.TransactionEvent{
+		UUID:                 tx.UUID,
+		TransactionType:      tx.TransactionType,
+		Status:               tx.Status,
+		StatusReason:         tx.StatusReason,
+		SourceAccount:        tx.SourceAccount,
+		DestinationBankCode:  tx.DestinationBankCode,
+		DestinationAccount:   tx.DestinationAccount,
+		Rail:                 tx.Rail,
+		Amount:               tx.Amount,
+		Fee:                  tx.Fee,
+		TransactionReference: tx.TransactionReference,
+		Username:             tx.Username,
+		OccurredAt:           time.Now(),
+	}
+}
+
+// transactionEventFromModel returns the outbox payload of the transaction model.
+func transactionEventFromModel(m model.Transaction) model.TransactionEvent {
+	return model.TransactionEvent{
+		UUID:                 m.UUID,
+		TransactionType:      m.TransactionType,
+		Status:               m.Status,
+		StatusReason:         m.StatusReason,
+		SourceAccount:        m.SourceAccount,
+		DestinationBankCode:  m.DestinationBankCode,
+		DestinationAccount:   m.DestinationAccount,
+		Rail:                 m.Rail,
+		Amount:               m.Amount,
+		Fee:                  m.Fee,
+		TransactionReference: m.TransactionReference,
+		Username:             m.UserUsername,
+		OccurredAt:           time.Now(),
+	}
 }
@@ -51,7 +51,7 @@ func (r *UserRepo) Create(ctx context.Context, u user.User) error {
 		DateOfBirth:  u.DateOfBirth,
 		Status:       u.Status,
 	}
-	err := r.db.WithContext(ctx).Create(&m).Error
+	err := conn(ctx, r.db).Create(&m).Error
 	var pgconnErr *pgconn.PgError
 	if err != nil && errors.As(err, &pgconnErr) {
 		if pgconnErr.Code != duplicateKeyErrCode {
@@ -95,10 +95,11 @@ func (r *UserRepo) GetByUsername(ctx context.Context, username string) (user.Use
 			LastName:    m.LastName,
 			CIF:         m.CIF,
 			Address:     m.Address,
+			Role:        m.Role,
 			LastLogin:   m.LastLogin,
 		}, nil
 	}
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).
 		Where("username = ?", username).
 		Find(&m).Error
 	if err != nil {
@@ -118,13 +119,40 @@ func (r *UserRepo) GetByUsername(ctx context.Context, username string) (user.Use
 		LastName:    m.LastName,
 		CIF:         m.CIF,
 		Address:     m.Address,
+		Role:        m.Role,
 		LastLogin:   m.LastLogin,
 	}, nil
 }
 
+func (r *UserRepo) GetCustomers(ctx context.Context) ([]user.User, error) {
+	var models []model.User
+	err := conn(ctx, r.db).
+		Where("role = ? AND cif <> ''", user.RoleCustomer).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	users := make([]user.User, 0, len(models))
+	for _, m := range models {
+		users = append(users, user.User{
+			Email:       m.Email,
+			Username:    m.Username,
+			PhoneNumber: m.PhoneNumber,
+			FirstName:   m.FirstName,
+			LastName:    m.LastName,
+			CIF:         m.CIF,
+			Address:     m.Address,
+			Role:        m.Role,
+			LastLogin:   m.LastLogin,
+		})
+	}
+	return users, nil
+}
+
 func (r *UserRepo) GetFieldsByUsername(ctx context.Context, username string, fields ...string) (user.User, error) {
 	var m model.User
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Select(fields).
 		Where("username = ?", username).
 		First(&m).Error
@@ -158,7 +186,7 @@ func (r *UserRepo) SaveToken(ctx context.Context, username string, token user.To
 	if err != nil {
 		return err
 	}
-	err = r.db.WithContext(ctx).Model(model.User{}).
+	err = conn(ctx, r.db).Model(model.User{}).
 		Where("username = ?", username).
 		UpdateColumn("last_login", time.Now()).Error
 	if err != nil {
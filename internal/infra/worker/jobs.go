@@ -0,0 +1,14 @@
+package worker
+
+func (w *Worker) registerJobs() {
+	w.register("standing-orders", w.cfg.StandingOrder.Interval, w.sou.ExecuteDue)
+	w.register("transaction-expiry", w.cfg.Transaction.ExpiryInterval, w.txu.ExpireStale)
+	w.register("transfer-reconciliation", w.cfg.Transaction.ReconcileInterval, w.tfu.Reconcile)
+	w.register("reversal-reconciliation", w.cfg.Transaction.ReconcileInterval, w.rvu.Reconcile)
+	w.register("stand-in-replay", w.cfg.StandIn.ReplayInterval, w.tfu.Replay)
+	w.register("bulk-transfer-resume", w.cfg.BulkTransfer.ResumeInterval, w.btu.Resume)
+	w.register("outbox-relay", w.cfg.Outbox.RelayInterval, w.obu.Relay)
+	w.register("savings-goal-sweeps", w.cfg.SavingsGoal.SweepInterval, w.sgu.Sweep)
+	w.register("deposit-maturity", w.cfg.Deposit.MaturityInterval, w.dpu.Mature)
+	w.register("account-dormancy", w.cfg.Account.DormancyInterval, w.acu.FlagDormant)
+}
@@ -0,0 +1,133 @@
+// Package worker runs the background jobs of the application.
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/bulktransfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/reversal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/savingsgoal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/standingorder"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+// job is a named unit of background work that runs on a fixed interval.
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// Worker runs the registered jobs until it is shut down.
+type Worker struct {
+	cfg    *config.Configs
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	jobs   []job
+	sou    *standingorder.Usecase
+	txu    *transaction.Usecase
+	tfu    *transfer.Usecase
+	obu    *outbox.Usecase
+	sgu    *savingsgoal.Usecase
+	dpu    *deposit.Usecase
+	acu    *account.Usecase
+	btu    *bulktransfer.Usecase
+	rvu    *reversal.Usecase
+}
+
+// NewWorker returns new Worker.
+func NewWorker(
+	cfg *config.Configs,
+	sou *standingorder.Usecase,
+	txu *transaction.Usecase,
+	tfu *transfer.Usecase,
+	obu *outbox.Usecase,
+	sgu *savingsgoal.Usecase,
+	dpu *deposit.Usecase,
+	acu *account.Usecase,
+	btu *bulktransfer.Usecase,
+	rvu *reversal.Usecase,
+) *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Worker{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		sou:    sou,
+		txu:    txu,
+		tfu:    tfu,
+		obu:    obu,
+		sgu:    sgu,
+		dpu:    dpu,
+		acu:    acu,
+		btu:    btu,
+		rvu:    rvu,
+	}
+}
+
+// Run starts the registered jobs and blocks until the worker is shut down.
+func (w *Worker) Run() {
+	w.registerJobs()
+	for _, j := range w.jobs {
+		if j.interval <= 0 {
+			log.Info().Msgf("job %s is disabled", j.name)
+			continue
+		}
+		w.wg.Add(1)
+		go w.loop(j)
+	}
+	w.wg.Wait()
+}
+
+// Shutdown stops the jobs and waits for the running ones to finish.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.cancel()
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// register adds a job to the worker.
+func (w *Worker) register(name string, interval time.Duration, run func(ctx context.Context) error) {
+	w.jobs = append(w.jobs, job{
+		name:     name,
+		interval: interval,
+		run:      run,
+	})
+}
+
+// loop runs the job on every tick until the worker is shut down.
+func (w *Worker) loop(j job) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.run(w.ctx); err != nil {
+				log.Error().Err(err).Msgf("job %s failed", j.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package breaker provides a circuit breaker with retries for the calls to outbound services.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit breaker.
+type State string
+
+const (
+	// StateClosed lets every call through.
+	StateClosed State = "closed"
+	// StateOpen fails every call until the open timeout is over.
+	StateOpen State = "open"
+	// StateHalfOpen lets a single probe call through, closing the breaker when it succeeds.
+	StateHalfOpen State = "half_open"
+)
+
+// ErrOpen is returned without calling the service while the breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Config is the thresholds of a breaker.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker, zero turns the breaker off.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before a probe call is let through.
+	OpenTimeout time.Duration
+	// RetryAttempts is the number of times a retryable call is tried again after a failure.
+	RetryAttempts int
+	// RetryBackoff is the wait before the first retry, doubled on every following retry.
+	RetryBackoff time.Duration
+}
+
+// Breaker stops calling a service after consecutive failures and probes it again after a timeout.
+type Breaker struct {
+	name      string
+	cfg       Config
+	isFailure func(error) bool
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New returns a closed breaker. isFailure tells which errors count as failures of the service,
+// nil counts every error except the cancellation of the caller.
+func New(name string, cfg Config, isFailure func(error) bool) *Breaker {
+	if isFailure == nil {
+		isFailure = func(error) bool { return true }
+	}
+	return &Breaker{
+		name:      name,
+		cfg:       cfg,
+		isFailure: isFailure,
+	}
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state(time.Now())
+}
+
+func (b *Breaker) state(now time.Time) State {
+	switch {
+	case b.cfg.FailureThreshold <= 0 || b.failures < b.cfg.FailureThreshold:
+		return StateClosed
+	case now.Sub(b.openedAt) < b.cfg.OpenTimeout:
+		return StateOpen
+	}
+	return StateHalfOpen
+}
+
+// allow reports whether a call can go through, claiming the probe when the breaker is half open.
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state(time.Now()) {
+	case StateOpen:
+		return false
+	case StateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+	return true
+}
+
+// record counts the outcome of a call, it reports whether err is a failure of the service.
+func (b *Breaker) record(err error) bool {
+	failed := err != nil && !errors.Is(err, context.Canceled) && b.isFailure(err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	if b.cfg.FailureThreshold > 0 && b.failures >= b.cfg.FailureThreshold {
+		b.openedAt = time.Now()
+	}
+	return true
+}
+
+// Call calls fn through the breaker. When retry is set, which is only safe for reads and idempotent writes,
+// a failed call is tried again with an exponential backoff while the breaker and ctx allow it.
+func Call[T any](ctx context.Context, b *Breaker, retry bool, fn func(ctx context.Context) (T, error)) (T, error) {
+	attempts := 1
+	if retry {
+		attempts += b.cfg.RetryAttempts
+	}
+
+	var (
+		res T
+		err error
+	)
+	backoff := b.cfg.RetryBackoff
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return res, err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		if !b.allow() {
+			if attempt > 0 {
+				return res, err
+			}
+			return res, ErrOpen
+		}
+		res, err = fn(ctx)
+		if !b.record(err) {
+			return res, err
+		}
+	}
+	return res, err
+}
+
+// Do calls fn through the breaker like Call for the calls that return no value.
+func Do(ctx context.Context, b *Breaker, retry bool, fn func(ctx context.Context) error) error {
+	_, err := Call(ctx, b, retry, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
@@ -0,0 +1,137 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	errUnavailable = errors.New("service unavailable")
+	errNotFound    = errors.New("not found")
+)
+
+func isFailure(err error) bool {
+	return !errors.Is(err, errNotFound)
+}
+
+func fail(calls *int, err error) func(context.Context) error {
+	return func(context.Context) error {
+		*calls++
+		return err
+	}
+}
+
+func TestCall_OpensAfterThreshold(t *testing.T) {
+	b := New("cbs", Config{FailureThreshold: 2, OpenTimeout: time.Minute}, isFailure)
+	ctx := context.Background()
+
+	var calls int
+	_ = Do(ctx, b, false, fail(&calls, errUnavailable))
+	if b.State() != StateClosed {
+		t.Fatalf("State() = %s after one failure, want closed", b.State())
+	}
+	_ = Do(ctx, b, false, fail(&calls, errUnavailable))
+	if b.State() != StateOpen {
+		t.Fatalf("State() = %s after two failures, want open", b.State())
+	}
+
+	err := Do(ctx, b, false, fail(&calls, nil))
+	if !errors.Is(err, ErrOpen) {
+		t.Errorf("Do() error = %v, want ErrOpen", err)
+	}
+	if calls != 2 {
+		t.Errorf("service called %d times, want 2", calls)
+	}
+}
+
+func TestCall_BusinessErrorIsNotFailure(t *testing.T) {
+	b := New("cbs", Config{FailureThreshold: 1, OpenTimeout: time.Minute}, isFailure)
+
+	var calls int
+	err := Do(context.Background(), b, true, fail(&calls, errNotFound))
+
+	if !errors.Is(err, errNotFound) {
+		t.Errorf("Do() error = %v, want errNotFound", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("State() = %s, want closed", b.State())
+	}
+	if calls != 1 {
+		t.Errorf("service called %d times, want 1", calls)
+	}
+}
+
+func TestCall_HalfOpenProbe(t *testing.T) {
+	b := New("cbs", Config{FailureThreshold: 1, OpenTimeout: time.Millisecond}, isFailure)
+	ctx := context.Background()
+
+	var calls int
+	_ = Do(ctx, b, false, fail(&calls, errUnavailable))
+	time.Sleep(2 * time.Millisecond)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("State() = %s after the open timeout, want half_open", b.State())
+	}
+
+	if err := Do(ctx, b, false, fail(&calls, nil)); err != nil {
+		t.Fatalf("Do() error = %v, want nil", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("State() = %s after a successful probe, want closed", b.State())
+	}
+}
+
+func TestCall_Retry(t *testing.T) {
+	b := New("cbs", Config{FailureThreshold: 5, OpenTimeout: time.Minute, RetryAttempts: 2, RetryBackoff: time.Millisecond}, isFailure)
+
+	var calls int
+	res, err := Call(context.Background(), b, true, func(context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errUnavailable
+		}
+		return "ok", nil
+	})
+
+	if err != nil || res != "ok" {
+		t.Errorf("Call() = %q, %v, want ok", res, err)
+	}
+	if calls != 3 {
+		t.Errorf("service called %d times, want 3", calls)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("State() = %s, want closed", b.State())
+	}
+}
+
+func TestCall_NoRetryForWrites(t *testing.T) {
+	b := New("cbs", Config{FailureThreshold: 5, OpenTimeout: time.Minute, RetryAttempts: 2}, isFailure)
+
+	var calls int
+	err := Do(context.Background(), b, false, fail(&calls, errUnavailable))
+
+	if !errors.Is(err, errUnavailable) {
+		t.Errorf("Do() error = %v, want errUnavailable", err)
+	}
+	if calls != 1 {
+		t.Errorf("service called %d times, want 1", calls)
+	}
+}
+
+func TestCall_Disabled(t *testing.T) {
+	b := New("cbs", Config{}, nil)
+	ctx := context.Background()
+
+	var calls int
+	for range 3 {
+		_ = Do(ctx, b, false, fail(&calls, errUnavailable))
+	}
+
+	if b.State() != StateClosed {
+		t.Errorf("State() = %s, want closed", b.State())
+	}
+	if calls != 3 {
+		t.Errorf("service called %d times, want 3", calls)
+	}
+}
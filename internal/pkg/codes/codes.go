@@ -24,4 +24,7 @@ const (
 
 	// Internal represents a code indicating an internal server error.
 	Internal
+
+	// Unavailable represents a code indicating the service is temporarily unavailable.
+	Unavailable
 )
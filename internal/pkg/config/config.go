@@ -21,6 +21,32 @@ type Configs struct {
 	DBD internal.DBD
 	// Redis defines the redis database configuration.
 	Redis internal.Redis
+	// Transaction defines the transaction lifecycle configuration.
+	Transaction internal.Transaction
+	// Outbox defines the transactional outbox relay configuration.
+	Outbox internal.Outbox
+	// Interbank defines the interbank transfer rails configuration.
+	Interbank internal.Interbank
+	// StandingOrder defines the standing order scheduler configuration.
+	StandingOrder internal.StandingOrder
+	// BulkTransfer defines the bulk transfer configuration.
+	BulkTransfer internal.BulkTransfer
+	// Beneficiary defines the beneficiary address book configuration.
+	Beneficiary internal.Beneficiary
+	// SavingsGoal defines the savings goal sweep configuration.
+	SavingsGoal internal.SavingsGoal
+	// Deposit defines the time deposit products configuration.
+	Deposit internal.Deposit
+	// Account defines the account lifecycle configuration.
+	Account internal.Account
+	// StandIn defines the store-and-forward configuration of the core banking system end of day.
+	StandIn internal.StandIn
+	// Resilience defines the circuit breaker and retry configuration of the outbound services.
+	Resilience internal.Resilience
+	// Callback defines the signature verification configuration of the partner callbacks.
+	Callback internal.Callback
+	// TapMoney defines the TapMoney service configuration.
+	TapMoney internal.TapMoney
 }
 
 // Config holds the application configuration.
@@ -46,6 +72,10 @@ func Load() *Configs {
 	if err != nil {
 		panic(err)
 	}
+	err = cfg.Configs.Interbank.Validate()
+	if err != nil {
+		panic(err)
+	}
 
 	return &cfg.Configs
 }
@@ -0,0 +1,12 @@
+package internal
+
+import "time"
+
+// Account config.
+type Account struct {
+	// DormancyInterval is how often the accounts are checked for dormancy.
+	DormancyInterval time.Duration
+	// DormancyMonths is the number of months without customer activity after which an account is dormant,
+	// zero turns the dormancy check off.
+	DormancyMonths int
+}
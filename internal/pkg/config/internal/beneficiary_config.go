@@ -0,0 +1,11 @@
+package internal
+
+import "time"
+
+// Beneficiary config.
+type Beneficiary struct {
+	// RequireOTP defines whether saving a new beneficiary needs an OTP step-up.
+	RequireOTP bool
+	// OTPDuration is how long a step-up OTP stays valid.
+	OTPDuration time.Duration
+}
@@ -0,0 +1,15 @@
+package internal
+
+import "time"
+
+// BulkTransfer config.
+type BulkTransfer struct {
+	// MaxRows is the maximum number of rows in a batch.
+	MaxRows int
+	// MaxTotalAmount is the maximum sum of the row amounts in a batch.
+	MaxTotalAmount int64
+	// Concurrency is the number of rows of a batch transferred at the same time.
+	Concurrency int
+	// ResumeInterval is how often the batches left processing, e.g. by a restart, are resumed.
+	ResumeInterval time.Duration
+}
@@ -0,0 +1,11 @@
+package internal
+
+import "time"
+
+// Callback config.
+type Callback struct {
+	// Keys are the HMAC-SHA256 secrets the partners sign their callbacks with, by key ID.
+	Keys map[string]string
+	// ClockSkew is how far the timestamp of a signed callback may be from the server time, zero uses five minutes.
+	ClockSkew time.Duration
+}
@@ -1,7 +1,37 @@
 package internal
 
+import "time"
+
 type CBS struct {
 	Addr     string
 	Username string
 	Password string
+	// Simulator replaces the core banking system with the in-memory simulator.
+	Simulator bool
+	// SimulatorOpeningBalance is credited to every account opened by the simulator.
+	SimulatorOpeningBalance int64
+	// SimulatorPort is the port cmd/cbs-sim serves the simulator on.
+	SimulatorPort string
+	// SimulatorFaults are injected into the operations of the simulator by operation name, e.g. transfer.
+	SimulatorFaults map[string]SimulatorFault
+	// EODStart is the time of day the end of day usually starts, as the offset from midnight.
+	EODStart time.Duration
+	// EODDuration is how long the end of day usually takes.
+	EODDuration time.Duration
+	// StatusTTL is how long the status of the core banking system is cached, zero asks for it on every check.
+	StatusTTL time.Duration
+}
+
+// SimulatorFault is a failure injected into an operation of the simulator.
+type SimulatorFault struct {
+	// Latency delays the operation.
+	Latency time.Duration
+	// Code is the response code returned instead of the result, e.g. 91 for system unavailable.
+	Code string
+	// Timeout returns a timeout instead of the result.
+	Timeout bool
+	// Applied executes the operation before the error is returned.
+	Applied bool
+	// Times limits the fault to the next calls, zero keeps it until the faults are cleared.
+	Times int
 }
@@ -0,0 +1,22 @@
+package internal
+
+import "time"
+
+// Deposit config.
+type Deposit struct {
+	// MaturityInterval is how often the deposits maturing on the core banking system date are settled.
+	MaturityInterval time.Duration
+	// MinAmount is the smallest principal of a deposit.
+	MinAmount int64
+	// TaxRateBps is the tax withheld from the interest in basis points.
+	TaxRateBps int64
+	// Products is the rate table of the deposit terms.
+	Products []DepositProduct
+}
+
+// DepositProduct is the annual rate of a deposit term.
+type DepositProduct struct {
+	TermMonths int
+	// RateBps is the annual rate in basis points, 350 is 3.50%.
+	RateBps int64
+}
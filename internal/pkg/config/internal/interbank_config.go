@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Interbank config.
+type Interbank struct {
+	// BankCode is the clearing code of this bank, it is required.
+	BankCode string
+	// BIFastLimit is the largest transfer sent through BI-FAST, zero uses 250,000,000.
+	BIFastLimit int64
+	// SKNLimit is the largest transfer sent through SKN, zero uses 1,000,000,000.
+	SKNLimit int64
+	// RTGSMinimum is the smallest transfer sent through RTGS, zero uses 100,000,000.
+	RTGSMinimum int64
+	// SKNBatchCutOffs are the cut-off times of the daily SKN clearing batches as offsets from midnight,
+	// empty uses 09:00, 12:00 and 15:00.
+	SKNBatchCutOffs []time.Duration
+	// RTGSCutOff is the daily RTGS cut-off time as the offset from midnight, zero uses 16:00.
+	RTGSCutOff time.Duration
+	// BIFastFee, SKNFee and RTGSFee are charged to the sender of a transfer through the rail.
+	BIFastFee int64
+	SKNFee    int64
+	RTGSFee   int64
+}
+
+// Validate checks the bank code, which tells the transfers within this bank from the interbank ones.
+func (c Interbank) Validate() error {
+	if len(c.BankCode) != 3 || strings.Trim(c.BankCode, "0123456789") != "" {
+		return fmt.Errorf("interbank bank code %q must be three digits", c.BankCode)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package internal
+
+import "time"
+
+// Outbox config.
+type Outbox struct {
+	// Broker is the broker the events are published to, redis or memory.
+	Broker string
+	// Stream is the Redis stream of the events.
+	Stream string
+	// StreamMaxLen caps the Redis stream length, zero keeps every event.
+	StreamMaxLen int64
+	// RelayInterval is how often the unpublished events are relayed.
+	RelayInterval time.Duration
+	// BatchSize is the number of events relayed at a time.
+	BatchSize int
+}
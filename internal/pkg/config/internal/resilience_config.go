@@ -0,0 +1,16 @@
+package internal
+
+import "time"
+
+// Resilience config.
+type Resilience struct {
+	// FailureThreshold is the number of consecutive failures of an outbound service that opens its circuit breaker,
+	// zero turns the circuit breakers off.
+	FailureThreshold int
+	// OpenTimeout is how long a circuit breaker stays open before the service is probed again.
+	OpenTimeout time.Duration
+	// RetryAttempts is the number of times a failed read is tried again.
+	RetryAttempts int
+	// RetryBackoff is the wait before the first retry, doubled on every following retry.
+	RetryBackoff time.Duration
+}
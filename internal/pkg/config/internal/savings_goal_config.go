@@ -0,0 +1,11 @@
+package internal
+
+import "time"
+
+// SavingsGoal config.
+type SavingsGoal struct {
+	// SweepInterval is how often the savings goals are swept into their pockets.
+	SweepInterval time.Duration
+	// RoundUpUnit is the multiple the debited amounts are rounded up to by round-up sweeps.
+	RoundUpUnit int64
+}
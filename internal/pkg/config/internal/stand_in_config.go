@@ -0,0 +1,17 @@
+package internal
+
+import "time"
+
+// StandIn config.
+type StandIn struct {
+	// Limit is the largest transfer accepted while the core banking system is at the end of day,
+	// zero turns the stand-in off.
+	Limit int64
+	// AccountLimit is the largest amount queued for one source account, zero is unlimited.
+	AccountLimit int64
+	// ReplayInterval is how often the queued transfers are forwarded once the end of day is over.
+	ReplayInterval time.Duration
+	// MaxAttempts is the number of failed forwards after which a queued transfer is parked
+	// and failed with its hold released, zero uses 5.
+	MaxAttempts int
+}
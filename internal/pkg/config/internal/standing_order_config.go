@@ -0,0 +1,11 @@
+package internal
+
+import "time"
+
+// StandingOrder config.
+type StandingOrder struct {
+	// Interval is how often the scheduler looks for due standing orders.
+	Interval time.Duration
+	// MaxAttempts is how many times a failed run is retried before the user is notified, zero uses 3.
+	MaxAttempts int
+}
@@ -0,0 +1,20 @@
+package internal
+
+import "time"
+
+// TapMoney config.
+type TapMoney struct {
+	// Addr is the base URL of the TapMoney service, the payment gateway answers locally when it is empty.
+	Addr string
+	// SigningKeyID names the key the requests to the TapMoney service are signed with.
+	SigningKeyID string
+	// SigningSecret is the HMAC-SHA256 key of the requests to the TapMoney service, empty sends them unsigned.
+	SigningSecret string
+	// RetryAttempts is the number of attempts of a request including the first one,
+	// zero uses the default of the client.
+	RetryAttempts int
+	// RetryBaseDelay is the wait before the first retry, doubled on every following retry.
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the wait between two attempts.
+	RetryMaxDelay time.Duration
+}
@@ -0,0 +1,20 @@
+package internal
+
+import "time"
+
+// Transaction config.
+type Transaction struct {
+	// InitiatedTTL is how long an initiated transaction can wait to be processed.
+	// Zero disables the expiry.
+	InitiatedTTL time.Duration
+	// ExpiryInterval is how often the stale initiated transactions are expired.
+	ExpiryInterval time.Duration
+	// ReconcileInterval is how often the pending transactions and reversals are reconciled.
+	ReconcileInterval time.Duration
+	// ReconcileAfter is how long a transaction stays pending before it is reconciled,
+	// giving in-flight transfers time to land in the core banking system.
+	ReconcileAfter time.Duration
+	// TransferLimit is the largest transfer between accounts of this bank, zero uses 50,000,000.
+	// Interbank transfers are bounded by the limits of their rail.
+	TransferLimit int64
+}
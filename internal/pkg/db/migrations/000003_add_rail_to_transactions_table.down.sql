@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS destination_bank_code,
+    DROP COLUMN IF EXISTS rail;
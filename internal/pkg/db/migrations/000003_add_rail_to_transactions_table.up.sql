@@ -0,0 +1,7 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS destination_bank_code VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS rail                  VARCHAR(20);
+
+UPDATE transactions
+SET rail = 'internal'
+WHERE transaction_type = 'transfer';
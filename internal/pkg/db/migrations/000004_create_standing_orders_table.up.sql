@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS standing_orders
+(
+    id                    SERIAL PRIMARY KEY,
+    uuid                  UUID           NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_username         VARCHAR(255)   NOT NULL,
+    source_account        VARCHAR(255)   NOT NULL,
+    destination_bank_code VARCHAR(3),
+    destination_account   VARCHAR(255)   NOT NULL,
+    amount                NUMERIC(14, 0) NOT NULL,
+    note                  VARCHAR(255),
+    frequency             VARCHAR(20)    NOT NULL,
+    start_date            DATE           NOT NULL,
+    end_date              DATE,
+    next_run_date         DATE           NOT NULL,
+    status                VARCHAR(20)    NOT NULL,
+    attempts              INTEGER        NOT NULL        DEFAULT 0,
+    created_at            TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    deleted_at            TIMESTAMP WITH TIME ZONE       DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_standing_orders_due ON standing_orders (status, next_run_date);
+
+CREATE TABLE IF NOT EXISTS standing_order_executions
+(
+    id                  SERIAL PRIMARY KEY,
+    standing_order_uuid UUID         NOT NULL REFERENCES standing_orders (uuid),
+    transaction_uuid    VARCHAR(36),
+    status              VARCHAR(20)  NOT NULL,
+    reason              VARCHAR(255),
+    attempt             INTEGER      NOT NULL,
+    executed_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at          TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_batch_uuid;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS batch_uuid,
+    DROP COLUMN IF EXISTS status_reason;
+
+DROP TABLE IF EXISTS bulk_transfers;
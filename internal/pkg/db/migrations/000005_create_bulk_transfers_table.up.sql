@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS bulk_transfers
+(
+    id             SERIAL PRIMARY KEY,
+    uuid           UUID           NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_username  VARCHAR(255)   NOT NULL,
+    source_account VARCHAR(255)   NOT NULL,
+    total_rows     INTEGER        NOT NULL,
+    total_amount   NUMERIC(16, 0) NOT NULL,
+    status         VARCHAR(20)    NOT NULL,
+    completed_at   TIMESTAMP WITH TIME ZONE       DEFAULT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    deleted_at     TIMESTAMP WITH TIME ZONE       DEFAULT NULL
+);
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS batch_uuid    VARCHAR(36),
+    ADD COLUMN IF NOT EXISTS status_reason VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_batch_uuid ON transactions (batch_uuid);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS beneficiaries
+(
+    id             SERIAL PRIMARY KEY,
+    uuid           UUID         NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_username  VARCHAR(255) NOT NULL,
+    bank_code      VARCHAR(3)   NOT NULL,
+    account_number VARCHAR(255) NOT NULL,
+    nickname       VARCHAR(100) NOT NULL,
+    verified_name  VARCHAR(255) NOT NULL,
+    favourite      BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at     TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_beneficiaries_user_account
+    ON beneficiaries (user_username, bank_code, account_number)
+    WHERE deleted_at IS NULL;
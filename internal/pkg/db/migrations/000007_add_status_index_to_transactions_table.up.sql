@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_status_created_at ON transactions (status, created_at);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox_events
+(
+    id             BIGSERIAL PRIMARY KEY,
+    aggregate_type VARCHAR(50)  NOT NULL,
+    aggregate_uuid VARCHAR(36)  NOT NULL,
+    event_type     VARCHAR(100) NOT NULL,
+    payload        JSONB        NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    published_at   TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events (id) WHERE published_at IS NULL;
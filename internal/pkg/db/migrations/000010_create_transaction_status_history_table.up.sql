@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS transaction_status_history
+(
+    id               BIGSERIAL PRIMARY KEY,
+    transaction_uuid UUID        NOT NULL,
+    from_status      VARCHAR(20) NOT NULL DEFAULT '',
+    to_status        VARCHAR(20) NOT NULL,
+    reason           TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_status_history_transaction_uuid ON transaction_status_history (transaction_uuid, id);
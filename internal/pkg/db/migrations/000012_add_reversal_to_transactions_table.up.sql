@@ -0,0 +1,6 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS payment_id VARCHAR(255);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reversal_of VARCHAR(36);
+
+-- A transaction has at most one reversal that is not failed.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_reversal_of ON transactions (reversal_of)
+    WHERE reversal_of IS NOT NULL AND reversal_of <> '' AND status <> 'failed';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS audit_logs
+(
+    id            BIGSERIAL PRIMARY KEY,
+    actor         VARCHAR(255) NOT NULL,
+    action        VARCHAR(100) NOT NULL,
+    resource_type VARCHAR(50)  NOT NULL,
+    resource_uuid VARCHAR(36)  NOT NULL,
+    detail        TEXT         NOT NULL DEFAULT '',
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource ON audit_logs (resource_type, resource_uuid);
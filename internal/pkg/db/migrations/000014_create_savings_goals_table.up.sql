@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS savings_goals
+(
+    id              SERIAL PRIMARY KEY,
+    uuid            UUID           NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_username   VARCHAR(255)   NOT NULL,
+    name            VARCHAR(100)   NOT NULL,
+    source_account  VARCHAR(255)   NOT NULL,
+    pocket_account  VARCHAR(255)   NOT NULL,
+    target_amount   NUMERIC(14, 0) NOT NULL,
+    deadline        DATE           NOT NULL,
+    sweep_rule      VARCHAR(20)    NOT NULL,
+    sweep_amount    NUMERIC(14, 0),
+    frequency       VARCHAR(20),
+    next_sweep_date DATE,
+    last_swept_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    status          VARCHAR(20)    NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    deleted_at      TIMESTAMP WITH TIME ZONE       DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goals_active ON savings_goals (status, sweep_rule, next_sweep_date);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_savings_goals_pocket
+    ON savings_goals (pocket_account)
+    WHERE status = 'active' AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS savings_goal_sweeps
+(
+    id                SERIAL PRIMARY KEY,
+    savings_goal_uuid UUID           NOT NULL REFERENCES savings_goals (uuid),
+    transaction_uuid  VARCHAR(36),
+    amount            NUMERIC(14, 0) NOT NULL,
+    status            VARCHAR(20)    NOT NULL,
+    reason            VARCHAR(255),
+    swept_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at        TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goal_sweeps_goal ON savings_goal_sweeps (savings_goal_uuid);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS deposits
+(
+    id                       SERIAL PRIMARY KEY,
+    uuid                     UUID           NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_username            VARCHAR(255)   NOT NULL,
+    source_account           VARCHAR(255)   NOT NULL,
+    deposit_account          VARCHAR(255)   NOT NULL,
+    principal                NUMERIC(14, 0) NOT NULL,
+    term_months              INTEGER        NOT NULL,
+    rate_bps                 INTEGER        NOT NULL,
+    start_date               DATE           NOT NULL,
+    maturity_date            DATE           NOT NULL,
+    instruction              VARCHAR(20)    NOT NULL,
+    status                   VARCHAR(20)    NOT NULL,
+    funding_transaction_uuid VARCHAR(36)    NOT NULL,
+    rollovers                INTEGER        NOT NULL        DEFAULT 0,
+    gross_interest           NUMERIC(14, 0) NOT NULL        DEFAULT 0,
+    tax                      NUMERIC(14, 0) NOT NULL        DEFAULT 0,
+    net_interest             NUMERIC(14, 0) NOT NULL        DEFAULT 0,
+    created_at               TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    updated_at               TIMESTAMP WITH TIME ZONE       DEFAULT CURRENT_TIMESTAMP,
+    deleted_at               TIMESTAMP WITH TIME ZONE       DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_deposits_maturity ON deposits (status, maturity_date);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_source_account_created_at ON transactions (source_account, created_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS stand_in_entries;
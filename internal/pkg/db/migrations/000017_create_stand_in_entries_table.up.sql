@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stand_in_entries
+(
+    id                  BIGSERIAL PRIMARY KEY,
+    transaction_uuid    VARCHAR(36)    NOT NULL UNIQUE,
+    source_account      VARCHAR(255)   NOT NULL,
+    destination_account VARCHAR(255)   NOT NULL,
+    amount              NUMERIC(14, 0) NOT NULL,
+    status              VARCHAR(20)    NOT NULL,
+    reason              TEXT           NOT NULL DEFAULT '',
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    forwarded_at        TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stand_in_entries_queued ON stand_in_entries (id) WHERE status = 'queued';
@@ -0,0 +1 @@
+ALTER TABLE stand_in_entries DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1 @@
+ALTER TABLE stand_in_entries ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE standing_orders ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP WITH TIME ZONE DEFAULT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE savings_goals DROP COLUMN IF EXISTS claimed_until;
+ALTER TABLE savings_goals DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,2 @@
+ALTER TABLE savings_goals ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE savings_goals ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP WITH TIME ZONE DEFAULT NULL;
@@ -30,6 +30,11 @@ func Unauthorized() *Error {
 	return New(codes.Unauthenticated)
 }
 
+// Forbidden returns a new Error with Forbidden code.
+func Forbidden() *Error {
+	return New(codes.Forbidden)
+}
+
 // NotFound returns a new Error with NotFound code.
 func NotFound() *Error {
 	return New(codes.NotFound)
@@ -45,6 +50,11 @@ func InternalServerError() *Error {
 	return New(codes.Internal)
 }
 
+// ServiceUnavailable returns a new Error with Unavailable code.
+func ServiceUnavailable() *Error {
+	return New(codes.Unavailable)
+}
+
 // SetMsg sets a custom error message for the client to display.
 // If no message is provided, the default message will be used.
 //
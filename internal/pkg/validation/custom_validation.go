@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
 )
 
 // validationRegistry maps validation tags to their corresponding validation functions.
@@ -22,8 +23,10 @@ type validationRegistry map[string]func(fl validator.FieldLevel) bool
 //
 // Add custom validation functions here.
 var customValidations = validationRegistry{
-	"phonenumber": ValidPhoneNumber,
-	"only":        Only,
+	"phonenumber":   ValidPhoneNumber,
+	"only":          Only,
+	"accountnumber": ValidAccountNumber,
+	"cardnumber":    ValidCardNumber,
 }
 
 func ValidPhoneNumber(fl validator.FieldLevel) bool {
@@ -32,6 +35,44 @@ func ValidPhoneNumber(fl validator.FieldLevel) bool {
 	return exp.MatchString(phone)
 }
 
+// ValidAccountNumber checks the account number against the account number scheme of the bank.
+// The param names the bank code field of the struct, when it is set the number may belong
+// to another bank and is only checked to be digits, e.g. accountnumber=DestinationBankCode.
+func ValidAccountNumber(fl validator.FieldLevel) bool {
+	number := fl.Field().String()
+	if fl.Param() != "" {
+		bankCode, _, _, ok := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+		if ok && bankCode.String() != "" {
+			return number != "" && strings.Trim(number, "0123456789") == ""
+		}
+	}
+	return account.ValidNumber(number)
+}
+
+// ValidCardNumber checks the card number is 16 to 19 digits with a valid Luhn check digit.
+func ValidCardNumber(fl validator.FieldLevel) bool {
+	number := fl.Field().String()
+	if len(number) < 16 || len(number) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range len(number) {
+		c := number[len(number)-1-i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
 func (v *Validator) registerCustomValidation() error {
 	for tag, fn := range customValidations {
 		if err := v.v.RegisterValidation(tag, fn); err != nil {
@@ -35,3 +35,94 @@ func TestValidPhoneNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestValidAccountNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   string
+		expected bool
+	}{
+		{name: "valid_account_number", number: "1000000015", expected: true},
+		{name: "valid_account_number_check_digit_zero", number: "1000000120", expected: true},
+		{name: "invalid_check_digit", number: "1000000016", expected: false},
+		{name: "invalid_swapped_digits", number: "1000000105", expected: false},
+		{name: "invalid_bank_prefix", number: "2000000015", expected: false},
+		{name: "invalid_length", number: "100000001", expected: false},
+		{name: "invalid_with_letters", number: "10000000A5", expected: false},
+		{name: "empty_account_number", number: "", expected: false},
+	}
+
+	v := validator.New()
+	_ = v.RegisterValidation("accountnumber", ValidAccountNumber)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Var(tt.number, "accountnumber")
+			got := err == nil
+
+			if got != tt.expected {
+				t.Errorf("ValidAccountNumber(%q) = %v, want %v", tt.number, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidAccountNumber_OtherBank(t *testing.T) {
+	type request struct {
+		BankCode      string
+		AccountNumber string `validate:"accountnumber=BankCode"`
+	}
+	tests := []struct {
+		name     string
+		req      request
+		expected bool
+	}{
+		{name: "own_bank_valid", req: request{AccountNumber: "1000000015"}, expected: true},
+		{name: "own_bank_invalid", req: request{AccountNumber: "1000000016"}, expected: false},
+		{name: "other_bank_digits", req: request{BankCode: "014", AccountNumber: "001201001479315"}, expected: true},
+		{name: "other_bank_letters", req: request{BankCode: "014", AccountNumber: "0012A"}, expected: false},
+	}
+
+	v := validator.New()
+	_ = v.RegisterValidation("accountnumber", ValidAccountNumber)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.req)
+			got := err == nil
+
+			if got != tt.expected {
+				t.Errorf("ValidAccountNumber(%+v) = %v, want %v", tt.req, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidCardNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   string
+		expected bool
+	}{
+		{name: "valid_16_digits", number: "4111111111111111", expected: true},
+		{name: "valid_19_digits", number: "6037990000000000008", expected: true},
+		{name: "invalid_check_digit", number: "4111111111111112", expected: false},
+		{name: "invalid_too_short", number: "411111111111116", expected: false},
+		{name: "invalid_with_spaces", number: "4111 1111 1111 1111", expected: false},
+		{name: "empty_card_number", number: "", expected: false},
+	}
+
+	v := validator.New()
+	_ = v.RegisterValidation("cardnumber", ValidCardNumber)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Var(tt.number, "cardnumber")
+			got := err == nil
+
+			if got != tt.expected {
+				t.Errorf("ValidCardNumber(%q) = %v, want %v", tt.number, got, tt.expected)
+			}
+		})
+	}
+}
@@ -16,3 +16,19 @@ func (fe FieldErrors) Error() string {
 	}
 	return errStr
 }
+
+// RowError represents the validation errors of one row in a batch request.
+type RowError struct {
+	Row    int         `json:"row"`
+	Errors FieldErrors `json:"errors"`
+}
+
+type RowErrors []RowError
+
+func (re RowErrors) Error() string {
+	var errStr string
+	for _, rowError := range re {
+		errStr += fmt.Sprintf("row %d: %s", rowError.Row, rowError.Errors.Error())
+	}
+	return errStr
+}
@@ -7,15 +7,22 @@ import (
 
 // tagMessages maps validation tags to corresponding error message templates.
 var tagMessages = map[string]string{
-	"required":    "%s is required",
-	"email":       "%s is not a valid email",
-	"len":         "%s length must be %s",
-	"min":         "%s minimum length must be %s",
-	"number":      "%s must be a number",
-	"gte":         "%s must be greater than or equal to %s",
-	"lte":         "%s must be less than or equal to %s",
-	"phonenumber": "%s is not a valid phone number",
-	"only":        "%s must contain only: %s",
+	"required":         "%s is required",
+	"email":            "%s is not a valid email",
+	"len":              "%s length must be %s",
+	"min":              "%s minimum length must be %s",
+	"number":           "%s must be a number",
+	"gte":              "%s must be greater than or equal to %s",
+	"lte":              "%s must be less than or equal to %s",
+	"phonenumber":      "%s is not a valid phone number",
+	"only":             "%s must contain only: %s",
+	"datetime":         "%s must match the format %s",
+	"max":              "%s maximum length must be %s",
+	"uuid":             "%s is not a valid UUID",
+	"required_without": "%s is required when %s is empty",
+	"oneof":            "%s must be one of: %s",
+	"accountnumber":    "%s is not a valid account number",
+	"cardnumber":       "%s is not a valid card number",
 }
 
 func (v *Validator) JSONTagFunc() {
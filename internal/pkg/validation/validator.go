@@ -3,6 +3,7 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -35,6 +36,30 @@ func (v *Validator) Validate(req any) error {
 	return joinValidationErrors(ve)
 }
 
+// ValidateRows checks the validity of each element of the rows slice
+// and returns the errors together with their row numbers, starting from 1.
+func (v *Validator) ValidateRows(rows any) error {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("validation: rows must be a slice, got %s", rv.Kind())
+	}
+	var rowErrors RowErrors
+	for i := range rv.Len() {
+		err := v.Validate(rv.Index(i).Interface())
+		var fe FieldErrors
+		if errors.As(err, &fe) {
+			rowErrors = append(rowErrors, RowError{
+				Row:    i + 1,
+				Errors: fe,
+			})
+		}
+	}
+	if len(rowErrors) > 0 {
+		return rowErrors
+	}
+	return nil
+}
+
 // joinValidationErrors converts a slice of FieldError into a concatenated error.
 func joinValidationErrors(validationErrors validator.ValidationErrors) error {
 	var fieldErrors FieldErrors
@@ -55,9 +80,10 @@ func validationMessage(fe validator.FieldError) string {
 	return formatMessage("%s format is invalid", fe.Field())
 }
 
-// formatMessage is a helper function to format messages with parameters.
+// formatMessage is a helper function to format messages with parameters,
+// the parameter is left out of templates without a placeholder for it.
 func formatMessage(template, field string, param ...string) string {
-	if len(param) > 0 && param[0] != "" {
+	if len(param) > 0 && param[0] != "" && strings.Count(template, "%s") > 1 {
 		param[0] = strings.ReplaceAll(param[0], " ", ", ")
 		return fmt.Sprintf(template, field, param[0])
 	}
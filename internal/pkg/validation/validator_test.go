@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+type testRow struct {
+	AccountNumber string `json:"account_number" validate:"required,number"`
+	Amount        int64  `json:"amount" validate:"required,gte=1000"`
+}
+
+func TestValidateRows(t *testing.T) {
+	v := New()
+
+	err := v.ValidateRows([]testRow{
+		{AccountNumber: "123", Amount: 1000},
+		{AccountNumber: "abc", Amount: 1000},
+		{AccountNumber: "456", Amount: 10},
+	})
+
+	var rowErrors RowErrors
+	if !errors.As(err, &rowErrors) {
+		t.Fatalf("ValidateRows() error = %v, want RowErrors", err)
+	}
+	if len(rowErrors) != 2 {
+		t.Fatalf("ValidateRows() returned %d row errors, want 2", len(rowErrors))
+	}
+	if rowErrors[0].Row != 2 || rowErrors[0].Errors[0].Name != "account_number" {
+		t.Errorf("ValidateRows() first error = %+v, want row 2 account_number", rowErrors[0])
+	}
+	if rowErrors[1].Row != 3 || rowErrors[1].Errors[0].Name != "amount" {
+		t.Errorf("ValidateRows() second error = %+v, want row 3 amount", rowErrors[1])
+	}
+}
+
+func TestValidateRows_Valid(t *testing.T) {
+	v := New()
+
+	err := v.ValidateRows([]testRow{
+		{AccountNumber: "123", Amount: 1000},
+	})
+
+	if err != nil {
+		t.Errorf("ValidateRows() error = %v, want nil", err)
+	}
+}
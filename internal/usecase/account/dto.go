@@ -0,0 +1,57 @@
+package account
+
+type GetAccountRequest struct {
+	Number string `param:"number" json:"number" validate:"required,accountnumber"`
+}
+
+type AccountResponse struct {
+	AccountNumber    string `json:"account_number"`
+	MaskedNumber     string `json:"masked_number"`
+	ParentAccount    string `json:"parent_account,omitempty"`
+	FullName         string `json:"full_name"`
+	Name             string `json:"name,omitempty"`
+	Type             string `json:"type"`
+	Status           string `json:"status"`
+	AvailableBalance int64  `json:"available_balance"`
+	LedgerBalance    int64  `json:"ledger_balance"`
+}
+
+type OpenRequest struct {
+	Type string `json:"type" validate:"required,only=savings current"`
+}
+
+type CreatePocketRequest struct {
+	Number string `param:"number" json:"number" validate:"required,accountnumber"`
+	Name   string `json:"name" validate:"required,max=50"`
+}
+
+type RenamePocketRequest struct {
+	Number string `param:"number" json:"number" validate:"required,accountnumber"`
+	Name   string `json:"name" validate:"required,max=50"`
+}
+
+type ClosePocketRequest struct {
+	Number string `param:"number" json:"number" validate:"required,accountnumber"`
+}
+
+type ClosePocketResponse struct {
+	Message string `json:"message"`
+}
+
+type MoveRequest struct {
+	SourceAccount      string `json:"source_account" validate:"required,accountnumber"`
+	DestinationAccount string `json:"destination_account" validate:"required,accountnumber,nefield=SourceAccount"`
+	Amount             int64  `json:"amount" validate:"required,min=1"`
+	Note               string `json:"note" validate:"max=255"`
+}
+
+type ChangeStatusRequest struct {
+	Number string `param:"number" json:"number" validate:"required,accountnumber"`
+	Status string `json:"status" validate:"required,only=active dormant frozen_debit frozen_credit closed"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type MoveResponse struct {
+	UUID   string `json:"uuid"`
+	Status string `json:"status"`
+}
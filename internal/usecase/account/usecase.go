@@ -0,0 +1,446 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/audit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+// dormancyActor is the audit actor of the status changes made by the dormancy job.
+const dormancyActor = "system"
+
+// Usecase defines the use case for the accounts of the logged in user and their lifecycle.
+type Usecase struct {
+	dormancyMonths int
+	accountRepo    account.Repository
+	userRepo       user.Repository
+	txRepo         transaction.Repository
+	auditRepo      audit.Repository
+	transferUc     *transfer.Usecase
+}
+
+func NewUsecase(
+	cfg *config.Configs,
+	accountRepo account.Repository,
+	userRepo user.Repository,
+	txRepo transaction.Repository,
+	auditRepo audit.Repository,
+	transferUc *transfer.Usecase,
+) *Usecase {
+	return &Usecase{
+		dormancyMonths: cfg.Account.DormancyMonths,
+		accountRepo:    accountRepo,
+		userRepo:       userRepo,
+		txRepo:         txRepo,
+		auditRepo:      auditRepo,
+		transferUc:     transferUc,
+	}
+}
+
+// GetAccounts returns the accounts of the user in the context with their balances.
+func (uc *Usecase) GetAccounts(ctx context.Context) ([]*AccountResponse, error) {
+	l := log.WithContext(ctx, "GetAccounts")
+
+	u, err := uc.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := uc.accountRepo.ListByCIF(ctx, u.CIF)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get accounts")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := make([]*AccountResponse, 0, len(accounts))
+	for _, acc := range accounts {
+		res = append(res, newAccountResponse(acc))
+	}
+	return res, nil
+}
+
+// GetAccount returns an account of the user in the context with its balances.
+func (uc *Usecase) GetAccount(ctx context.Context, req *GetAccountRequest) (*AccountResponse, error) {
+	u, err := uc.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := uc.getOwnedAccount(ctx, u.CIF, req.Number)
+	if err != nil {
+		return nil, err
+	}
+	return newAccountResponse(acc), nil
+}
+
+// Open opens a savings or current account under the CIF of the user in the context.
+func (uc *Usecase) Open(ctx context.Context, req *OpenRequest) (*AccountResponse, error) {
+	l := log.WithContext(ctx, "Open")
+
+	u, err := uc.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := uc.accountRepo.Open(ctx, account.Account{
+		CIF:      u.CIF,
+		FullName: u.FullName(),
+		Type:     req.Type,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("cif", u.CIF).
+			Str("type", req.Type).
+			Msg("Failed to open account")
+		return nil, pkgerror.InternalServerError()
+	}
+	return newAccountResponse(acc), nil
+}
+
+// CreatePocket opens a pocket with its own balance under a savings or current account of the user in the context.
+func (uc *Usecase) CreatePocket(ctx context.Context, req *CreatePocketRequest) (*AccountResponse, error) {
+	l := log.WithContext(ctx, "CreatePocket")
+
+	u, err := uc.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := uc.getOwnedAccount(ctx, u.CIF, req.Number)
+	if err != nil {
+		return nil, err
+	}
+	if parent.IsPocket() {
+		return nil, pkgerror.BadRequest().SetMsg("A pocket cannot be opened under another pocket")
+	}
+
+	pocket, err := uc.accountRepo.Open(ctx, account.Account{
+		CIF:           u.CIF,
+		ParentAccount: parent.AccountNumber,
+		FullName:      parent.FullName,
+		Name:          req.Name,
+		Type:          account.TypePocket,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", parent.AccountNumber).
+			Msg("Failed to open pocket")
+		return nil, pkgerror.InternalServerError()
+	}
+	return newAccountResponse(pocket), nil
+}
+
+// RenamePocket renames a pocket of the user in the context.
+func (uc *Usecase) RenamePocket(ctx context.Context, req *RenamePocketRequest) (*AccountResponse, error) {
+	l := log.WithContext(ctx, "RenamePocket")
+
+	pocket, err := uc.getOwnedPocket(ctx, req.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.accountRepo.Rename(ctx, pocket.AccountNumber, req.Name)
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", pocket.AccountNumber).
+			Msg("Failed to rename pocket")
+		return nil, pkgerror.InternalServerError()
+	}
+	pocket.Name = req.Name
+	return newAccountResponse(pocket), nil
+}
+
+// ClosePocket moves the balance of a pocket of the user in the context back to its parent account and closes it.
+func (uc *Usecase) ClosePocket(ctx context.Context, req *ClosePocketRequest) (*ClosePocketResponse, error) {
+	l := log.WithContext(ctx, "ClosePocket")
+
+	pocket, err := uc.getOwnedPocket(ctx, req.Number)
+	if err != nil {
+		return nil, err
+	}
+	if pocket.AvailableBalance != pocket.Balance {
+		return nil, pkgerror.Conflict().SetMsg("Pocket has transactions in progress")
+	}
+
+	if pocket.Balance > 0 {
+		res, err := uc.move(ctx, pocket.AccountNumber, pocket.ParentAccount, pocket.Balance, "Close pocket "+pocket.Name)
+		if err != nil {
+			return nil, err
+		}
+		if res.Status != transaction.StatusCompleted {
+			l.Error().
+				Str("account_number", pocket.AccountNumber).
+				Str("transaction_id", res.UUID).
+				Str("status", res.Status).
+				Msg("Pocket balance was not moved to the parent account")
+			return nil, pkgerror.Conflict().SetMsg("Pocket balance is still being moved, try again later")
+		}
+	}
+
+	err = uc.accountRepo.Close(ctx, pocket.AccountNumber)
+	if err != nil && errors.Is(err, account.ErrBalanceNotZero) {
+		return nil, pkgerror.Conflict().SetMsg("Pocket still has a balance")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", pocket.AccountNumber).
+			Msg("Failed to close pocket")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return &ClosePocketResponse{
+		Message: "Pocket closed successfully",
+	}, nil
+}
+
+// Move moves money between two accounts or pockets of the user in the context.
+func (uc *Usecase) Move(ctx context.Context, req *MoveRequest) (*MoveResponse, error) {
+	u, err := uc.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = uc.getOwnedAccount(ctx, u.CIF, req.SourceAccount)
+	if err != nil {
+		return nil, err
+	}
+	_, err = uc.getOwnedAccount(ctx, u.CIF, req.DestinationAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := uc.move(ctx, req.SourceAccount, req.DestinationAccount, req.Amount, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &MoveResponse{
+		UUID:   res.UUID,
+		Status: res.Status,
+	}, nil
+}
+
+// ChangeStatus changes the status of an account with the reason, on behalf of the ops user in the context.
+// Accounts are only closed without money, holds or open pockets, and closed accounts stay closed.
+func (uc *Usecase) ChangeStatus(ctx context.Context, req *ChangeStatusRequest) (*AccountResponse, error) {
+	l := log.WithContext(ctx, "ChangeStatus")
+
+	actor, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	acc, err := uc.accountRepo.Get(ctx, req.Number)
+	if err != nil && errors.Is(err, account.ErrNotFound) {
+		return nil, pkgerror.NotFound().SetMsg("Account not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", req.Number).
+			Msg("Failed to get account")
+		return nil, pkgerror.InternalServerError()
+	}
+	if acc.IsClosed() {
+		return nil, pkgerror.Conflict().SetMsg("Account is closed")
+	}
+	if !acc.CanChangeStatus(req.Status) {
+		return nil, pkgerror.Conflict().SetMsg("Account cannot change from " + acc.Status + " to " + req.Status)
+	}
+
+	if req.Status == account.StatusClosed {
+		err = uc.accountRepo.Close(ctx, acc.AccountNumber)
+	} else {
+		err = uc.accountRepo.SetStatus(ctx, acc.AccountNumber, req.Status)
+	}
+	if err != nil && errors.Is(err, account.ErrBalanceNotZero) {
+		return nil, pkgerror.Conflict().SetMsg("Account still has a balance, holds or pockets")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", acc.AccountNumber).
+			Str("status", req.Status).
+			Msg("Failed to change account status")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	uc.recordStatusChange(ctx, actor.Username, acc, req.Status, req.Reason)
+	acc.Status = req.Status
+	return newAccountResponse(acc), nil
+}
+
+// FlagDormant marks the active savings and current accounts of the customers as dormant
+// when they had no customer activity for the configured number of months.
+// The activity is the latest completed transaction the customer initiated from the account,
+// reversals and the transfers of standing orders and savings goal sweeps do not count.
+func (uc *Usecase) FlagDormant(ctx context.Context) error {
+	l := log.WithContext(ctx, "FlagDormant")
+
+	if uc.dormancyMonths <= 0 {
+		return nil
+	}
+	cutoff := time.Now().AddDate(0, -uc.dormancyMonths, 0)
+	reason := "No customer activity for " + strconv.Itoa(uc.dormancyMonths) + " months"
+
+	customers, err := uc.userRepo.GetCustomers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, u := range customers {
+		accounts, err := uc.accountRepo.ListByCIF(ctx, u.CIF)
+		if err != nil {
+			l.Error().Err(err).
+				Str("cif", u.CIF).
+				Msg("Failed to get accounts")
+			continue
+		}
+		for _, acc := range accounts {
+			if acc.Status != account.StatusActive || acc.OpenedAt.After(cutoff) {
+				continue
+			}
+			lastDebitAt, err := uc.txRepo.GetLastDebitAt(ctx, acc.AccountNumber)
+			if err != nil {
+				l.Error().Err(err).
+					Str("account_number", acc.AccountNumber).
+					Msg("Failed to get last activity")
+				continue
+			}
+			if !acc.Dormant(lastDebitAt, cutoff) {
+				continue
+			}
+			err = uc.accountRepo.SetStatus(ctx, acc.AccountNumber, account.StatusDormant)
+			if err != nil {
+				l.Error().Err(err).
+					Str("account_number", acc.AccountNumber).
+					Msg("Failed to flag account as dormant")
+				continue
+			}
+			uc.recordStatusChange(ctx, dormancyActor, acc, account.StatusDormant, reason)
+		}
+	}
+	return nil
+}
+
+// recordStatusChange records the status change of the account in the audit trail.
+// The status is already changed in the core banking system, so a failure is only logged.
+func (uc *Usecase) recordStatusChange(ctx context.Context, actor string, acc account.Account, status, reason string) {
+	l := log.WithContext(ctx, "recordStatusChange")
+
+	err := uc.auditRepo.Create(ctx, audit.Entry{
+		Actor:        actor,
+		Action:       audit.ActionAccountStatusChanged,
+		ResourceType: audit.ResourceAccount,
+		ResourceUUID: acc.AccountNumber,
+		Detail:       "status " + acc.Status + " to " + status + ": " + reason,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("actor", actor).
+			Str("account_number", acc.AccountNumber).
+			Str("from", acc.Status).
+			Str("to", status).
+			Str("reason", reason).
+			Msg("Failed to record account status change")
+	}
+}
+
+// move initiates and processes an internal transfer between accounts of the user in the context.
+func (uc *Usecase) move(ctx context.Context, src, dest string, amount int64, note string) (*transfer.ProcessResponse, error) {
+	initRes, err := uc.transferUc.Initiate(ctx, &transfer.InitiateRequest{
+		SourceAccount:      src,
+		DestinationAccount: dest,
+		Amount:             amount,
+		Note:               note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.transferUc.Process(ctx, &transfer.ProcessRequest{
+		UUID:               initRes.UUID,
+		SourceAccount:      src,
+		DestinationAccount: dest,
+		Amount:             amount,
+	})
+}
+
+// getUser returns the user in the context from the repository, the token does not carry the CIF.
+func (uc *Usecase) getUser(ctx context.Context) (user.User, error) {
+	l := log.WithContext(ctx, "getUser")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return user.User{}, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	u, err := uc.userRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).
+			Str("username", userFromCtx.Username).
+			Msg("Failed to get user")
+		return user.User{}, pkgerror.InternalServerError()
+	}
+	return u, nil
+}
+
+// getOwnedAccount returns the open account when it belongs to the CIF,
+// other customers' and closed accounts are reported as not found.
+func (uc *Usecase) getOwnedAccount(ctx context.Context, cif, accountNumber string) (account.Account, error) {
+	l := log.WithContext(ctx, "getOwnedAccount")
+
+	acc, err := uc.accountRepo.Get(ctx, accountNumber)
+	if err != nil && errors.Is(err, account.ErrNotFound) {
+		return account.Account{}, pkgerror.NotFound().SetMsg("Account not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", accountNumber).
+			Msg("Failed to get account")
+		return account.Account{}, pkgerror.InternalServerError()
+	}
+	if !acc.OwnedBy(cif) || acc.IsClosed() {
+		return account.Account{}, pkgerror.NotFound().SetMsg("Account not found")
+	}
+	return acc, nil
+}
+
+// getOwnedPocket returns the open pocket of the user in the context.
+func (uc *Usecase) getOwnedPocket(ctx context.Context, accountNumber string) (account.Account, error) {
+	u, err := uc.getUser(ctx)
+	if err != nil {
+		return account.Account{}, err
+	}
+
+	pocket, err := uc.getOwnedAccount(ctx, u.CIF, accountNumber)
+	if err != nil {
+		return account.Account{}, err
+	}
+	if !pocket.IsPocket() {
+		return account.Account{}, pkgerror.NotFound().SetMsg("Pocket not found")
+	}
+	return pocket, nil
+}
+
+func newAccountResponse(acc account.Account) *AccountResponse {
+	return &AccountResponse{
+		AccountNumber:    acc.AccountNumber,
+		MaskedNumber:     acc.MaskedNumber(),
+		ParentAccount:    acc.ParentAccount,
+		FullName:         acc.FullName,
+		Name:             acc.Name,
+		Type:             acc.Type,
+		Status:           acc.Status,
+		AvailableBalance: acc.AvailableBalance,
+		LedgerBalance:    acc.Balance,
+	}
+}
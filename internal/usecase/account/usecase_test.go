@@ -0,0 +1,522 @@
+package account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/audit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	domaintransfer "go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+func TestGetAccounts_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().ListByCIF(mock.Anything, "0000000001").
+		Return([]account.Account{
+			{
+				CIF:              "0000000001",
+				AccountNumber:    "1000000001",
+				FullName:         "John Doe",
+				Type:             account.TypeSavings,
+				Status:           account.StatusActive,
+				Balance:          100000,
+				AvailableBalance: 75000,
+			},
+		}, nil)
+
+	res, err := uc.GetAccounts(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*AccountResponse{
+		{
+			AccountNumber:    "1000000001",
+			MaskedNumber:     "******0001",
+			FullName:         "John Doe",
+			Type:             account.TypeSavings,
+			Status:           account.StatusActive,
+			AvailableBalance: 75000,
+			LedgerBalance:    100000,
+		},
+	}, res)
+}
+
+func TestGetAccount_NotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000002").
+		Return(account.Account{CIF: "0000000002", AccountNumber: "1000000002"}, nil)
+
+	res, err := uc.GetAccount(ctx, &GetAccountRequest{Number: "1000000002"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Account not found"), err)
+}
+
+func TestGetAccount_NotFound(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000009").
+		Return(account.Account{}, account.ErrNotFound)
+
+	res, err := uc.GetAccount(ctx, &GetAccountRequest{Number: "1000000009"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Account not found"), err)
+}
+
+func TestOpen_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001", FirstName: "John", LastName: "Doe"}, nil)
+	accountRepo.EXPECT().Open(mock.Anything, account.Account{
+		CIF:      "0000000001",
+		FullName: "John Doe",
+		Type:     account.TypeCurrent,
+	}).Return(account.Account{
+		CIF:           "0000000001",
+		AccountNumber: "1000000002",
+		FullName:      "John Doe",
+		Type:          account.TypeCurrent,
+		Status:        account.StatusActive,
+	}, nil)
+
+	res, err := uc.Open(ctx, &OpenRequest{Type: account.TypeCurrent})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000002", res.AccountNumber)
+	assert.Equal(t, account.TypeCurrent, res.Type)
+}
+
+func TestCreatePocket_UnderPocket(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(account.Account{
+			CIF:           "0000000001",
+			AccountNumber: "1000000003",
+			ParentAccount: "1000000001",
+			Type:          account.TypePocket,
+			Status:        account.StatusActive,
+		}, nil)
+
+	res, err := uc.CreatePocket(ctx, &CreatePocketRequest{Number: "1000000003", Name: "Holiday"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("A pocket cannot be opened under another pocket"), err)
+}
+
+func TestClosePocket_MovesBalance(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	pocket := account.Account{
+		CIF:              "0000000001",
+		AccountNumber:    "1000000003",
+		ParentAccount:    "1000000001",
+		Name:             "Holiday",
+		Type:             account.TypePocket,
+		Status:           account.StatusActive,
+		Balance:          50000,
+		AvailableBalance: 50000,
+	}
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(pocket, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{CIF: "0000000001", AccountNumber: "1000000001", Status: account.StatusActive}, nil)
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "1000000003", mock.Anything, int64(50000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, uuid string) (transaction.Transaction, error) {
+			return transaction.Transaction{
+				UUID:               uuid,
+				Status:             transaction.StatusInitiated,
+				Rail:               domaintransfer.RailInternal,
+				SourceAccount:      "1000000003",
+				DestinationAccount: "1000000001",
+				Amount:             50000,
+				Username:           "johndoe",
+			}, nil
+		})
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, uuid, reason string) (transaction.Transaction, error) {
+			return transaction.Transaction{
+				UUID:               uuid,
+				Status:             transaction.StatusPending,
+				Rail:               domaintransfer.RailInternal,
+				SourceAccount:      "1000000003",
+				DestinationAccount: "1000000001",
+				Amount:             50000,
+				Username:           "johndoe",
+			}, nil
+		})
+	transferSvc.EXPECT().Transfer(mock.Anything, "1000000003", "1000000001", int64(50000), mock.Anything).
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusCompleted
+	})).Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, mock.Anything).
+		Return(nil)
+	accountRepo.EXPECT().Close(mock.Anything, "1000000003").
+		Return(nil)
+
+	res, err := uc.ClosePocket(ctx, &ClosePocketRequest{Number: "1000000003"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Pocket closed successfully", res.Message)
+}
+
+func TestMove_DestinationNotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{CIF: "0000000001", AccountNumber: "1000000001", Status: account.StatusActive}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000002").
+		Return(account.Account{CIF: "0000000002", AccountNumber: "1000000002", Status: account.StatusActive}, nil)
+
+	res, err := uc.Move(ctx, &MoveRequest{
+		SourceAccount:      "1000000001",
+		DestinationAccount: "1000000002",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Account not found"), err)
+}
+
+func TestChangeStatus_FreezeDebit(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "opsuser",
+			Role:     user.RoleOps,
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{
+			AccountNumber: "1000000001",
+			Type:          account.TypeSavings,
+			Status:        account.StatusActive,
+		}, nil)
+	accountRepo.EXPECT().SetStatus(mock.Anything, "1000000001", account.StatusFrozenDebit).
+		Return(nil)
+	auditRepo.EXPECT().Create(mock.Anything, audit.Entry{
+		Actor:        "opsuser",
+		Action:       audit.ActionAccountStatusChanged,
+		ResourceType: audit.ResourceAccount,
+		ResourceUUID: "1000000001",
+		Detail:       "status active to frozen_debit: Court order 123",
+	}).Return(nil)
+
+	res, err := uc.ChangeStatus(ctx, &ChangeStatusRequest{
+		Number: "1000000001",
+		Status: account.StatusFrozenDebit,
+		Reason: "Court order 123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, account.StatusFrozenDebit, res.Status)
+}
+
+func TestChangeStatus_ClosedAccount(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "opsuser",
+			Role:     user.RoleOps,
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{AccountNumber: "1000000001", Status: account.StatusClosed}, nil)
+
+	res, err := uc.ChangeStatus(ctx, &ChangeStatusRequest{
+		Number: "1000000001",
+		Status: account.StatusActive,
+		Reason: "Customer request",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Account is closed"), err)
+}
+
+func TestChangeStatus_CloseWithBalance(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "opsuser",
+			Role:     user.RoleOps,
+		})
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{
+			AccountNumber: "1000000001",
+			Type:          account.TypeSavings,
+			Status:        account.StatusDormant,
+			Balance:       100000,
+		}, nil)
+	accountRepo.EXPECT().Close(mock.Anything, "1000000001").
+		Return(account.ErrBalanceNotZero)
+
+	res, err := uc.ChangeStatus(ctx, &ChangeStatusRequest{
+		Number: "1000000001",
+		Status: account.StatusClosed,
+		Reason: "Customer request",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Account still has a balance, holds or pockets"), err)
+}
+
+func TestFlagDormant(t *testing.T) {
+	var (
+		cfg         = new(config.Configs)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		cbsService  = cbs.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Account.DormancyMonths = 12
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, accountRepo, userRepo, txRepo, auditRepo, transferUc)
+
+	longAgo := time.Now().AddDate(-2, 0, 0)
+	userRepo.EXPECT().GetCustomers(mock.Anything).
+		Return([]user.User{{Username: "johndoe", CIF: "0000000001"}}, nil)
+	accountRepo.EXPECT().ListByCIF(mock.Anything, "0000000001").
+		Return([]account.Account{
+			{AccountNumber: "1000000001", Type: account.TypeSavings, Status: account.StatusActive, OpenedAt: longAgo},
+			{AccountNumber: "1000000002", Type: account.TypeCurrent, Status: account.StatusActive, OpenedAt: longAgo},
+			{AccountNumber: "1000000003", Type: account.TypeSavings, Status: account.StatusActive, OpenedAt: time.Now()},
+			{AccountNumber: "1000000004", Type: account.TypeSavings, Status: account.StatusFrozenDebit, OpenedAt: longAgo},
+		}, nil)
+	txRepo.EXPECT().GetLastDebitAt(mock.Anything, "1000000001").
+		Return(longAgo.AddDate(0, 6, 0), nil)
+	txRepo.EXPECT().GetLastDebitAt(mock.Anything, "1000000002").
+		Return(time.Now().AddDate(0, -1, 0), nil)
+	accountRepo.EXPECT().SetStatus(mock.Anything, "1000000001", account.StatusDormant).
+		Return(nil)
+	auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e audit.Entry) bool {
+		return e.Actor == dormancyActor &&
+			e.ResourceUUID == "1000000001" &&
+			e.Detail == "status active to dormant: No customer activity for 12 months"
+	})).Return(nil)
+
+	err := uc.FlagDormant(context.Background())
+
+	assert.NoError(t, err)
+}
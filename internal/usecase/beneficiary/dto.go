@@ -0,0 +1,40 @@
+package beneficiary
+
+import "time"
+
+type CreateRequest struct {
+	BankCode      string `json:"bank_code" validate:"omitempty,number,len=3"`
+	AccountNumber string `json:"account_number" validate:"required,accountnumber=BankCode"`
+	Nickname      string `json:"nickname" validate:"required,max=100"`
+	Favourite     bool   `json:"favourite"`
+	OTP           string `json:"otp" validate:"omitempty,number,len=6"`
+}
+
+type BeneficiaryResponse struct {
+	UUID          string    `json:"uuid"`
+	BankCode      string    `json:"bank_code"`
+	AccountNumber string    `json:"account_number"`
+	Nickname      string    `json:"nickname"`
+	VerifiedName  string    `json:"verified_name"`
+	Favourite     bool      `json:"favourite"`
+	CreatedAt     time.Time `json:"created_at,omitzero"`
+}
+
+type UpdateRequest struct {
+	UUID      string `param:"uuid" json:"uuid" validate:"required,uuid"`
+	Nickname  string `json:"nickname" validate:"max=100"`
+	Favourite *bool  `json:"favourite"`
+}
+
+type DeleteRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type DeleteResponse struct {
+	Message string `json:"message"`
+}
+
+type RequestOTPResponse struct {
+	Message   string    `json:"message"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,335 @@
+package beneficiary
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/otp"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+)
+
+// maxOTPAttempts is the number of wrong codes after which the OTP is discarded.
+const maxOTPAttempts = 3
+
+// Usecase defines the use case for the beneficiary address book.
+type Usecase struct {
+	bankCode        string
+	requireOTP      bool
+	otpDuration     time.Duration
+	beneficiaryRepo beneficiary.Repository
+	otpRepo         otp.Repository
+	accountRepo     account.Repository
+	bifastSvc       transfer.BIFastService
+	authSvc         user.AuthService
+	notificationSvc notification.Service
+}
+
+func NewUsecase(
+	cfg *config.Configs,
+	beneficiaryRepo beneficiary.Repository,
+	otpRepo otp.Repository,
+	accountRepo account.Repository,
+	bifastSvc transfer.BIFastService,
+	authSvc user.AuthService,
+	notificationSvc notification.Service,
+) *Usecase {
+	return &Usecase{
+		bankCode:        cfg.Interbank.BankCode,
+		requireOTP:      cfg.Beneficiary.RequireOTP,
+		otpDuration:     cfg.Beneficiary.OTPDuration,
+		beneficiaryRepo: beneficiaryRepo,
+		otpRepo:         otpRepo,
+		accountRepo:     accountRepo,
+		bifastSvc:       bifastSvc,
+		authSvc:         authSvc,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// RequestOTP sends the user an OTP to confirm saving a new beneficiary.
+func (uc *Usecase) RequestOTP(ctx context.Context) (*RequestOTPResponse, error) {
+	l := log.WithContext(ctx, "RequestOTP")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	code, err := generateOTPCode()
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to generate OTP")
+		return nil, pkgerror.InternalServerError()
+	}
+	codeHash, err := uc.authSvc.HashPassword(code)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to hash OTP")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	o := otp.OTP{
+		Username:  userFromCtx.Username,
+		Purpose:   otp.PurposeCreateBeneficiary,
+		CodeHash:  codeHash,
+		ExpiresAt: time.Now().Add(uc.otpDuration),
+	}
+	err = uc.otpRepo.Save(ctx, o)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to save OTP")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	err = uc.notificationSvc.Send(ctx, notification.Notification{
+		Username: userFromCtx.Username,
+		Title:    "Beneficiary verification code",
+		Message:  "Use " + code + " to confirm your new beneficiary. Never share this code with anyone.",
+	})
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to send OTP")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return &RequestOTPResponse{
+		Message:   "OTP sent",
+		ExpiresAt: o.ExpiresAt,
+	}, nil
+}
+
+func (uc *Usecase) Create(ctx context.Context, req *CreateRequest) (*BeneficiaryResponse, error) {
+	l := log.WithContext(ctx, "Create")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	if uc.requireOTP {
+		err = uc.verifyOTP(ctx, userFromCtx.Username, req.OTP)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bankCode := req.BankCode
+	if bankCode == "" {
+		bankCode = uc.bankCode
+	}
+	verifiedName, err := uc.inquiry(ctx, bankCode, req.AccountNumber)
+	if err != nil {
+		l.Error().Err(err).
+			Str("bank_code", bankCode).
+			Str("account_number", req.AccountNumber).
+			Msg("Account inquiry failed")
+		return nil, pkgerror.BadRequest().SetMsg("Account not found")
+	}
+
+	b := beneficiary.Beneficiary{
+		UUID:          uuid.New().String(),
+		Username:      userFromCtx.Username,
+		BankCode:      bankCode,
+		AccountNumber: req.AccountNumber,
+		Nickname:      req.Nickname,
+		VerifiedName:  verifiedName,
+		Favourite:     req.Favourite,
+	}
+
+	err = uc.beneficiaryRepo.Create(ctx, b)
+	if err != nil && errors.Is(err, beneficiary.ErrDuplicate) {
+		return nil, pkgerror.Conflict().SetMsg("Beneficiary already exists")
+	}
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to create beneficiary")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return newBeneficiaryResponse(b), nil
+}
+
+func (uc *Usecase) GetBeneficiaries(ctx context.Context) ([]*BeneficiaryResponse, error) {
+	l := log.WithContext(ctx, "GetBeneficiaries")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	beneficiaries, err := uc.beneficiaryRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get beneficiaries")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := make([]*BeneficiaryResponse, 0, len(beneficiaries))
+	for _, b := range beneficiaries {
+		res = append(res, newBeneficiaryResponse(b))
+	}
+	return res, nil
+}
+
+func (uc *Usecase) Update(ctx context.Context, req *UpdateRequest) (*BeneficiaryResponse, error) {
+	l := log.WithContext(ctx, "Update")
+
+	b, err := uc.getOwnedBeneficiary(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Nickname != "" {
+		b.Nickname = req.Nickname
+	}
+	if req.Favourite != nil {
+		b.Favourite = *req.Favourite
+	}
+
+	err = uc.beneficiaryRepo.Update(ctx, b)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", b.UUID).
+			Msg("Failed to update beneficiary")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return newBeneficiaryResponse(b), nil
+}
+
+func (uc *Usecase) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	l := log.WithContext(ctx, "Delete")
+
+	b, err := uc.getOwnedBeneficiary(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.beneficiaryRepo.Delete(ctx, b.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", b.UUID).
+			Msg("Failed to delete beneficiary")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return &DeleteResponse{
+		Message: "Beneficiary deleted",
+	}, nil
+}
+
+// verifyOTP checks the step-up code of the user. The OTP is single use
+// and is discarded after too many wrong codes.
+func (uc *Usecase) verifyOTP(ctx context.Context, username, code string) error {
+	l := log.WithContext(ctx, "verifyOTP")
+
+	if code == "" {
+		return pkgerror.BadRequest().SetMsg("OTP is required")
+	}
+
+	o, err := uc.otpRepo.Get(ctx, username, otp.PurposeCreateBeneficiary)
+	if err != nil && errors.Is(err, otp.ErrNotFound) {
+		return pkgerror.BadRequest().SetMsg("OTP is invalid or expired")
+	}
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get OTP")
+		return pkgerror.InternalServerError()
+	}
+	if o.Expired() {
+		return pkgerror.BadRequest().SetMsg("OTP is invalid or expired")
+	}
+
+	err = uc.authSvc.ValidatePassword(code, o.CodeHash)
+	if err != nil {
+		o.Attempts++
+		if o.Attempts >= maxOTPAttempts {
+			err = uc.otpRepo.Delete(ctx, username, o.Purpose)
+		} else {
+			err = uc.otpRepo.Save(ctx, o)
+		}
+		if err != nil {
+			l.Error().Err(err).Msg("Failed to record OTP attempt")
+		}
+		return pkgerror.BadRequest().SetMsg("OTP is invalid or expired")
+	}
+
+	err = uc.otpRepo.Delete(ctx, username, o.Purpose)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to delete OTP")
+		return pkgerror.InternalServerError()
+	}
+	return nil
+}
+
+// inquiry returns the holder name of the account,
+// from the core banking system or from BI-FAST for other banks.
+func (uc *Usecase) inquiry(ctx context.Context, bankCode, accountNumber string) (string, error) {
+	if bankCode == uc.bankCode {
+		acc, err := uc.accountRepo.Get(ctx, accountNumber)
+		if err != nil {
+			return "", err
+		}
+		return acc.FullName, nil
+	}
+	acc, err := uc.bifastSvc.AccountInquiry(ctx, bankCode, accountNumber)
+	if err != nil {
+		return "", err
+	}
+	return acc.Name, nil
+}
+
+// getOwnedBeneficiary retrieves a beneficiary that belongs to the user in the context.
+func (uc *Usecase) getOwnedBeneficiary(ctx context.Context, beneficiaryUUID string) (beneficiary.Beneficiary, error) {
+	l := log.WithContext(ctx, "getOwnedBeneficiary")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return beneficiary.Beneficiary{}, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	b, err := uc.beneficiaryRepo.GetByUUID(ctx, beneficiaryUUID)
+	if err != nil && errors.Is(err, beneficiary.ErrNotFound) {
+		return beneficiary.Beneficiary{}, pkgerror.NotFound().SetMsg("Beneficiary not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", beneficiaryUUID).
+			Msg("Failed to get beneficiary")
+		return beneficiary.Beneficiary{}, pkgerror.InternalServerError()
+	}
+	if !b.OwnedBy(userFromCtx.Username) {
+		return beneficiary.Beneficiary{}, pkgerror.NotFound().SetMsg("Beneficiary not found")
+	}
+	return b, nil
+}
+
+func newBeneficiaryResponse(b beneficiary.Beneficiary) *BeneficiaryResponse {
+	return &BeneficiaryResponse{
+		UUID:          b.UUID,
+		BankCode:      b.BankCode,
+		AccountNumber: b.AccountNumber,
+		Nickname:      b.Nickname,
+		VerifiedName:  b.VerifiedName,
+		Favourite:     b.Favourite,
+		CreatedAt:     b.CreatedAt,
+	}
+}
+
+// generateOTPCode returns a random 6-digit code.
+func generateOTPCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
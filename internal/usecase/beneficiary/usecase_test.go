@@ -0,0 +1,336 @@
+package beneficiary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/otp"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+)
+
+func TestCreate_InternalSuccess(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	cfg.Interbank.BankCode = "999"
+	uc := NewUsecase(cfg, beneficiaryRepo, otpRepo, accountRepo, bifastSvc, authSvc, notificationSvc)
+
+	log.Configure("test")
+
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{AccountNumber: "456", FullName: "Jane Doe"}, nil)
+	beneficiaryRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(b beneficiary.Beneficiary) bool {
+		return b.Username == "johndoe" && b.BankCode == "999" && b.VerifiedName == "Jane Doe"
+	})).Return(nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		AccountNumber: "456",
+		Nickname:      "Jane",
+		Favourite:     true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", res.VerifiedName)
+	assert.Equal(t, "999", res.BankCode)
+	assert.True(t, res.Favourite)
+}
+
+func TestCreate_InterbankSuccess(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	cfg.Interbank.BankCode = "999"
+	uc := NewUsecase(cfg, beneficiaryRepo, otpRepo, accountRepo, bifastSvc, authSvc, notificationSvc)
+
+	log.Configure("test")
+
+	bifastSvc.EXPECT().AccountInquiry(mock.Anything, "014", "456").
+		Return(transfer.InterbankAccount{BankCode: "014", AccountNumber: "456", Name: "Jane Doe"}, nil)
+	beneficiaryRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		BankCode:      "014",
+		AccountNumber: "456",
+		Nickname:      "Jane",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", res.VerifiedName)
+}
+
+func TestCreate_Duplicate(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	cfg.Interbank.BankCode = "999"
+	uc := NewUsecase(cfg, beneficiaryRepo, otpRepo, accountRepo, bifastSvc, authSvc, notificationSvc)
+
+	log.Configure("test")
+
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{AccountNumber: "456", FullName: "Jane Doe"}, nil)
+	beneficiaryRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(beneficiary.ErrDuplicate)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		AccountNumber: "456",
+		Nickname:      "Jane",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Beneficiary already exists"), err)
+}
+
+func TestCreate_OTPRequired(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	cfg.Interbank.BankCode = "999"
+	cfg.Beneficiary.RequireOTP = true
+	uc := NewUsecase(cfg, beneficiaryRepo, otpRepo, accountRepo, bifastSvc, authSvc, notificationSvc)
+
+	log.Configure("test")
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		AccountNumber: "456",
+		Nickname:      "Jane",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("OTP is required"), err)
+}
+
+func TestCreate_OTPWrongCode(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	cfg.Interbank.BankCode = "999"
+	cfg.Beneficiary.RequireOTP = true
+	uc := NewUsecase(cfg, beneficiaryRepo, otpRepo, accountRepo, bifastSvc, authSvc, notificationSvc)
+
+	log.Configure("test")
+
+	otpRepo.EXPECT().Get(mock.Anything, "johndoe", otp.PurposeCreateBeneficiary).
+		Return(otp.OTP{
+			Username:  "johndoe",
+			Purpose:   otp.PurposeCreateBeneficiary,
+			CodeHash:  "hash",
+			Attempts:  maxOTPAttempts - 1,
+			ExpiresAt: time.Now().Add(time.Minute),
+		}, nil)
+	authSvc.EXPECT().ValidatePassword("123456", "hash").
+		Return(errors.New("mismatch"))
+	otpRepo.EXPECT().Delete(mock.Anything, "johndoe", otp.PurposeCreateBeneficiary).
+		Return(nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		AccountNumber: "456",
+		Nickname:      "Jane",
+		OTP:           "123456",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("OTP is invalid or expired"), err)
+}
+
+func TestCreate_OTPSuccess(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	cfg.Interbank.BankCode = "999"
+	cfg.Beneficiary.RequireOTP = true
+	uc := NewUsecase(cfg, beneficiaryRepo, otpRepo, accountRepo, bifastSvc, authSvc, notificationSvc)
+
+	log.Configure("test")
+
+	otpRepo.EXPECT().Get(mock.Anything, "johndoe", otp.PurposeCreateBeneficiary).
+		Return(otp.OTP{
+			Username:  "johndoe",
+			Purpose:   otp.PurposeCreateBeneficiary,
+			CodeHash:  "hash",
+			ExpiresAt: time.Now().Add(time.Minute),
+		}, nil)
+	authSvc.EXPECT().ValidatePassword("123456", "hash").
+		Return(nil)
+	otpRepo.EXPECT().Delete(mock.Anything, "johndoe", otp.PurposeCreateBeneficiary).
+		Return(nil)
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{AccountNumber: "456", FullName: "Jane Doe"}, nil)
+	beneficiaryRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		AccountNumber: "456",
+		Nickname:      "Jane",
+		OTP:           "123456",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", res.Nickname)
+}
+
+func TestRequestOTP_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	cfg.Beneficiary.RequireOTP = true
+	cfg.Beneficiary.OTPDuration = 5 * time.Minute
+	uc := NewUsecase(cfg, beneficiaryRepo, otpRepo, accountRepo, bifastSvc, authSvc, notificationSvc)
+
+	log.Configure("test")
+
+	authSvc.EXPECT().HashPassword(mock.Anything).
+		Return("hash", nil)
+	otpRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(o otp.OTP) bool {
+		return o.Username == "johndoe" && o.CodeHash == "hash" && !o.Expired()
+	})).Return(nil)
+	notificationSvc.EXPECT().Send(mock.Anything, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Username == "johndoe"
+	})).Return(nil)
+
+	res, err := uc.RequestOTP(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "OTP sent", res.Message)
+}
+
+func TestUpdate_Favourite(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(new(config.Configs), beneficiaryRepo, otpRepo, accountRepo, bifastSvc,
+			authSvc, notificationSvc)
+	)
+
+	log.Configure("test")
+
+	favourite := true
+	beneficiaryRepo.EXPECT().GetByUUID(mock.Anything, "ben-123").
+		Return(beneficiary.Beneficiary{UUID: "ben-123", Username: "johndoe", Nickname: "Jane"}, nil)
+	beneficiaryRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(b beneficiary.Beneficiary) bool {
+		return b.Favourite && b.Nickname == "Jane"
+	})).Return(nil)
+
+	res, err := uc.Update(ctx, &UpdateRequest{
+		UUID:      "ben-123",
+		Favourite: &favourite,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, res.Favourite)
+}
+
+func TestDelete_NotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		otpRepo         = otp.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		authSvc         = user.NewMockAuthService(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(new(config.Configs), beneficiaryRepo, otpRepo, accountRepo, bifastSvc,
+			authSvc, notificationSvc)
+	)
+
+	log.Configure("test")
+
+	beneficiaryRepo.EXPECT().GetByUUID(mock.Anything, "ben-123").
+		Return(beneficiary.Beneficiary{UUID: "ben-123", Username: "janedoe"}, nil)
+
+	res, err := uc.Delete(ctx, &DeleteRequest{UUID: "ben-123"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Beneficiary not found"), err)
+}
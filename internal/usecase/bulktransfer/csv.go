@@ -0,0 +1,114 @@
+package bulktransfer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSV columns of the bulk transfer upload and report.
+const (
+	columnDestinationBankCode = "destination_bank_code"
+	columnDestinationAccount  = "destination_account"
+	columnAmount              = "amount"
+	columnNote                = "note"
+)
+
+var reportHeader = []string{
+	"transaction_uuid",
+	columnDestinationBankCode,
+	columnDestinationAccount,
+	columnAmount,
+	columnNote,
+	"status",
+	"reason",
+}
+
+// ParseCSV reads the rows of a bulk transfer upload.
+// The first line is a header naming the columns, in any order;
+// destination_bank_code and note columns are optional.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil && errors.Is(err, io.EOF) {
+		return nil, errors.New("csv file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{columnDestinationAccount, columnAmount} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header is missing the %s column", name)
+		}
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err != nil && errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv line %d: %w", line, err)
+		}
+		amount, err := strconv.ParseInt(field(record, columns, columnAmount), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: amount must be a whole number", line)
+		}
+		rows = append(rows, Row{
+			DestinationBankCode: field(record, columns, columnDestinationBankCode),
+			DestinationAccount:  field(record, columns, columnDestinationAccount),
+			Amount:              amount,
+			Note:                field(record, columns, columnNote),
+		})
+	}
+	return rows, nil
+}
+
+// field returns the trimmed value of the named column, or empty if the column is absent.
+func field(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// writeReport writes the rows of a batch as a CSV report.
+func writeReport(rows []RowResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	err := w.Write(reportHeader)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		err = w.Write([]string{
+			row.TransactionUUID,
+			row.DestinationBankCode,
+			row.DestinationAccount,
+			strconv.FormatInt(row.Amount, 10),
+			row.Note,
+			row.Status,
+			row.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,50 @@
+package bulktransfer
+
+import "time"
+
+type Row struct {
+	DestinationBankCode string `json:"destination_bank_code" validate:"omitempty,number,len=3"`
+	DestinationAccount  string `json:"destination_account" validate:"required,accountnumber=DestinationBankCode"`
+	Amount              int64  `json:"amount" validate:"required,gte=1000"`
+	Note                string `json:"note" validate:"max=255"`
+}
+
+type CreateRequest struct {
+	SourceAccount string `json:"source_account" form:"source_account" query:"source_account" validate:"required,accountnumber"`
+	Rows          []Row  `json:"rows" validate:"required,min=1"`
+}
+
+type CreateResponse struct {
+	UUID        string `json:"uuid"`
+	Status      string `json:"status"`
+	TotalRows   int    `json:"total_rows"`
+	TotalAmount int64  `json:"total_amount"`
+}
+
+type GetRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type BatchResponse struct {
+	UUID          string        `json:"uuid"`
+	SourceAccount string        `json:"source_account"`
+	Status        string        `json:"status"`
+	TotalRows     int           `json:"total_rows"`
+	TotalAmount   int64         `json:"total_amount"`
+	Completed     int           `json:"completed"`
+	Failed        int           `json:"failed"`
+	Pending       int           `json:"pending"`
+	CreatedAt     time.Time     `json:"created_at"`
+	CompletedAt   time.Time     `json:"completed_at,omitzero"`
+	Rows          []RowResponse `json:"rows"`
+}
+
+type RowResponse struct {
+	TransactionUUID     string `json:"transaction_uuid"`
+	DestinationBankCode string `json:"destination_bank_code,omitempty"`
+	DestinationAccount  string `json:"destination_account"`
+	Amount              int64  `json:"amount"`
+	Note                string `json:"note"`
+	Status              string `json:"status"`
+	Reason              string `json:"reason,omitempty"`
+}
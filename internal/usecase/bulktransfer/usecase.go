@@ -0,0 +1,420 @@
+package bulktransfer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/bulktransfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+const bulkTransferTransactionType = "transfer"
+
+// Usecase defines the use case for bulk transfers.
+type Usecase struct {
+	maxRows        int
+	maxTotalAmount int64
+	concurrency    int
+	batchRepo      bulktransfer.Repository
+	txRepo         transaction.Repository
+	accountRepo    account.Repository
+	transferUc     *transfer.Usecase
+
+	// wg tracks the batches that are still being processed.
+	wg sync.WaitGroup
+	// processing holds the UUIDs of the batches processed by this instance,
+	// so Resume does not pick them up a second time.
+	processing sync.Map
+}
+
+func NewUsecase(
+	cfg *config.Configs,
+	batchRepo bulktransfer.Repository,
+	txRepo transaction.Repository,
+	accountRepo account.Repository,
+	transferUc *transfer.Usecase,
+) *Usecase {
+	return &Usecase{
+		maxRows:        cfg.BulkTransfer.MaxRows,
+		maxTotalAmount: cfg.BulkTransfer.MaxTotalAmount,
+		concurrency:    max(cfg.BulkTransfer.Concurrency, 1),
+		batchRepo:      batchRepo,
+		txRepo:         txRepo,
+		accountRepo:    accountRepo,
+		transferUc:     transferUc,
+	}
+}
+
+// Create checks the batch against the limits and the source account balance
+// and initiates one transfer per row, linked to the batch, before the batch is stored.
+// The initiated transfers are then processed in the background, and by Resume
+// when the process stops before they are done.
+// The rows must be validated before calling Create.
+func (uc *Usecase) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	l := log.WithContext(ctx, "Create")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	if uc.maxRows > 0 && len(req.Rows) > uc.maxRows {
+		l.Error().
+			Int("rows", len(req.Rows)).
+			Int("max_rows", uc.maxRows).
+			Msg("Batch has too many rows")
+		return nil, pkgerror.BadRequest().SetMsg("Batch has too many rows")
+	}
+
+	var totalAmount, totalFee int64
+	for _, row := range req.Rows {
+		totalAmount += row.Amount
+		totalFee += uc.transferUc.Fee(row.DestinationBankCode, row.Amount)
+	}
+	if uc.maxTotalAmount > 0 && totalAmount > uc.maxTotalAmount {
+		l.Error().
+			Int64("total_amount", totalAmount).
+			Int64("max_total_amount", uc.maxTotalAmount).
+			Msg("Batch total amount exceeds the limit")
+		return nil, pkgerror.BadRequest().SetMsg("Batch total amount exceeds the limit")
+	}
+
+	srcAccount, err := uc.accountRepo.Get(ctx, req.SourceAccount)
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", req.SourceAccount).
+			Msg("Failed to get account")
+		return nil, pkgerror.InternalServerError()
+	}
+	if !srcAccount.CanDebit() {
+		l.Error().
+			Str("account_number", srcAccount.AccountNumber).
+			Str("status", srcAccount.Status).
+			Msg("Account cannot be debited")
+		return nil, pkgerror.BadRequest().SetMsg("Account cannot be debited")
+	}
+	if !srcAccount.CanTransfer(totalAmount + totalFee) {
+		l.Error().
+			Int64("account_balance", srcAccount.Balance).
+			Int64("total_amount", totalAmount).
+			Int64("total_fee", totalFee).
+			Msg("Insufficient balance")
+		return nil, pkgerror.BadRequest().SetMsg("Insufficient balance")
+	}
+
+	batch := bulktransfer.Batch{
+		UUID:          uuid.New().String(),
+		Username:      userFromCtx.Username,
+		SourceAccount: srcAccount.AccountNumber,
+		TotalRows:     len(req.Rows),
+		TotalAmount:   totalAmount,
+		Status:        bulktransfer.StatusProcessing,
+	}
+
+	txUUIDs := uc.initiateRows(ctx, batch, req.Rows)
+
+	// The batch is stored once all of its rows are, a batch left processing always has its transactions.
+	// Rows initiated for a batch that could not be stored are cancelled,
+	// or expire with their hold when the process stops before.
+	err = uc.batchRepo.Create(ctx, batch)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to create batch")
+		for _, txUUID := range txUUIDs {
+			uc.failRow(ctx, txUUID, err)
+		}
+		return nil, pkgerror.InternalServerError()
+	}
+
+	uc.startBatch(context.WithoutCancel(ctx), batch)
+
+	return &CreateResponse{
+		UUID:        batch.UUID,
+		Status:      batch.Status,
+		TotalRows:   batch.TotalRows,
+		TotalAmount: batch.TotalAmount,
+	}, nil
+}
+
+func (uc *Usecase) GetBatch(ctx context.Context, req *GetRequest) (*BatchResponse, error) {
+	l := log.WithContext(ctx, "GetBatch")
+
+	batch, err := uc.getOwnedBatch(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+		"batch_uuid": batch.UUID,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", batch.UUID).
+			Msg("Failed to get batch transactions")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := &BatchResponse{
+		UUID:          batch.UUID,
+		SourceAccount: batch.SourceAccount,
+		Status:        batch.Status,
+		TotalRows:     batch.TotalRows,
+		TotalAmount:   batch.TotalAmount,
+		CreatedAt:     batch.CreatedAt,
+		CompletedAt:   batch.CompletedAt,
+		Rows:          make([]RowResponse, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		switch tx.Status {
+		case transaction.StatusCompleted:
+			res.Completed++
+		case transaction.StatusFailed, transaction.StatusCancelled, transaction.StatusExpired:
+			res.Failed++
+		default:
+			res.Pending++
+		}
+		res.Rows = append(res.Rows, RowResponse{
+			TransactionUUID:     tx.UUID,
+			DestinationBankCode: tx.DestinationBankCode,
+			DestinationAccount:  tx.DestinationAccount,
+			Amount:              tx.Amount,
+			Note:                tx.Note,
+			Status:              tx.Status,
+			Reason:              tx.StatusReason,
+		})
+	}
+	return res, nil
+}
+
+// Report returns the per-row outcome of a batch as a CSV file.
+func (uc *Usecase) Report(ctx context.Context, req *GetRequest) ([]byte, error) {
+	l := log.WithContext(ctx, "Report")
+
+	batch, err := uc.GetBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := writeReport(batch.Rows)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", batch.UUID).
+			Msg("Failed to write batch report")
+		return nil, pkgerror.InternalServerError()
+	}
+	return report, nil
+}
+
+// Resume processes the batches left processing, e.g. by a restart in the middle of a batch.
+// The transfers are claimed before they are sent, so a batch processed by another instance
+// at the same time is not transferred twice.
+func (uc *Usecase) Resume(ctx context.Context) error {
+	batches, err := uc.batchRepo.GetByStatus(ctx, bulktransfer.StatusProcessing)
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		uc.startBatch(ctx, batch)
+	}
+	uc.wg.Wait()
+	return nil
+}
+
+// startBatch processes the batch in the background unless this instance is already processing it.
+func (uc *Usecase) startBatch(ctx context.Context, batch bulktransfer.Batch) {
+	_, processing := uc.processing.LoadOrStore(batch.UUID, struct{}{})
+	if processing {
+		return
+	}
+
+	uc.wg.Add(1)
+	go func() {
+		defer uc.wg.Done()
+		defer uc.processing.Delete(batch.UUID)
+		uc.processBatch(ctx, batch)
+	}()
+}
+
+// initiateRows initiates the transfer of every row with bounded concurrency and returns their UUIDs.
+// A row that cannot be initiated is recorded as a failed transaction with the reason.
+func (uc *Usecase) initiateRows(ctx context.Context, batch bulktransfer.Batch, rows []Row) []string {
+	var (
+		mu      sync.Mutex
+		txUUIDs []string
+		wg      sync.WaitGroup
+	)
+	sem := make(chan struct{}, uc.concurrency)
+	for _, row := range rows {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := uc.transferUc.Initiate(ctx, &transfer.InitiateRequest{
+				SourceAccount:       batch.SourceAccount,
+				DestinationBankCode: row.DestinationBankCode,
+				DestinationAccount:  row.DestinationAccount,
+				Amount:              row.Amount,
+				Note:                row.Note,
+				BatchUUID:           batch.UUID,
+			})
+			if err != nil {
+				uc.createFailedRow(ctx, batch, row, err)
+				return
+			}
+			mu.Lock()
+			txUUIDs = append(txUUIDs, res.UUID)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return txUUIDs
+}
+
+// processBatch processes the initiated transfers of a batch with bounded concurrency
+// and marks the batch completed once none is left.
+func (uc *Usecase) processBatch(ctx context.Context, batch bulktransfer.Batch) {
+	l := log.WithContext(ctx, "processBatch")
+
+	txs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+		"batch_uuid": batch.UUID,
+		"status":     transaction.StatusInitiated,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", batch.UUID).
+			Msg("Failed to get batch transactions")
+		return
+	}
+
+	sem := make(chan struct{}, uc.concurrency)
+	var wg sync.WaitGroup
+	for _, tx := range txs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			uc.processRow(ctx, tx)
+		}()
+	}
+	wg.Wait()
+
+	batch.Status = bulktransfer.StatusCompleted
+	batch.CompletedAt = time.Now()
+	err = uc.batchRepo.Update(ctx, batch)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", batch.UUID).
+			Msg("Failed to update batch status")
+	}
+}
+
+// processRow processes the initiated transfer of one row.
+func (uc *Usecase) processRow(ctx context.Context, tx transaction.Transaction) {
+	_, err := uc.transferUc.Process(ctx, &transfer.ProcessRequest{
+		UUID:               tx.UUID,
+		SourceAccount:      tx.SourceAccount,
+		DestinationAccount: tx.DestinationAccount,
+		Amount:             tx.Amount,
+	})
+	if err != nil {
+		uc.failRow(ctx, tx.UUID, err)
+	}
+}
+
+// createFailedRow records a row that could not be initiated.
+func (uc *Usecase) createFailedRow(ctx context.Context, batch bulktransfer.Batch, row Row, reason error) {
+	l := log.WithContext(ctx, "createFailedRow")
+
+	err := uc.txRepo.Create(ctx, transaction.Transaction{
+		UUID:                uuid.New().String(),
+		SourceAccount:       batch.SourceAccount,
+		DestinationBankCode: row.DestinationBankCode,
+		DestinationAccount:  row.DestinationAccount,
+		TransactionType:     bulkTransferTransactionType,
+		BatchUUID:           batch.UUID,
+		Status:              transaction.StatusFailed,
+		StatusReason:        reason.Error(),
+		Amount:              row.Amount,
+		Username:            batch.Username,
+		Note:                row.Note,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("batch_uuid", batch.UUID).
+			Msg("Failed to create failed transaction")
+	}
+}
+
+// failRow cancels a row transaction that is still initiated with the reason
+// and releases the amount held for it.
+// Rows that already moved on, e.g. rejected interbank transfers, keep their status.
+func (uc *Usecase) failRow(ctx context.Context, txUUID string, reason error) {
+	l := log.WithContext(ctx, "failRow")
+
+	tx, err := uc.txRepo.GetByUUID(ctx, txUUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", txUUID).
+			Msg("Failed to get transaction")
+		return
+	}
+	if tx.Status != transaction.StatusInitiated {
+		return
+	}
+
+	err = tx.Transition(transaction.StatusCancelled, reason.Error())
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", txUUID).
+			Msg("Failed to update transaction status")
+		return
+	}
+
+	err = uc.accountRepo.ReleaseHold(ctx, txUUID)
+	if err != nil && !errors.Is(err, account.ErrHoldNotFound) {
+		l.Error().Err(err).
+			Str("transaction_id", txUUID).
+			Msg("Failed to release hold")
+	}
+}
+
+// getOwnedBatch retrieves a batch that belongs to the user in the context.
+func (uc *Usecase) getOwnedBatch(ctx context.Context, batchUUID string) (bulktransfer.Batch, error) {
+	l := log.WithContext(ctx, "getOwnedBatch")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return bulktransfer.Batch{}, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	batch, err := uc.batchRepo.GetByUUID(ctx, batchUUID)
+	if err != nil && errors.Is(err, bulktransfer.ErrNotFound) {
+		return bulktransfer.Batch{}, pkgerror.NotFound().SetMsg("Bulk transfer not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", batchUUID).
+			Msg("Failed to get batch")
+		return bulktransfer.Batch{}, pkgerror.InternalServerError()
+	}
+	if batch.Username != userFromCtx.Username {
+		return bulktransfer.Batch{}, pkgerror.NotFound().SetMsg("Bulk transfer not found")
+	}
+	return batch, nil
+}
@@ -0,0 +1,377 @@
+package bulktransfer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/bulktransfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	domaintransfer "go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+func TestCreate_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		cbsService  = cbs.NewMockService(t)
+		batchRepo   = bulktransfer.NewMockRepository(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, batchRepo, txRepo, accountRepo, transferUc)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{AccountNumber: "123", AvailableBalance: 10000000}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{AccountNumber: "456"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "789").
+		Return(account.Account{}, errors.New("account not found"))
+	batchRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(batch bulktransfer.Batch) bool {
+		return batch.Username == "johndoe" &&
+			batch.TotalRows == 2 &&
+			batch.TotalAmount == 3500000 &&
+			batch.Status == bulktransfer.StatusProcessing
+	})).Return(nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(2500000)).
+		Return(nil)
+	var initiated transaction.Transaction
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.DestinationAccount == "456" && tx.BatchUUID != "" && tx.Status == transaction.StatusInitiated
+	})).RunAndReturn(func(ctx context.Context, tx transaction.Transaction) error {
+		initiated = tx
+		return nil
+	})
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.DestinationAccount == "789" && tx.BatchUUID != "" && tx.Status == transaction.StatusFailed &&
+			tx.StatusReason != ""
+	})).Return(nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.MatchedBy(func(params map[string]any) bool {
+		return params["batch_uuid"] == initiated.BatchUUID && params["status"] == transaction.StatusInitiated
+	})).RunAndReturn(func(ctx context.Context, params map[string]any) ([]transaction.Transaction, error) {
+		return []transaction.Transaction{initiated}, nil
+	})
+	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, uuid string) (transaction.Transaction, error) {
+			return transaction.Transaction{
+				UUID:               uuid,
+				Status:             transaction.StatusInitiated,
+				Rail:               domaintransfer.RailInternal,
+				SourceAccount:      "123",
+				DestinationAccount: "456",
+				Amount:             2500000,
+			}, nil
+		})
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, uuid, reason string) (transaction.Transaction, error) {
+			return transaction.Transaction{
+				UUID:               uuid,
+				Status:             transaction.StatusPending,
+				Rail:               domaintransfer.RailInternal,
+				SourceAccount:      "123",
+				DestinationAccount: "456",
+				Amount:             2500000,
+			}, nil
+		})
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(2500000), mock.Anything).
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusCompleted
+	})).Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, mock.Anything).
+		Return(nil)
+	batchRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(batch bulktransfer.Batch) bool {
+		return batch.Status == bulktransfer.StatusCompleted && !batch.CompletedAt.IsZero()
+	})).Return(nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		SourceAccount: "123",
+		Rows: []Row{
+			{DestinationAccount: "456", Amount: 2500000},
+			{DestinationAccount: "789", Amount: 1000000},
+		},
+	})
+	uc.wg.Wait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, bulktransfer.StatusProcessing, res.Status)
+	assert.Equal(t, 2, res.TotalRows)
+	assert.Equal(t, int64(3500000), res.TotalAmount)
+}
+
+func TestCreate_TooManyRows(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		cbsService  = cbs.NewMockService(t)
+		batchRepo   = bulktransfer.NewMockRepository(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	cfg.BulkTransfer.MaxRows = 3
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, batchRepo, txRepo, accountRepo, transferUc)
+
+	log.Configure("test")
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		SourceAccount: "123",
+		Rows:          make([]Row, 4),
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Batch has too many rows"), err)
+}
+
+func TestCreate_InsufficientBalance(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		cbsService  = cbs.NewMockService(t)
+		batchRepo   = bulktransfer.NewMockRepository(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, batchRepo, txRepo, accountRepo, transferUc)
+
+	log.Configure("test")
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{AccountNumber: "123", AvailableBalance: 3000000}, nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		SourceAccount: "123",
+		Rows: []Row{
+			{DestinationAccount: "456", Amount: 2500000},
+			{DestinationAccount: "789", Amount: 1000000},
+		},
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Insufficient balance"), err)
+}
+
+func TestCreate_InsufficientBalanceForFees(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		cbsService  = cbs.NewMockService(t)
+		batchRepo   = bulktransfer.NewMockRepository(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	cfg.Interbank.BankCode = "999"
+	cfg.Interbank.BIFastFee = 2500
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, batchRepo, txRepo, accountRepo, transferUc)
+
+	log.Configure("test")
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{AccountNumber: "123", AvailableBalance: 3500000}, nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		SourceAccount: "123",
+		Rows: []Row{
+			{DestinationBankCode: "014", DestinationAccount: "456", Amount: 2500000},
+			{DestinationAccount: "789", Amount: 1000000},
+		},
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Insufficient balance"), err)
+}
+
+func TestResume(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		cfg         = new(config.Configs)
+		cbsService  = cbs.NewMockService(t)
+		batchRepo   = bulktransfer.NewMockRepository(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, batchRepo, txRepo, accountRepo, transferUc)
+
+	log.Configure("test")
+
+	initiated := transaction.Transaction{
+		UUID:               "tx-2",
+		BatchUUID:          "batch-123",
+		Status:             transaction.StatusInitiated,
+		Rail:               domaintransfer.RailInternal,
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             2500000,
+		CreatedAt:          time.Now(),
+	}
+	batchRepo.EXPECT().GetByStatus(mock.Anything, bulktransfer.StatusProcessing).
+		Return([]bulktransfer.Batch{{UUID: "batch-123", SourceAccount: "123", Status: bulktransfer.StatusProcessing}}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{
+		"batch_uuid": "batch-123",
+		"status":     transaction.StatusInitiated,
+	}).Return([]transaction.Transaction{initiated}, nil)
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-2").
+		Return(initiated, nil)
+	pending := initiated
+	pending.Status = transaction.StatusPending
+	txRepo.EXPECT().Claim(mock.Anything, "tx-2", mock.Anything).
+		Return(pending, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(2500000), mock.Anything).
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-2" && tx.Status == transaction.StatusCompleted
+	})).Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, "tx-2").
+		Return(nil)
+	batchRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(batch bulktransfer.Batch) bool {
+		return batch.UUID == "batch-123" && batch.Status == bulktransfer.StatusCompleted
+	})).Return(nil)
+
+	err := uc.Resume(ctx)
+
+	assert.NoError(t, err)
+}
+
+func TestGetBatch_NotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		cbsService  = cbs.NewMockService(t)
+		batchRepo   = bulktransfer.NewMockRepository(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, batchRepo, txRepo, accountRepo, transferUc)
+
+	log.Configure("test")
+
+	batchRepo.EXPECT().GetByUUID(mock.Anything, "batch-123").
+		Return(bulktransfer.Batch{UUID: "batch-123", Username: "janedoe"}, nil)
+
+	res, err := uc.GetBatch(ctx, &GetRequest{UUID: "batch-123"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Bulk transfer not found"), err)
+}
+
+func TestReport_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = new(config.Configs)
+		cbsService  = cbs.NewMockService(t)
+		batchRepo   = bulktransfer.NewMockRepository(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+	)
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, batchRepo, txRepo, accountRepo, transferUc)
+
+	log.Configure("test")
+
+	batchRepo.EXPECT().GetByUUID(mock.Anything, "batch-123").
+		Return(bulktransfer.Batch{
+			UUID:        "batch-123",
+			Username:    "johndoe",
+			TotalRows:   2,
+			TotalAmount: 3500000,
+			Status:      bulktransfer.StatusCompleted,
+			CompletedAt: time.Now(),
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"batch_uuid": "batch-123"}).
+		Return([]transaction.Transaction{
+			{UUID: "tx-1", DestinationAccount: "456", Amount: 2500000, Status: transaction.StatusCompleted},
+			{UUID: "tx-2", DestinationAccount: "789", Amount: 1000000, Status: transaction.StatusFailed, StatusReason: "Internal Server Error"},
+		}, nil)
+
+	report, err := uc.Report(ctx, &GetRequest{UUID: "batch-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "transaction_uuid,destination_bank_code,destination_account,amount,note,status,reason\n"+
+		"tx-1,,456,2500000,,completed,\n"+
+		"tx-2,,789,1000000,,failed,Internal Server Error\n", string(report))
+}
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("amount,destination_account,note\n" +
+		"2500000,456,rent\n" +
+		"1000000, 789,\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Row{
+		{DestinationAccount: "456", Amount: 2500000, Note: "rent"},
+		{DestinationAccount: "789", Amount: 1000000},
+	}, rows)
+}
+
+func TestParseCSV_InvalidAmount(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("destination_account,amount\n456,abc\n"))
+
+	assert.Nil(t, rows)
+	assert.EqualError(t, err, "csv line 2: amount must be a whole number")
+}
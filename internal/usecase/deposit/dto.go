@@ -0,0 +1,37 @@
+package deposit
+
+import "time"
+
+type ProductResponse struct {
+	TermMonths int   `json:"term_months"`
+	RateBps    int64 `json:"rate_bps"`
+}
+
+type PlaceRequest struct {
+	SourceAccount string `json:"source_account" validate:"required,accountnumber"`
+	Amount        int64  `json:"amount" validate:"required,gte=1"`
+	TermMonths    int    `json:"term_months" validate:"required,oneof=1 3 6 12"`
+	Instruction   string `json:"instruction" validate:"required,only=rollover credit"`
+}
+
+type GetRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type DepositResponse struct {
+	UUID           string    `json:"uuid"`
+	SourceAccount  string    `json:"source_account"`
+	DepositAccount string    `json:"deposit_account"`
+	Principal      int64     `json:"principal"`
+	TermMonths     int       `json:"term_months"`
+	RateBps        int64     `json:"rate_bps"`
+	StartDate      time.Time `json:"start_date"`
+	MaturityDate   time.Time `json:"maturity_date"`
+	Instruction    string    `json:"instruction"`
+	Status         string    `json:"status"`
+	Rollovers      int       `json:"rollovers"`
+	// The interest of the current term, or of the last term once matured.
+	GrossInterest int64 `json:"gross_interest"`
+	Tax           int64 `json:"tax"`
+	NetInterest   int64 `json:"net_interest"`
+}
@@ -0,0 +1,440 @@
+package deposit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	domaintransfer "go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+const systemDateLayout = "2006-01-02"
+
+// Usecase defines the use case for time deposits.
+type Usecase struct {
+	minAmount   int64
+	taxRateBps  int64
+	products    []deposit.Product
+	cbsSvc      cbs.Service
+	depositRepo deposit.Repository
+	depositSvc  deposit.Service
+	txRepo      transaction.Repository
+	accountRepo account.Repository
+	userRepo    user.Repository
+	transferSvc domaintransfer.Service
+	transferUc  *transfer.Usecase
+}
+
+func NewUsecase(
+	cfg *config.Configs,
+	cbsSvc cbs.Service,
+	depositRepo deposit.Repository,
+	depositSvc deposit.Service,
+	txRepo transaction.Repository,
+	accountRepo account.Repository,
+	userRepo user.Repository,
+	transferSvc domaintransfer.Service,
+	transferUc *transfer.Usecase,
+) *Usecase {
+	products := make([]deposit.Product, 0, len(cfg.Deposit.Products))
+	for _, p := range cfg.Deposit.Products {
+		products = append(products, deposit.Product{
+			TermMonths: p.TermMonths,
+			RateBps:    p.RateBps,
+		})
+	}
+	slices.SortFunc(products, func(a, b deposit.Product) int {
+		return a.TermMonths - b.TermMonths
+	})
+	return &Usecase{
+		minAmount:   cfg.Deposit.MinAmount,
+		taxRateBps:  cfg.Deposit.TaxRateBps,
+		products:    products,
+		cbsSvc:      cbsSvc,
+		depositRepo: depositRepo,
+		depositSvc:  depositSvc,
+		txRepo:      txRepo,
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		transferSvc: transferSvc,
+		transferUc:  transferUc,
+	}
+}
+
+// GetProducts returns the rate table of the deposit terms, shortest term first.
+func (uc *Usecase) GetProducts(ctx context.Context) ([]*ProductResponse, error) {
+	res := make([]*ProductResponse, 0, len(uc.products))
+	for _, p := range uc.products {
+		res = append(res, &ProductResponse{
+			TermMonths: p.TermMonths,
+			RateBps:    p.RateBps,
+		})
+	}
+	return res, nil
+}
+
+// Place moves the amount from a savings account of the user in the context to a new deposit account
+// for the term. The deposit starts on the core banking system date. It is created pending before
+// the principal is moved, so Mature settles it from its funding transfer even when Place is interrupted.
+func (uc *Usecase) Place(ctx context.Context, req *PlaceRequest) (*DepositResponse, error) {
+	l := log.WithContext(ctx, "Place")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	product, ok := uc.product(req.TermMonths)
+	if !ok {
+		return nil, pkgerror.BadRequest().SetMsg("Deposit term is not available")
+	}
+	if req.Amount < uc.minAmount {
+		return nil, pkgerror.BadRequest().SetMsg("Amount is below the minimum deposit of " + strconv.FormatInt(uc.minAmount, 10))
+	}
+
+	cbsStatus, err := cbs.GetStatus(ctx, uc.cbsSvc)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to Get CBS status")
+		return nil, pkgerror.InternalServerError()
+	}
+	startDate, err := time.Parse(systemDateLayout, cbsStatus.SystemDate)
+	if err != nil {
+		l.Error().Err(err).
+			Str("system_date", cbsStatus.SystemDate).
+			Msg("Invalid CBS system date")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	u, err := uc.userRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).
+			Str("username", userFromCtx.Username).
+			Msg("Failed to get user")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	src, err := uc.accountRepo.Get(ctx, req.SourceAccount)
+	if err != nil && errors.Is(err, account.ErrNotFound) {
+		return nil, pkgerror.NotFound().SetMsg("Account not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", req.SourceAccount).
+			Msg("Failed to get account")
+		return nil, pkgerror.InternalServerError()
+	}
+	if !src.OwnedBy(u.CIF) || src.IsClosed() {
+		return nil, pkgerror.NotFound().SetMsg("Account not found")
+	}
+	if src.Type != account.TypeSavings {
+		return nil, pkgerror.BadRequest().SetMsg("Deposits can only be placed from a savings account")
+	}
+
+	depositAccount, err := uc.accountRepo.Open(ctx, account.Account{
+		CIF:      u.CIF,
+		FullName: src.FullName,
+		Name:     "Time deposit " + strconv.Itoa(req.TermMonths) + " months",
+		Type:     account.TypeDeposit,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("cif", u.CIF).
+			Msg("Failed to open deposit account")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	d := deposit.Deposit{
+		UUID:                   uuid.New().String(),
+		Username:               userFromCtx.Username,
+		SourceAccount:          src.AccountNumber,
+		DepositAccount:         depositAccount.AccountNumber,
+		Principal:              req.Amount,
+		TermMonths:             product.TermMonths,
+		RateBps:                product.RateBps,
+		StartDate:              startDate,
+		MaturityDate:           deposit.MaturityDate(startDate, product.TermMonths),
+		Instruction:            req.Instruction,
+		Status:                 deposit.StatusPending,
+		FundingTransactionUUID: uuid.New().String(),
+	}
+	err = uc.depositRepo.Create(ctx, d)
+	if err != nil {
+		l.Error().Err(err).
+			Str("deposit_account", d.DepositAccount).
+			Msg("Failed to create deposit")
+		uc.closeAccount(ctx, depositAccount.AccountNumber)
+		return nil, pkgerror.InternalServerError()
+	}
+
+	err = uc.fund(ctx, d)
+	d = uc.settleFunding(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	return uc.newDepositResponse(d), nil
+}
+
+// GetDeposits returns the deposits of the user in the context.
+func (uc *Usecase) GetDeposits(ctx context.Context) ([]*DepositResponse, error) {
+	l := log.WithContext(ctx, "GetDeposits")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	deposits, err := uc.depositRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get deposits")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := make([]*DepositResponse, 0, len(deposits))
+	for _, d := range deposits {
+		res = append(res, uc.newDepositResponse(d))
+	}
+	return res, nil
+}
+
+// GetDeposit returns a deposit of the user in the context.
+func (uc *Usecase) GetDeposit(ctx context.Context, req *GetRequest) (*DepositResponse, error) {
+	l := log.WithContext(ctx, "GetDeposit")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	d, err := uc.depositRepo.GetByUUID(ctx, req.UUID)
+	if err != nil && errors.Is(err, deposit.ErrNotFound) {
+		return nil, pkgerror.NotFound().SetMsg("Deposit not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Failed to get deposit")
+		return nil, pkgerror.InternalServerError()
+	}
+	if d.Username != userFromCtx.Username {
+		return nil, pkgerror.NotFound().SetMsg("Deposit not found")
+	}
+	return uc.newDepositResponse(d), nil
+}
+
+// Mature settles the pending deposits whose funding transfer is settled and the deposits
+// maturing on or before the core banking system date. Nothing is settled while the core banking
+// system is not ready for transactions. Every step is safe to repeat, a failed deposit is retried
+// on the next run.
+func (uc *Usecase) Mature(ctx context.Context) error {
+	l := log.WithContext(ctx, "Mature")
+
+	cbsStatus, err := uc.cbsSvc.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if cbsStatus.NotReady() {
+		l.Info().
+			Bool("is_eod", cbsStatus.IsEOD).
+			Bool("is_stand_in", cbsStatus.IsStandIn).
+			Msg("CBS is not ready, deposit maturity is postponed")
+		return nil
+	}
+	systemDate, err := time.Parse(systemDateLayout, cbsStatus.SystemDate)
+	if err != nil {
+		return err
+	}
+
+	pending, err := uc.depositRepo.GetPending(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range pending {
+		uc.settleFunding(ctx, d)
+	}
+
+	due, err := uc.depositRepo.GetDue(ctx, systemDate)
+	if err != nil {
+		return err
+	}
+	for _, d := range due {
+		uc.mature(ctx, d)
+	}
+	return nil
+}
+
+// settleFunding activates a pending deposit once its funding transfer completed,
+// and fails it when the transfer did not move the principal or was never initiated.
+// It returns the deposit with its settled status.
+func (uc *Usecase) settleFunding(ctx context.Context, d deposit.Deposit) deposit.Deposit {
+	l := log.WithContext(ctx, "settleFunding")
+
+	txs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+		"uuid": d.FundingTransactionUUID,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", d.UUID).
+			Str("transaction_id", d.FundingTransactionUUID).
+			Msg("Failed to get funding transaction")
+		return d
+	}
+	status := transaction.StatusFailed
+	if len(txs) > 0 {
+		status = txs[0].Status
+	}
+	switch status {
+	case transaction.StatusCompleted:
+		d.Status = deposit.StatusActive
+	case transaction.StatusFailed, transaction.StatusCancelled, transaction.StatusExpired:
+		uc.closeAccount(ctx, d.DepositAccount)
+		d.Status = deposit.StatusFailed
+	default:
+		return d
+	}
+	uc.update(ctx, d)
+	return d
+}
+
+// mature credits the interest of a deposit net of tax, then either places the deposit again
+// or pays the principal back to the source account and closes the deposit account.
+// A deposit is rolled over at the current rate of its term, or paid back when the term is no longer offered.
+func (uc *Usecase) mature(ctx context.Context, d deposit.Deposit) {
+	l := log.WithContext(ctx, "mature")
+
+	reference := "DEP-" + d.UUID + "-" + strconv.Itoa(d.Rollovers)
+	product, rollover := uc.product(d.TermMonths)
+	rollover = rollover && d.Instruction == deposit.InstructionRollover
+
+	interestAccount := d.SourceAccount
+	if rollover {
+		interestAccount = d.DepositAccount
+	}
+	d.Accrue(uc.taxRateBps)
+	if d.GrossInterest > 0 {
+		err := uc.depositSvc.CreditInterest(ctx, interestAccount, d.GrossInterest, d.Tax, reference)
+		if err != nil {
+			l.Error().Err(err).
+				Str("uuid", d.UUID).
+				Msg("Failed to credit deposit interest")
+			return
+		}
+	}
+
+	if rollover {
+		d.Rollover(product.RateBps)
+		uc.update(ctx, d)
+		return
+	}
+
+	_, err := uc.transferSvc.Transfer(ctx, d.DepositAccount, d.SourceAccount, d.Principal, reference)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", d.UUID).
+			Msg("Failed to pay back deposit principal")
+		return
+	}
+	err = uc.accountRepo.Close(ctx, d.DepositAccount)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", d.UUID).
+			Str("account_number", d.DepositAccount).
+			Msg("Failed to close deposit account")
+		return
+	}
+	d.Status = deposit.StatusMatured
+	uc.update(ctx, d)
+}
+
+// fund moves the principal to the deposit account through the transfer usecase,
+// under the funding transaction UUID of the deposit.
+func (uc *Usecase) fund(ctx context.Context, d deposit.Deposit) error {
+	initRes, err := uc.transferUc.Initiate(ctx, &transfer.InitiateRequest{
+		UUID:               d.FundingTransactionUUID,
+		SourceAccount:      d.SourceAccount,
+		DestinationAccount: d.DepositAccount,
+		Amount:             d.Principal,
+		Note:               "Time deposit placement",
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.transferUc.Process(ctx, &transfer.ProcessRequest{
+		UUID:               initRes.UUID,
+		SourceAccount:      d.SourceAccount,
+		DestinationAccount: d.DepositAccount,
+		Amount:             d.Principal,
+	})
+	return err
+}
+
+func (uc *Usecase) product(termMonths int) (deposit.Product, bool) {
+	i := slices.IndexFunc(uc.products, func(p deposit.Product) bool {
+		return p.TermMonths == termMonths
+	})
+	if i < 0 {
+		return deposit.Product{}, false
+	}
+	return uc.products[i], true
+}
+
+// closeAccount closes a deposit account that holds no principal, logging the failure.
+func (uc *Usecase) closeAccount(ctx context.Context, accountNumber string) {
+	l := log.WithContext(ctx, "closeAccount")
+
+	err := uc.accountRepo.Close(ctx, accountNumber)
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", accountNumber).
+			Msg("Failed to close deposit account")
+	}
+}
+
+// update stores the deposit, logging the failure since the maturity job has no caller to report to.
+func (uc *Usecase) update(ctx context.Context, d deposit.Deposit) {
+	l := log.WithContext(ctx, "update")
+
+	err := uc.depositRepo.Update(ctx, d)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", d.UUID).
+			Msg("Failed to update deposit")
+	}
+}
+
+func (uc *Usecase) newDepositResponse(d deposit.Deposit) *DepositResponse {
+	if d.Status != deposit.StatusMatured {
+		d.Accrue(uc.taxRateBps)
+	}
+	return &DepositResponse{
+		UUID:           d.UUID,
+		SourceAccount:  d.SourceAccount,
+		DepositAccount: d.DepositAccount,
+		Principal:      d.Principal,
+		TermMonths:     d.TermMonths,
+		RateBps:        d.RateBps,
+		StartDate:      d.StartDate,
+		MaturityDate:   d.MaturityDate,
+		Instruction:    d.Instruction,
+		Status:         d.Status,
+		Rollovers:      d.Rollovers,
+		GrossInterest:  d.GrossInterest,
+		Tax:            d.Tax,
+		NetInterest:    d.NetInterest,
+	}
+}
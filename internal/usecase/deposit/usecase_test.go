@@ -0,0 +1,503 @@
+package deposit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	domaintransfer "go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+func newTestConfig() *config.Configs {
+	cfg := new(config.Configs)
+	cfg.Deposit.MinAmount = 1000000
+	cfg.Deposit.TaxRateBps = 2000
+	// The product type is internal to the config package, the table is filled in place.
+	cfg.Deposit.Products = slices.Grow(cfg.Deposit.Products, 2)[:2]
+	cfg.Deposit.Products[0].TermMonths, cfg.Deposit.Products[0].RateBps = 12, 400
+	cfg.Deposit.Products[1].TermMonths, cfg.Deposit.Products[1].RateBps = 3, 300
+	return cfg
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetProducts(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	res, err := uc.GetProducts(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*ProductResponse{
+		{TermMonths: 3, RateBps: 300},
+		{TermMonths: 12, RateBps: 400},
+	}, res)
+}
+
+func TestPlace_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{
+			CIF:              "0000000001",
+			AccountNumber:    "1000000001",
+			Type:             account.TypeSavings,
+			Status:           account.StatusActive,
+			AvailableBalance: 50000000,
+		}, nil)
+	accountRepo.EXPECT().Open(mock.Anything, mock.MatchedBy(func(acc account.Account) bool {
+		return acc.CIF == "0000000001" && acc.Type == account.TypeDeposit
+	})).Return(account.Account{AccountNumber: "1000000005", Type: account.TypeDeposit}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000005").
+		Return(account.Account{AccountNumber: "1000000005", Type: account.TypeDeposit, Status: account.StatusActive}, nil)
+	var fundingUUID string
+	depositRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(d deposit.Deposit) bool {
+		fundingUUID = d.FundingTransactionUUID
+		return d.Username == "johndoe" &&
+			d.DepositAccount == "1000000005" &&
+			d.RateBps == 300 &&
+			d.StartDate.Equal(date(2025, time.January, 31)) &&
+			d.MaturityDate.Equal(date(2025, time.April, 30)) &&
+			d.FundingTransactionUUID != "" &&
+			d.Status == deposit.StatusPending
+	})).Return(nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil).Once()
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "1000000001", mock.Anything, int64(10000000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == fundingUUID
+	})).Return(nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, uuid string) (transaction.Transaction, error) {
+			return transaction.Transaction{
+				UUID:               uuid,
+				Status:             transaction.StatusInitiated,
+				Rail:               domaintransfer.RailInternal,
+				SourceAccount:      "1000000001",
+				DestinationAccount: "1000000005",
+				Amount:             10000000,
+			}, nil
+		})
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, uuid, reason string) (transaction.Transaction, error) {
+			return transaction.Transaction{
+				UUID:               uuid,
+				Status:             transaction.StatusPending,
+				Rail:               domaintransfer.RailInternal,
+				SourceAccount:      "1000000001",
+				DestinationAccount: "1000000005",
+				Amount:             10000000,
+			}, nil
+		})
+	transferSvc.EXPECT().Transfer(mock.Anything, "1000000001", "1000000005", int64(10000000), mock.Anything).
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
+		Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, mock.Anything).
+		Return(nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, params map[string]any) ([]transaction.Transaction, error) {
+			return []transaction.Transaction{{UUID: fundingUUID, Status: transaction.StatusCompleted}}, nil
+		}).Once()
+	depositRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(d deposit.Deposit) bool {
+		return d.FundingTransactionUUID == fundingUUID && d.Status == deposit.StatusActive
+	})).Return(nil)
+
+	res, err := uc.Place(ctx, &PlaceRequest{
+		SourceAccount: "1000000001",
+		Amount:        10000000,
+		TermMonths:    3,
+		Instruction:   deposit.InstructionCredit,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, deposit.StatusActive, res.Status)
+	// 10.000.000 at 3% for 89 days, 20% tax withheld.
+	assert.Equal(t, int64(73150), res.GrossInterest)
+	assert.Equal(t, int64(14630), res.Tax)
+	assert.Equal(t, int64(58520), res.NetInterest)
+}
+
+func TestPlace_CreateDepositFailed(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{
+			CIF:              "0000000001",
+			AccountNumber:    "1000000001",
+			Type:             account.TypeSavings,
+			Status:           account.StatusActive,
+			AvailableBalance: 50000000,
+		}, nil)
+	accountRepo.EXPECT().Open(mock.Anything, mock.Anything).
+		Return(account.Account{AccountNumber: "1000000005", Type: account.TypeDeposit}, nil)
+	depositRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(errors.New("mock error"))
+	accountRepo.EXPECT().Close(mock.Anything, "1000000005").
+		Return(nil)
+
+	res, err := uc.Place(ctx, &PlaceRequest{
+		SourceAccount: "1000000001",
+		Amount:        10000000,
+		TermMonths:    3,
+		Instruction:   deposit.InstructionCredit,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.InternalServerError(), err)
+	transferSvc.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlace_FundingNotInitiated(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{
+			CIF:              "0000000001",
+			AccountNumber:    "1000000001",
+			Type:             account.TypeSavings,
+			Status:           account.StatusActive,
+			AvailableBalance: 50000000,
+		}, nil)
+	accountRepo.EXPECT().Open(mock.Anything, mock.Anything).
+		Return(account.Account{AccountNumber: "1000000005", Type: account.TypeDeposit}, nil)
+	depositRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(d deposit.Deposit) bool {
+		return d.Status == deposit.StatusPending
+	})).Return(nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000005").
+		Return(account.Account{}, errors.New("mock error"))
+	accountRepo.EXPECT().Close(mock.Anything, "1000000005").
+		Return(nil)
+	depositRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(d deposit.Deposit) bool {
+		return d.Status == deposit.StatusFailed
+	})).Return(nil)
+
+	res, err := uc.Place(ctx, &PlaceRequest{
+		SourceAccount: "1000000001",
+		Amount:        10000000,
+		TermMonths:    3,
+		Instruction:   deposit.InstructionCredit,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.InternalServerError(), err)
+}
+
+func TestPlace_NotSavings(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(account.Account{
+			CIF:           "0000000001",
+			AccountNumber: "1000000003",
+			Type:          account.TypePocket,
+			Status:        account.StatusActive,
+		}, nil)
+
+	res, err := uc.Place(ctx, &PlaceRequest{
+		SourceAccount: "1000000003",
+		Amount:        10000000,
+		TermMonths:    3,
+		Instruction:   deposit.InstructionCredit,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Deposits can only be placed from a savings account"), err)
+}
+
+func TestPlace_TermNotAvailable(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	res, err := uc.Place(ctx, &PlaceRequest{
+		SourceAccount: "1000000001",
+		Amount:        10000000,
+		TermMonths:    6,
+		Instruction:   deposit.InstructionCredit,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Deposit term is not available"), err)
+}
+
+func TestMature_Credit(t *testing.T) {
+	var (
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	log.Configure("test")
+
+	d := deposit.Deposit{
+		UUID:           "dep-1",
+		SourceAccount:  "1000000001",
+		DepositAccount: "1000000005",
+		Principal:      10000000,
+		TermMonths:     3,
+		RateBps:        300,
+		StartDate:      date(2025, time.January, 31),
+		MaturityDate:   date(2025, time.April, 30),
+		Instruction:    deposit.InstructionCredit,
+		Status:         deposit.StatusActive,
+	}
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-04-30"}, nil)
+	depositRepo.EXPECT().GetPending(mock.Anything).
+		Return(nil, nil)
+	depositRepo.EXPECT().GetDue(mock.Anything, date(2025, time.April, 30)).
+		Return([]deposit.Deposit{d}, nil)
+	depositSvc.EXPECT().CreditInterest(mock.Anything, "1000000001", int64(73150), int64(14630), "DEP-dep-1-0").
+		Return(nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "1000000005", "1000000001", int64(10000000), "DEP-dep-1-0").
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	accountRepo.EXPECT().Close(mock.Anything, "1000000005").
+		Return(nil)
+	depositRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(d deposit.Deposit) bool {
+		return d.Status == deposit.StatusMatured && d.NetInterest == 58520
+	})).Return(nil)
+
+	err := uc.Mature(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestMature_Rollover(t *testing.T) {
+	var (
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-05-02"}, nil)
+	depositRepo.EXPECT().GetPending(mock.Anything).
+		Return([]deposit.Deposit{{
+			UUID:                   "dep-2",
+			FundingTransactionUUID: "tx-2",
+			DepositAccount:         "1000000006",
+			Status:                 deposit.StatusPending,
+		}}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": "tx-2"}).
+		Return([]transaction.Transaction{{UUID: "tx-2", Status: transaction.StatusFailed}}, nil)
+	accountRepo.EXPECT().Close(mock.Anything, "1000000006").
+		Return(nil)
+	depositRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(d deposit.Deposit) bool {
+		return d.UUID == "dep-2" && d.Status == deposit.StatusFailed
+	})).Return(nil)
+	depositRepo.EXPECT().GetDue(mock.Anything, date(2025, time.May, 2)).
+		Return([]deposit.Deposit{{
+			UUID:           "dep-1",
+			SourceAccount:  "1000000001",
+			DepositAccount: "1000000005",
+			Principal:      10000000,
+			TermMonths:     3,
+			RateBps:        250,
+			StartDate:      date(2025, time.January, 31),
+			MaturityDate:   date(2025, time.April, 30),
+			Instruction:    deposit.InstructionRollover,
+			Status:         deposit.StatusActive,
+		}}, nil)
+	depositSvc.EXPECT().CreditInterest(mock.Anything, "1000000005", int64(60958), int64(12191), "DEP-dep-1-0").
+		Return(nil)
+	depositRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(d deposit.Deposit) bool {
+		return d.UUID == "dep-1" &&
+			d.Status == deposit.StatusActive &&
+			d.Principal == 10048767 &&
+			d.RateBps == 300 &&
+			d.StartDate.Equal(date(2025, time.April, 30)) &&
+			d.MaturityDate.Equal(date(2025, time.July, 30)) &&
+			d.Rollovers == 1
+	})).Return(nil)
+
+	err := uc.Mature(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestMature_CbsNotReady(t *testing.T) {
+	var (
+		cfg         = newTestConfig()
+		cbsService  = cbs.NewMockService(t)
+		depositRepo = deposit.NewMockRepository(t)
+		depositSvc  = deposit.NewMockService(t)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		userRepo    = user.NewMockRepository(t)
+		transferSvc = domaintransfer.NewMockService(t)
+		transferUc  = transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+			domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+			standin.NewMockRepository(t), notification.NewMockService(t), uow.NewMockUnitOfWork(t))
+		uc = NewUsecase(cfg, cbsService, depositRepo, depositSvc, txRepo, accountRepo, userRepo, transferSvc, transferUc)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{IsEOD: true}, nil)
+
+	err := uc.Mature(context.Background())
+
+	assert.NoError(t, err)
+}
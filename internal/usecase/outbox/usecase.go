@@ -0,0 +1,84 @@
+package outbox
+
+import (
+	"context"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+)
+
+const defaultBatchSize = 100
+
+// Usecase defines the use case for relaying the outbox events to the broker.
+type Usecase struct {
+	batchSize  int
+	outboxRepo outbox.Repository
+	publisher  outbox.Publisher
+	uow        uow.UnitOfWork
+}
+
+func NewUsecase(cfg *config.Configs, outboxRepo outbox.Repository, publisher outbox.Publisher, uow uow.UnitOfWork) *Usecase {
+	batchSize := cfg.Outbox.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &Usecase{
+		batchSize:  batchSize,
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		uow:        uow,
+	}
+}
+
+// Relay publishes the unpublished events, oldest first.
+// An event is marked published only after the broker accepted it, so delivery is at least once.
+// When an event fails, the later events of the same aggregate are held back
+// until the next run, which keeps the events of each aggregate in order.
+// The job runs in every replica, the relay lock lets one of them relay at a time
+// and the others skip the run.
+func (uc *Usecase) Relay(ctx context.Context) error {
+	return uc.uow.Do(ctx, func(ctx context.Context) error {
+		locked, err := uc.outboxRepo.Lock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+		return uc.relay(ctx)
+	})
+}
+
+// relay publishes a batch of events while holding the relay lock.
+func (uc *Usecase) relay(ctx context.Context) error {
+	l := log.WithContext(ctx, "relay")
+
+	events, err := uc.outboxRepo.GetUnpublished(ctx, uc.batchSize)
+	if err != nil {
+		return err
+	}
+
+	held := make(map[string]bool)
+	for _, e := range events {
+		key := e.AggregateType + ":" + e.AggregateUUID
+		if held[key] {
+			continue
+		}
+		err = uc.publisher.Publish(ctx, e)
+		if err != nil {
+			l.Error().Err(err).
+				Int64("event_id", e.ID).
+				Str("aggregate_uuid", e.AggregateUUID).
+				Msg("Failed to publish event")
+			held[key] = true
+			continue
+		}
+		err = uc.outboxRepo.MarkPublished(ctx, e.ID)
+		if err != nil {
+			l.Error().Err(err).
+				Int64("event_id", e.ID).
+				Msg("Failed to mark event published")
+			held[key] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/infra/broker"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+)
+
+func testEvents() []outbox.Event {
+	return []outbox.Event{
+		{ID: 1, AggregateType: outbox.AggregateTransaction, AggregateUUID: "tx-1", Type: outbox.EventTransactionCreated},
+		{ID: 2, AggregateType: outbox.AggregateTransaction, AggregateUUID: "tx-2", Type: outbox.EventTransactionCreated},
+		{ID: 3, AggregateType: outbox.AggregateTransaction, AggregateUUID: "tx-1", Type: outbox.EventTransactionUpdated},
+	}
+}
+
+func TestRelay_Success(t *testing.T) {
+	var (
+		outboxRepo = outbox.NewMockRepository(t)
+		publisher  = broker.NewMemoryBroker()
+		unitOfWork = uow.NewMockUnitOfWork(t)
+		uc         = NewUsecase(new(config.Configs), outboxRepo, publisher, unitOfWork)
+	)
+
+	log.Configure("test")
+
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	outboxRepo.EXPECT().Lock(mock.Anything).
+		Return(true, nil)
+	outboxRepo.EXPECT().GetUnpublished(mock.Anything, defaultBatchSize).
+		Return(testEvents(), nil)
+	outboxRepo.EXPECT().MarkPublished(mock.Anything, mock.Anything).
+		Return(nil).Times(3)
+
+	err := uc.Relay(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, testEvents(), publisher.Events())
+}
+
+func TestRelay_FailedEventHoldsBackItsAggregate(t *testing.T) {
+	var (
+		outboxRepo = outbox.NewMockRepository(t)
+		publisher  = outbox.NewMockPublisher(t)
+		unitOfWork = uow.NewMockUnitOfWork(t)
+		uc         = NewUsecase(new(config.Configs), outboxRepo, publisher, unitOfWork)
+	)
+
+	log.Configure("test")
+
+	events := testEvents()
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	outboxRepo.EXPECT().Lock(mock.Anything).
+		Return(true, nil)
+	outboxRepo.EXPECT().GetUnpublished(mock.Anything, defaultBatchSize).
+		Return(events, nil)
+	publisher.EXPECT().Publish(mock.Anything, events[0]).
+		Return(errors.New("broker down"))
+	publisher.EXPECT().Publish(mock.Anything, events[1]).
+		Return(nil)
+	outboxRepo.EXPECT().MarkPublished(mock.Anything, int64(2)).
+		Return(nil)
+
+	err := uc.Relay(context.Background())
+
+	assert.NoError(t, err)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, events[2])
+}
+
+func TestRelay_GetUnpublishedFailed(t *testing.T) {
+	var (
+		outboxRepo = outbox.NewMockRepository(t)
+		publisher  = outbox.NewMockPublisher(t)
+		unitOfWork = uow.NewMockUnitOfWork(t)
+		uc         = NewUsecase(new(config.Configs), outboxRepo, publisher, unitOfWork)
+	)
+
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	outboxRepo.EXPECT().Lock(mock.Anything).
+		Return(true, nil)
+	outboxRepo.EXPECT().GetUnpublished(mock.Anything, defaultBatchSize).
+		Return(nil, errors.New("db error"))
+
+	err := uc.Relay(context.Background())
+
+	assert.EqualError(t, err, "db error")
+}
+
+func TestRelay_LockedByAnotherRelay(t *testing.T) {
+	var (
+		outboxRepo = outbox.NewMockRepository(t)
+		publisher  = outbox.NewMockPublisher(t)
+		unitOfWork = uow.NewMockUnitOfWork(t)
+		uc         = NewUsecase(new(config.Configs), outboxRepo, publisher, unitOfWork)
+	)
+
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	outboxRepo.EXPECT().Lock(mock.Anything).
+		Return(false, nil)
+
+	err := uc.Relay(context.Background())
+
+	assert.NoError(t, err)
+	outboxRepo.AssertNotCalled(t, "GetUnpublished", mock.Anything, mock.Anything)
+}
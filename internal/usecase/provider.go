@@ -2,7 +2,16 @@ package usecase
 
 import (
 	"github.com/google/wire"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/account"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/authentication"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/bulktransfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/deposit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/outbox"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/reversal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/savingsgoal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/standingorder"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/system"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/tapmoney"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transaction"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
@@ -15,4 +24,13 @@ var ProviderSet = wire.NewSet(
 	transfer.NewUsecase,
 	user.NewUsecase,
 	transaction.NewUsecase,
+	standingorder.NewUsecase,
+	bulktransfer.NewUsecase,
+	beneficiary.NewUsecase,
+	outbox.NewUsecase,
+	reversal.NewUsecase,
+	account.NewUsecase,
+	savingsgoal.NewUsecase,
+	deposit.NewUsecase,
+	system.NewUsecase,
 )
@@ -0,0 +1,16 @@
+package reversal
+
+type ReverseRequest struct {
+	UUID  string `param:"uuid" json:"uuid" validate:"required,uuid"`
+	Cause string `json:"cause" validate:"required,only=bank_error customer_request"`
+	Note  string `json:"note" validate:"required,max=255"`
+}
+
+type ReverseResponse struct {
+	UUID         string `json:"uuid"`
+	OriginalUUID string `json:"original_uuid"`
+	Status       string `json:"status"`
+	StatusReason string `json:"status_reason,omitempty"`
+	Amount       int64  `json:"amount"`
+	RefundedFee  int64  `json:"refunded_fee"`
+}
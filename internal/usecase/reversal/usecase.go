@@ -0,0 +1,373 @@
+package reversal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/audit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+)
+
+const (
+	reversalTransactionType = "reversal"
+	transferTransactionType = "transfer"
+	tapMoneyTransactionType = "tapmoney"
+	// reconcileActor is the actor of the reversals completed by Reconcile in the audit trail.
+	reconcileActor = "system"
+)
+
+// Status reasons of reversals.
+const (
+	reasonReversing = "Reversal is being processed"
+	reasonRefused   = "Reversal was refused"
+	reasonFailed    = "Reversal could not be processed"
+)
+
+// Usecase defines the use case for reversing completed transactions.
+type Usecase struct {
+	reconcileAfter time.Duration
+	txRepo         transaction.Repository
+	auditRepo      audit.Repository
+	uow            uow.UnitOfWork
+	transferSvc    transfer.Service
+	paymentSvc     payment.Service
+}
+
+func NewUsecase(
+	cfg *config.Configs,
+	txRepo transaction.Repository,
+	auditRepo audit.Repository,
+	uow uow.UnitOfWork,
+	transferSvc transfer.Service,
+	paymentSvc payment.Service,
+) *Usecase {
+	return &Usecase{
+		reconcileAfter: cfg.Transaction.ReconcileAfter,
+		txRepo:         txRepo,
+		auditRepo:      auditRepo,
+		uow:            uow,
+		transferSvc:    transferSvc,
+		paymentSvc:     paymentSvc,
+	}
+}
+
+// Reverse returns the money of a completed transfer or TapMoney payment through a linked
+// reversal transaction, on behalf of the ops user in the context.
+// The fee is refunded depending on the cause. Reversing a transaction again returns
+// its existing reversal, so the money is returned at most once.
+func (uc *Usecase) Reverse(ctx context.Context, req *ReverseRequest) (*ReverseResponse, error) {
+	l := log.WithContext(ctx, "Reverse")
+
+	actor, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	original, err := uc.txRepo.GetByUUID(ctx, req.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Failed to get transaction")
+		return nil, pkgerror.NotFound().SetMsg("Transaction not found")
+	}
+	if original.TransactionType != transferTransactionType && original.TransactionType != tapMoneyTransactionType {
+		return nil, pkgerror.BadRequest().SetMsg("Transaction cannot be reversed")
+	}
+
+	rv, found, err := uc.getActiveReversal(ctx, original.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", original.UUID).
+			Msg("Failed to get reversal")
+		return nil, pkgerror.InternalServerError()
+	}
+	if found && rv.Status != transaction.StatusInitiated {
+		return newReverseResponse(rv), nil
+	}
+	if !found {
+		if original.Status != transaction.StatusCompleted {
+			return nil, pkgerror.Conflict().SetMsg("Only completed transactions can be reversed")
+		}
+		rv, err = uc.createReversal(ctx, original, req)
+		if err != nil && errors.Is(err, transaction.ErrDuplicate) {
+			// Someone else reversed the transaction in the meantime.
+			rv, _, err = uc.getActiveReversal(ctx, original.UUID)
+			if err == nil {
+				return newReverseResponse(rv), nil
+			}
+		}
+		if err != nil {
+			l.Error().Err(err).
+				Str("uuid", original.UUID).
+				Msg("Failed to create reversal")
+			return nil, pkgerror.InternalServerError()
+		}
+	}
+
+	rv, err = uc.txRepo.Claim(ctx, rv.UUID, reasonReversing)
+	if err != nil && errors.Is(err, transaction.ErrConflict) {
+		return nil, pkgerror.Conflict().SetMsg("Reversal is already in progress")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("reversal_uuid", rv.UUID).
+			Msg("Failed to claim reversal")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	reference, err := uc.reverse(ctx, original, rv)
+	if err != nil && isOutcomeUnknown(err) {
+		l.Error().Err(err).
+			Str("reversal_uuid", rv.UUID).
+			Msg("Reversal outcome is unknown, waiting for reconciliation")
+		return newReverseResponse(rv), nil
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("reversal_uuid", rv.UUID).
+			Msg("Failed to reverse transaction")
+		reason := reasonFailed
+		if errors.Is(err, errRefused) {
+			reason = reasonRefused
+		}
+		uc.fail(ctx, actor.Username, original, rv, reason)
+		if errors.Is(err, errRefused) {
+			return nil, pkgerror.BadRequest().SetMsg(reasonRefused)
+		}
+		return nil, pkgerror.InternalServerError()
+	}
+
+	rv, err = uc.complete(ctx, actor.Username, original, rv, reference, reversalDetail(rv, req.Cause))
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", original.UUID).
+			Str("reversal_uuid", rv.UUID).
+			Msg("Failed to record reversal")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return newReverseResponse(rv), nil
+}
+
+// Reconcile settles the reversals that stayed pending because their outcome was unknown
+// or could not be recorded. A reversal is sent again under the same reference, which the core
+// banking system and the payment gateway deduplicate, so its money is returned at most once.
+func (uc *Usecase) Reconcile(ctx context.Context) error {
+	l := log.WithContext(ctx, "Reconcile")
+
+	rvs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+		"transaction_type": reversalTransactionType,
+		"status":           transaction.StatusPending,
+	})
+	if err != nil {
+		return err
+	}
+
+	settleBefore := time.Now().Add(-uc.reconcileAfter)
+	for _, rv := range rvs {
+		if rv.UpdatedAt.After(settleBefore) {
+			continue
+		}
+		err = uc.reconcile(ctx, rv)
+		if err != nil {
+			l.Error().Err(err).
+				Str("reversal_uuid", rv.UUID).
+				Msg("Failed to reconcile reversal")
+		}
+	}
+	return nil
+}
+
+// reconcile sends a pending reversal again and records its outcome.
+func (uc *Usecase) reconcile(ctx context.Context, rv transaction.Transaction) error {
+	original, err := uc.txRepo.GetByUUID(ctx, rv.ReversalOf)
+	if err != nil {
+		return err
+	}
+
+	reference, err := uc.reverse(ctx, original, rv)
+	if err != nil && isOutcomeUnknown(err) {
+		return nil
+	}
+	if err != nil {
+		reason := reasonFailed
+		if errors.Is(err, errRefused) {
+			reason = reasonRefused
+		}
+		uc.fail(ctx, reconcileActor, original, rv, reason)
+		return nil
+	}
+
+	_, err = uc.complete(ctx, reconcileActor, original, rv, reference,
+		fmt.Sprintf("reversal %s reconciled, amount %d, refunded fee %d: %s", rv.UUID, rv.Amount, rv.Fee, rv.Note))
+	return err
+}
+
+// complete records a reversal that returned the money and marks the original transaction reversed.
+func (uc *Usecase) complete(ctx context.Context, actor string, original, rv transaction.Transaction, reference, detail string) (transaction.Transaction, error) {
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		rv.TransactionReference = reference
+		err := rv.Transition(transaction.StatusCompleted, "")
+		if err != nil {
+			return err
+		}
+		err = uc.txRepo.Update(ctx, rv)
+		if err != nil {
+			return err
+		}
+		err = original.Transition(transaction.StatusReversed, rv.Note)
+		if err != nil {
+			return err
+		}
+		err = uc.txRepo.Update(ctx, original)
+		if err != nil {
+			return err
+		}
+		return uc.auditRepo.Create(ctx, audit.Entry{
+			Actor:        actor,
+			Action:       audit.ActionReversalCompleted,
+			ResourceType: audit.ResourceTransaction,
+			ResourceUUID: original.UUID,
+			Detail:       detail,
+		})
+	})
+	return rv, err
+}
+
+// errRefused is returned by reverse when the core banking system or the biller refused the reversal.
+var errRefused = errors.New("reversal refused")
+
+// reverse asks the core banking system or the payment gateway to return the money
+// and returns the reference of the reversal.
+func (uc *Usecase) reverse(ctx context.Context, original, rv transaction.Transaction) (string, error) {
+	if original.TransactionType == tapMoneyTransactionType {
+		res, err := uc.paymentSvc.Reverse(ctx, payment.Reversal{
+			PaymentID:   original.PaymentID,
+			Amount:      rv.Amount,
+			Fee:         rv.Fee,
+			ReferenceID: rv.UUID,
+		})
+		if err != nil {
+			return "", err
+		}
+		if res.Status != "success" {
+			return "", errRefused
+		}
+		return res.ID, nil
+	}
+
+	res, err := uc.transferSvc.Reverse(ctx, transfer.Reversal{
+		TransactionReference: original.TransactionReference,
+		SourceAccount:        original.SourceAccount,
+		DestinationAccount:   original.DestinationAccount,
+		Amount:               rv.Amount,
+		Fee:                  rv.Fee,
+		Remark:               fmt.Sprintf("REV %s BNKKRD %s", original.UUID, rv.UUID),
+	})
+	if err != nil {
+		return "", err
+	}
+	if res.Failed() {
+		return "", errRefused
+	}
+	return res.TransactionReference, nil
+}
+
+// createReversal records a reversal of the original transaction, crediting its source account.
+func (uc *Usecase) createReversal(ctx context.Context, original transaction.Transaction, req *ReverseRequest) (transaction.Transaction, error) {
+	rv := transaction.Transaction{
+		UUID:               uuid.New().String(),
+		TransactionType:    reversalTransactionType,
+		ReversalOf:         original.UUID,
+		SourceAccount:      original.DestinationAccount,
+		DestinationAccount: original.SourceAccount,
+		Rail:               original.Rail,
+		Status:             transaction.StatusInitiated,
+		Amount:             original.Amount,
+		Fee:                original.RefundedFee(req.Cause),
+		Note:               req.Note,
+		Username:           original.Username,
+	}
+	err := uc.txRepo.Create(ctx, rv)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+	return rv, nil
+}
+
+// fail records a reversal that was refused or failed before any money moved.
+func (uc *Usecase) fail(ctx context.Context, actor string, original, rv transaction.Transaction, reason string) {
+	l := log.WithContext(ctx, "fail")
+
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		err := rv.Transition(transaction.StatusFailed, reason)
+		if err != nil {
+			return err
+		}
+		err = uc.txRepo.Update(ctx, rv)
+		if err != nil {
+			return err
+		}
+		return uc.auditRepo.Create(ctx, audit.Entry{
+			Actor:        actor,
+			Action:       audit.ActionReversalFailed,
+			ResourceType: audit.ResourceTransaction,
+			ResourceUUID: original.UUID,
+			Detail:       reason,
+		})
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("reversal_uuid", rv.UUID).
+			Msg("Failed to record failed reversal")
+	}
+}
+
+// getActiveReversal retrieves the reversal of a transaction that has not failed.
+func (uc *Usecase) getActiveReversal(ctx context.Context, originalUUID string) (transaction.Transaction, bool, error) {
+	txs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+		"reversal_of": originalUUID,
+	})
+	if err != nil {
+		return transaction.Transaction{}, false, err
+	}
+	for _, tx := range txs {
+		if tx.Status != transaction.StatusFailed {
+			return tx, true, nil
+		}
+	}
+	return transaction.Transaction{}, false, nil
+}
+
+func newReverseResponse(rv transaction.Transaction) *ReverseResponse {
+	return &ReverseResponse{
+		UUID:         rv.UUID,
+		OriginalUUID: rv.ReversalOf,
+		Status:       rv.Status,
+		StatusReason: rv.StatusReason,
+		Amount:       rv.Amount,
+		RefundedFee:  rv.Fee,
+	}
+}
+
+// reversalDetail describes a completed reversal for the audit trail.
+func reversalDetail(rv transaction.Transaction, cause string) string {
+	return fmt.Sprintf("reversal %s, cause %s, amount %d, refunded fee %d: %s",
+		rv.UUID, cause, rv.Amount, rv.Fee, rv.Note)
+}
+
+// isOutcomeUnknown checks if the reversal may or may not have been executed.
+func isOutcomeUnknown(err error) bool {
+	return transfer.IsOutcomeUnknown(err) || errors.Is(err, payment.ErrOutcomeUnknown)
+}
@@ -0,0 +1,432 @@
+package reversal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/audit"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+)
+
+func TestReverse_TransferSuccess(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "ops-jane",
+			Role:     user.RoleOps,
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		unitOfWork  = uow.NewMockUnitOfWork(t)
+		transferSvc = transfer.NewMockService(t)
+		paymentSvc  = payment.NewMockService(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, auditRepo, unitOfWork, transferSvc, paymentSvc)
+	)
+
+	log.Configure("test")
+
+	original := transaction.Transaction{
+		UUID:                 "7b0f6f0e-3c1d-4f51-9d55-0f1b8d1c2a10",
+		TransactionType:      transferTransactionType,
+		TransactionReference: "ref-123",
+		SourceAccount:        "123",
+		DestinationAccount:   "456",
+		Status:               transaction.StatusCompleted,
+		Amount:               100000,
+		Fee:                  2500,
+		Username:             "johndoe",
+	}
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, original.UUID).
+		Return(original, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"reversal_of": original.UUID}).
+		Return(nil, nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(rv transaction.Transaction) bool {
+		return rv.ReversalOf == original.UUID &&
+			rv.SourceAccount == "456" &&
+			rv.DestinationAccount == "123" &&
+			rv.Amount == 100000 &&
+			rv.Fee == 2500
+	})).Return(nil)
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, reasonReversing).
+		RunAndReturn(func(ctx context.Context, uuid, reason string) (transaction.Transaction, error) {
+			return transaction.Transaction{
+				UUID:            uuid,
+				TransactionType: reversalTransactionType,
+				ReversalOf:      original.UUID,
+				Status:          transaction.StatusPending,
+				StatusReason:    reason,
+				Amount:          100000,
+				Fee:             2500,
+			}, nil
+		})
+	transferSvc.EXPECT().Reverse(mock.Anything, mock.MatchedBy(func(rv transfer.Reversal) bool {
+		return rv.TransactionReference == "ref-123" && rv.Amount == 100000 && rv.Fee == 2500
+	})).Return(transfer.Transfer{Status: transfer.StatusSuccess, TransactionReference: "rev-123"}, nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.TransactionType == reversalTransactionType && tx.Status == transaction.StatusCompleted
+	})).Return(nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == original.UUID && tx.Status == transaction.StatusReversed
+	})).Return(nil)
+	auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e audit.Entry) bool {
+		return e.Actor == "ops-jane" &&
+			e.Action == audit.ActionReversalCompleted &&
+			e.ResourceUUID == original.UUID
+	})).Return(nil)
+
+	res, err := uc.Reverse(ctx, &ReverseRequest{
+		UUID:  original.UUID,
+		Cause: transaction.ReversalBankError,
+		Note:  "CBS posted the transfer twice",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusCompleted, res.Status)
+	assert.Equal(t, original.UUID, res.OriginalUUID)
+	assert.Equal(t, int64(2500), res.RefundedFee)
+}
+
+func TestReverse_TapMoneyKeepsFee(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "ops-jane",
+			Role:     user.RoleOps,
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		unitOfWork  = uow.NewMockUnitOfWork(t)
+		transferSvc = transfer.NewMockService(t)
+		paymentSvc  = payment.NewMockService(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, auditRepo, unitOfWork, transferSvc, paymentSvc)
+	)
+
+	log.Configure("test")
+
+	original := transaction.Transaction{
+		UUID:               "7b0f6f0e-3c1d-4f51-9d55-0f1b8d1c2a10",
+		TransactionType:    tapMoneyTransactionType,
+		PaymentID:          "pay-123",
+		SourceAccount:      "123",
+		DestinationAccount: "6013501000500719",
+		Status:             transaction.StatusCompleted,
+		Amount:             100000,
+		Fee:                2500,
+		Username:           "johndoe",
+	}
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, original.UUID).
+		Return(original, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(rv transaction.Transaction) bool {
+		return rv.Fee == 0
+	})).Return(nil)
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, reasonReversing).
+		Return(transaction.Transaction{
+			UUID:       "rev-uuid",
+			ReversalOf: original.UUID,
+			Status:     transaction.StatusPending,
+			Amount:     100000,
+		}, nil)
+	paymentSvc.EXPECT().Reverse(mock.Anything, payment.Reversal{
+		PaymentID:   "pay-123",
+		Amount:      100000,
+		ReferenceID: "rev-uuid",
+	}).Return(payment.Payment{ID: "refund-123", Status: "success"}, nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
+		Return(nil).Times(2)
+	auditRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := uc.Reverse(ctx, &ReverseRequest{
+		UUID:  original.UUID,
+		Cause: transaction.ReversalCustomerRequest,
+		Note:  "Topped up the wrong card",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusCompleted, res.Status)
+	assert.Equal(t, int64(0), res.RefundedFee)
+}
+
+func TestReverse_AlreadyReversed(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "ops-jane",
+			Role:     user.RoleOps,
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		unitOfWork  = uow.NewMockUnitOfWork(t)
+		transferSvc = transfer.NewMockService(t)
+		paymentSvc  = payment.NewMockService(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, auditRepo, unitOfWork, transferSvc, paymentSvc)
+	)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:            "tx-123",
+			TransactionType: transferTransactionType,
+			Status:          transaction.StatusReversed,
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return([]transaction.Transaction{
+			{UUID: "rev-failed", ReversalOf: "tx-123", Status: transaction.StatusFailed},
+			{UUID: "rev-done", ReversalOf: "tx-123", Status: transaction.StatusCompleted, Amount: 100000},
+		}, nil)
+
+	res, err := uc.Reverse(ctx, &ReverseRequest{
+		UUID:  "tx-123",
+		Cause: transaction.ReversalBankError,
+		Note:  "Retry",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rev-done", res.UUID)
+	assert.Equal(t, transaction.StatusCompleted, res.Status)
+}
+
+func TestReverse_NotCompleted(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "ops-jane",
+			Role:     user.RoleOps,
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		unitOfWork  = uow.NewMockUnitOfWork(t)
+		transferSvc = transfer.NewMockService(t)
+		paymentSvc  = payment.NewMockService(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, auditRepo, unitOfWork, transferSvc, paymentSvc)
+	)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:            "tx-123",
+			TransactionType: transferTransactionType,
+			Status:          transaction.StatusPending,
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+
+	res, err := uc.Reverse(ctx, &ReverseRequest{
+		UUID:  "tx-123",
+		Cause: transaction.ReversalBankError,
+		Note:  "Not settled yet",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Only completed transactions can be reversed"), err)
+}
+
+func TestReverse_Refused(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "ops-jane",
+			Role:     user.RoleOps,
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		unitOfWork  = uow.NewMockUnitOfWork(t)
+		transferSvc = transfer.NewMockService(t)
+		paymentSvc  = payment.NewMockService(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, auditRepo, unitOfWork, transferSvc, paymentSvc)
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:                 "tx-123",
+			TransactionType:      transferTransactionType,
+			TransactionReference: "ref-123",
+			Status:               transaction.StatusCompleted,
+			Amount:               100000,
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil)
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, reasonReversing).
+		Return(transaction.Transaction{
+			UUID:       "rev-uuid",
+			ReversalOf: "tx-123",
+			Status:     transaction.StatusPending,
+		}, nil)
+	transferSvc.EXPECT().Reverse(mock.Anything, mock.Anything).
+		Return(transfer.Transfer{Status: transfer.StatusFailed}, nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "rev-uuid" && tx.Status == transaction.StatusFailed
+	})).Return(nil)
+	auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e audit.Entry) bool {
+		return e.Action == audit.ActionReversalFailed
+	})).Return(nil)
+
+	res, err := uc.Reverse(ctx, &ReverseRequest{
+		UUID:  "tx-123",
+		Cause: transaction.ReversalBankError,
+		Note:  "CBS posted the transfer twice",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg(reasonRefused), err)
+}
+
+func TestReverse_OutcomeUnknown(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "ops-jane",
+			Role:     user.RoleOps,
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		unitOfWork  = uow.NewMockUnitOfWork(t)
+		transferSvc = transfer.NewMockService(t)
+		paymentSvc  = payment.NewMockService(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, auditRepo, unitOfWork, transferSvc, paymentSvc)
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:                 "tx-123",
+			TransactionType:      transferTransactionType,
+			TransactionReference: "ref-123",
+			Status:               transaction.StatusCompleted,
+			Amount:               100000,
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil)
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, reasonReversing).
+		Return(transaction.Transaction{
+			UUID:       "rev-uuid",
+			ReversalOf: "tx-123",
+			Status:     transaction.StatusPending,
+		}, nil)
+	transferSvc.EXPECT().Reverse(mock.Anything, mock.Anything).
+		Return(transfer.Transfer{}, context.DeadlineExceeded)
+
+	res, err := uc.Reverse(ctx, &ReverseRequest{
+		UUID:  "tx-123",
+		Cause: transaction.ReversalBankError,
+		Note:  "CBS posted the transfer twice",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusPending, res.Status)
+	txRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestReconcile(t *testing.T) {
+	var (
+		cfg         = new(config.Configs)
+		txRepo      = transaction.NewMockRepository(t)
+		auditRepo   = audit.NewMockRepository(t)
+		unitOfWork  = uow.NewMockUnitOfWork(t)
+		transferSvc = transfer.NewMockService(t)
+		paymentSvc  = payment.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Transaction.ReconcileAfter = time.Minute
+	uc := NewUsecase(cfg, txRepo, auditRepo, unitOfWork, transferSvc, paymentSvc)
+
+	updatedAt := time.Now().Add(-time.Hour)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{
+		"transaction_type": reversalTransactionType,
+		"status":           transaction.StatusPending,
+	}).Return([]transaction.Transaction{
+		{UUID: "rev-1", ReversalOf: "tx-1", Status: transaction.StatusPending, Amount: 100000, Note: "Duplicate", UpdatedAt: updatedAt},
+		{UUID: "rev-2", ReversalOf: "tx-2", Status: transaction.StatusPending, Amount: 5000, UpdatedAt: updatedAt},
+		{UUID: "rev-3", ReversalOf: "tx-3", Status: transaction.StatusPending, Amount: 7000, UpdatedAt: updatedAt},
+		{UUID: "rev-4", ReversalOf: "tx-4", Status: transaction.StatusPending, UpdatedAt: time.Now()},
+	}, nil)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-1").
+		Return(transaction.Transaction{
+			UUID:                 "tx-1",
+			TransactionType:      transferTransactionType,
+			TransactionReference: "ref-1",
+			SourceAccount:        "123",
+			DestinationAccount:   "456",
+			Status:               transaction.StatusCompleted,
+			Amount:               100000,
+		}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-2").
+		Return(transaction.Transaction{
+			UUID:                 "tx-2",
+			TransactionType:      transferTransactionType,
+			TransactionReference: "ref-2",
+			Status:               transaction.StatusCompleted,
+			Amount:               5000,
+		}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-3").
+		Return(transaction.Transaction{
+			UUID:            "tx-3",
+			TransactionType: tapMoneyTransactionType,
+			PaymentID:       "pay-3",
+			Status:          transaction.StatusCompleted,
+			Amount:          7000,
+		}, nil)
+
+	// The reversal was executed before, the core banking system deduplicates it by its remark.
+	transferSvc.EXPECT().Reverse(mock.Anything, mock.MatchedBy(func(rv transfer.Reversal) bool {
+		return rv.Remark == "REV tx-1 BNKKRD rev-1"
+	})).Return(transfer.Transfer{Status: transfer.StatusSuccess, TransactionReference: "rev-ref-1"}, nil)
+	transferSvc.EXPECT().Reverse(mock.Anything, mock.MatchedBy(func(rv transfer.Reversal) bool {
+		return rv.Remark == "REV tx-2 BNKKRD rev-2"
+	})).Return(transfer.Transfer{Status: transfer.StatusFailed}, nil)
+	paymentSvc.EXPECT().Reverse(mock.Anything, mock.MatchedBy(func(rv payment.Reversal) bool {
+		return rv.ReferenceID == "rev-3"
+	})).Return(payment.Payment{}, payment.ErrOutcomeUnknown)
+
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "rev-1" && tx.Status == transaction.StatusCompleted && tx.TransactionReference == "rev-ref-1"
+	})).Return(nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-1" && tx.Status == transaction.StatusReversed && tx.StatusReason == "Duplicate"
+	})).Return(nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "rev-2" && tx.Status == transaction.StatusFailed && tx.StatusReason == reasonRefused
+	})).Return(nil)
+	auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e audit.Entry) bool {
+		return e.Actor == reconcileActor && e.Action == audit.ActionReversalCompleted && e.ResourceUUID == "tx-1"
+	})).Return(nil)
+	auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e audit.Entry) bool {
+		return e.Actor == reconcileActor && e.Action == audit.ActionReversalFailed && e.ResourceUUID == "tx-2"
+	})).Return(nil)
+
+	err := uc.Reconcile(context.Background())
+
+	assert.NoError(t, err)
+}
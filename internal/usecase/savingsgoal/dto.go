@@ -0,0 +1,51 @@
+package savingsgoal
+
+import "time"
+
+type CreateRequest struct {
+	Name          string `json:"name" validate:"required,max=100"`
+	PocketAccount string `json:"pocket_account" validate:"required,accountnumber"`
+	TargetAmount  int64  `json:"target_amount" validate:"required,gte=10000"`
+	Deadline      string `json:"deadline" validate:"required,datetime=2006-01-02"`
+	SweepRule     string `json:"sweep_rule" validate:"required,only=scheduled roundup"`
+	SweepAmount   int64  `json:"sweep_amount" validate:"omitempty,gte=1000,lte=50000000"`
+	Frequency     string `json:"frequency" validate:"omitempty,only=daily weekly monthly"`
+}
+
+type GoalResponse struct {
+	UUID            string    `json:"uuid"`
+	Name            string    `json:"name"`
+	SourceAccount   string    `json:"source_account"`
+	PocketAccount   string    `json:"pocket_account"`
+	TargetAmount    int64     `json:"target_amount"`
+	SavedAmount     int64     `json:"saved_amount"`
+	RemainingAmount int64     `json:"remaining_amount"`
+	Progress        int       `json:"progress"`
+	Deadline        time.Time `json:"deadline"`
+	DaysLeft        int       `json:"days_left"`
+	SweepRule       string    `json:"sweep_rule"`
+	SweepAmount     int64     `json:"sweep_amount,omitempty"`
+	Frequency       string    `json:"frequency,omitempty"`
+	NextSweepDate   time.Time `json:"next_sweep_date,omitzero"`
+	Status          string    `json:"status"`
+}
+
+type GetRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type SweepResponse struct {
+	TransactionUUID string    `json:"transaction_uuid,omitempty"`
+	Amount          int64     `json:"amount"`
+	Status          string    `json:"status"`
+	Reason          string    `json:"reason,omitempty"`
+	SweptAt         time.Time `json:"swept_at"`
+}
+
+type CancelRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type CancelResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,534 @@
+package savingsgoal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/savingsgoal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+const (
+	dateLayout         = "2006-01-02"
+	defaultRoundUpUnit = 1000
+	// claimLease is how long a scheduler holds the sweep of a savings goal,
+	// a sweep that is not recorded by then is taken up again.
+	claimLease = 15 * time.Minute
+)
+
+// Usecase defines the use case for savings goals and their sweeps into pockets.
+type Usecase struct {
+	roundUpUnit     int64
+	cbsSvc          cbs.Service
+	goalRepo        savingsgoal.Repository
+	txRepo          transaction.Repository
+	accountRepo     account.Repository
+	userRepo        user.Repository
+	uow             uow.UnitOfWork
+	notificationSvc notification.Service
+	transferUc      *transfer.Usecase
+}
+
+func NewUsecase(
+	cfg *config.Configs,
+	cbsSvc cbs.Service,
+	goalRepo savingsgoal.Repository,
+	txRepo transaction.Repository,
+	accountRepo account.Repository,
+	userRepo user.Repository,
+	uow uow.UnitOfWork,
+	notificationSvc notification.Service,
+	transferUc *transfer.Usecase,
+) *Usecase {
+	roundUpUnit := cfg.SavingsGoal.RoundUpUnit
+	if roundUpUnit <= 0 {
+		roundUpUnit = defaultRoundUpUnit
+	}
+	return &Usecase{
+		roundUpUnit:     roundUpUnit,
+		cbsSvc:          cbsSvc,
+		goalRepo:        goalRepo,
+		txRepo:          txRepo,
+		accountRepo:     accountRepo,
+		userRepo:        userRepo,
+		uow:             uow,
+		notificationSvc: notificationSvc,
+		transferUc:      transferUc,
+	}
+}
+
+// Create sets up a savings goal on a pocket of the user in the context.
+// The sweeps are taken from the parent account of the pocket.
+func (uc *Usecase) Create(ctx context.Context, req *CreateRequest) (*GoalResponse, error) {
+	l := log.WithContext(ctx, "Create")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	deadline, err := time.Parse(dateLayout, req.Deadline)
+	if err != nil || !deadline.After(today()) {
+		l.Error().Err(err).
+			Str("deadline", req.Deadline).
+			Msg("Invalid deadline")
+		return nil, pkgerror.BadRequest().SetMsg("Deadline must be in the future")
+	}
+	if req.SweepRule == savingsgoal.SweepScheduled && (req.SweepAmount == 0 || req.Frequency == "") {
+		return nil, pkgerror.BadRequest().SetMsg("Sweep amount and frequency are required for scheduled sweeps")
+	}
+
+	u, err := uc.userRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).
+			Str("username", userFromCtx.Username).
+			Msg("Failed to get user")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	pocket, err := uc.accountRepo.Get(ctx, req.PocketAccount)
+	if err != nil && errors.Is(err, account.ErrNotFound) {
+		return nil, pkgerror.NotFound().SetMsg("Pocket not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", req.PocketAccount).
+			Msg("Failed to get account")
+		return nil, pkgerror.InternalServerError()
+	}
+	if !pocket.OwnedBy(u.CIF) || !pocket.IsPocket() || pocket.IsClosed() {
+		return nil, pkgerror.NotFound().SetMsg("Pocket not found")
+	}
+
+	goals, err := uc.goalRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get savings goals")
+		return nil, pkgerror.InternalServerError()
+	}
+	for _, g := range goals {
+		if g.IsActive() && g.PocketAccount == pocket.AccountNumber {
+			return nil, pkgerror.Conflict().SetMsg("Pocket already has an active savings goal")
+		}
+	}
+
+	goal := savingsgoal.Goal{
+		UUID:          uuid.New().String(),
+		Username:      userFromCtx.Username,
+		Name:          req.Name,
+		SourceAccount: pocket.ParentAccount,
+		PocketAccount: pocket.AccountNumber,
+		TargetAmount:  req.TargetAmount,
+		Deadline:      deadline,
+		SweepRule:     req.SweepRule,
+		LastSweptAt:   time.Now(),
+		Status:        savingsgoal.StatusActive,
+		CreatedAt:     time.Now(),
+	}
+	if goal.SweepRule == savingsgoal.SweepScheduled {
+		goal.SweepAmount = req.SweepAmount
+		goal.Frequency = req.Frequency
+		goal.NextSweepDate = today()
+	}
+
+	err = uc.goalRepo.Create(ctx, goal)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to create savings goal")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return newGoalResponse(goal, pocket), nil
+}
+
+// GetGoals returns the savings goals of the user in the context with their progress.
+func (uc *Usecase) GetGoals(ctx context.Context) ([]*GoalResponse, error) {
+	l := log.WithContext(ctx, "GetGoals")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	goals, err := uc.goalRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get savings goals")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := make([]*GoalResponse, 0, len(goals))
+	for _, goal := range goals {
+		pocket, err := uc.accountRepo.Get(ctx, goal.PocketAccount)
+		if err != nil {
+			l.Error().Err(err).
+				Str("account_number", goal.PocketAccount).
+				Msg("Failed to get pocket")
+			return nil, pkgerror.InternalServerError()
+		}
+		res = append(res, newGoalResponse(goal, pocket))
+	}
+	return res, nil
+}
+
+// GetGoal returns a savings goal of the user in the context with its progress.
+func (uc *Usecase) GetGoal(ctx context.Context, req *GetRequest) (*GoalResponse, error) {
+	l := log.WithContext(ctx, "GetGoal")
+
+	goal, err := uc.getOwnedGoal(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	pocket, err := uc.accountRepo.Get(ctx, goal.PocketAccount)
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", goal.PocketAccount).
+			Msg("Failed to get pocket")
+		return nil, pkgerror.InternalServerError()
+	}
+	return newGoalResponse(goal, pocket), nil
+}
+
+// GetSweeps returns the sweeps of a savings goal of the user in the context, latest first.
+func (uc *Usecase) GetSweeps(ctx context.Context, req *GetRequest) ([]*SweepResponse, error) {
+	l := log.WithContext(ctx, "GetSweeps")
+
+	goal, err := uc.getOwnedGoal(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	sweeps, err := uc.goalRepo.GetSweeps(ctx, goal.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", goal.UUID).
+			Msg("Failed to get savings goal sweeps")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := make([]*SweepResponse, 0, len(sweeps))
+	for _, s := range sweeps {
+		res = append(res, &SweepResponse{
+			TransactionUUID: s.TransactionUUID,
+			Amount:          s.Amount,
+			Status:          s.Status,
+			Reason:          s.Reason,
+			SweptAt:         s.SweptAt,
+		})
+	}
+	return res, nil
+}
+
+// Cancel stops the sweeps of a savings goal of the user in the context,
+// the money already in the pocket stays there.
+func (uc *Usecase) Cancel(ctx context.Context, req *CancelRequest) (*CancelResponse, error) {
+	l := log.WithContext(ctx, "Cancel")
+
+	goal, err := uc.getOwnedGoal(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if !goal.IsActive() {
+		return nil, pkgerror.Conflict().SetMsg("Savings goal is not active")
+	}
+
+	goal.Status = savingsgoal.StatusCancelled
+	err = uc.goalRepo.Update(ctx, goal)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", goal.UUID).
+			Msg("Failed to cancel savings goal")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return &CancelResponse{
+		Message: "Savings goal cancelled",
+	}, nil
+}
+
+// Sweep moves money into the pockets of the active savings goals through the transfer usecase:
+// the fixed amount of the scheduled goals that are due and the round-ups of the transactions
+// completed on the source accounts since the last sweep. The goals are claimed first, so a goal is
+// swept by one scheduler even when every replica runs one. Nothing is swept while the core banking
+// system is not ready for transactions.
+func (uc *Usecase) Sweep(ctx context.Context) error {
+	l := log.WithContext(ctx, "Sweep")
+
+	cbsStatus, err := uc.cbsSvc.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if cbsStatus.NotReady() {
+		l.Info().
+			Bool("is_eod", cbsStatus.IsEOD).
+			Bool("is_stand_in", cbsStatus.IsStandIn).
+			Msg("CBS is not ready, savings goal sweeps are postponed")
+		return nil
+	}
+
+	goals, err := uc.goalRepo.ClaimActive(ctx, time.Now(), claimLease)
+	if err != nil {
+		return err
+	}
+	for _, goal := range goals {
+		uc.sweep(ctx, goal)
+	}
+	return nil
+}
+
+// sweep moves the next amount of a claimed goal into its pocket and records the outcome,
+// which releases the claim. The goal is reached, and no longer swept, once the pocket holds the
+// target amount. The schedule and the round-up window only move on once the amount is swept,
+// a failed sweep is retried on the next schedule tick.
+// A sweep whose outcome could not be recorded is taken up again once its claim expires,
+// its transfer is idempotent so the amount is not swept twice.
+func (uc *Usecase) sweep(ctx context.Context, goal savingsgoal.Goal) {
+	l := log.WithContext(ctx, "sweep")
+
+	pocket, err := uc.accountRepo.Get(ctx, goal.PocketAccount)
+	if err != nil && !errors.Is(err, account.ErrNotFound) {
+		l.Error().Err(err).
+			Str("uuid", goal.UUID).
+			Msg("Failed to get pocket")
+		return
+	}
+	goal.ClaimedUntil = time.Time{}
+	if err != nil || pocket.IsClosed() {
+		goal.Status = savingsgoal.StatusCancelled
+		uc.update(ctx, goal)
+		return
+	}
+
+	remaining := goal.Remaining(pocket.Balance)
+	if remaining == 0 {
+		uc.reach(ctx, &goal)
+		uc.update(ctx, goal)
+		return
+	}
+
+	var (
+		amount      int64
+		lastSweptAt = goal.LastSweptAt
+	)
+	switch goal.SweepRule {
+	case savingsgoal.SweepScheduled:
+		amount = goal.SweepAmount
+	case savingsgoal.SweepRoundUp:
+		amount, lastSweptAt, err = uc.roundUp(ctx, goal)
+		if err != nil {
+			l.Error().Err(err).
+				Str("uuid", goal.UUID).
+				Msg("Failed to round up transactions")
+			return
+		}
+		if amount == 0 {
+			goal.LastSweptAt = lastSweptAt
+			uc.update(ctx, goal)
+			return
+		}
+	}
+	amount = min(amount, remaining)
+
+	sweep := savingsgoal.Sweep{
+		GoalUUID: goal.UUID,
+		Amount:   amount,
+		SweptAt:  time.Now(),
+	}
+	sweep.TransactionUUID, err = uc.transfer(ctx, goal, amount)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", goal.UUID).
+			Int64("amount", amount).
+			Msg("Savings goal sweep failed")
+		sweep.Status = savingsgoal.SweepStatusFailed
+		sweep.Reason = err.Error()
+		goal.Attempts++
+	} else {
+		sweep.Status = savingsgoal.SweepStatusCompleted
+		goal.Attempts = 0
+		if goal.SweepRule == savingsgoal.SweepScheduled {
+			goal.Advance()
+		}
+		goal.LastSweptAt = lastSweptAt
+		if amount == remaining {
+			uc.reach(ctx, &goal)
+		}
+	}
+
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		err := uc.goalRepo.CreateSweep(ctx, sweep)
+		if err != nil {
+			return err
+		}
+		return uc.goalRepo.Update(ctx, goal)
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", goal.UUID).
+			Msg("Failed to record savings goal sweep")
+	}
+}
+
+// roundUp returns the sum of the round-ups of the transactions completed on the source account
+// since the last sweep and the update time of the last of them. The sweeps into the pocket are not rounded up.
+func (uc *Usecase) roundUp(ctx context.Context, goal savingsgoal.Goal) (int64, time.Time, error) {
+	txs, err := uc.txRepo.GetCompletedDebits(ctx, goal.SourceAccount, goal.LastSweptAt)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	var amount int64
+	lastSweptAt := goal.LastSweptAt
+	for _, tx := range txs {
+		if tx.DestinationAccount != goal.PocketAccount {
+			amount += savingsgoal.RoundUp(tx.HoldAmount(), uc.roundUpUnit)
+		}
+		lastSweptAt = tx.UpdatedAt
+	}
+	return amount, lastSweptAt, nil
+}
+
+// transfer initiates and processes the sweep of a goal on behalf of its owner.
+// An attempt that was already taken up resumes its transfer instead of initiating another one.
+func (uc *Usecase) transfer(ctx context.Context, goal savingsgoal.Goal, amount int64) (string, error) {
+	ctx = context.WithValue(ctx, user.ContextKey, user.User{
+		Username: goal.Username,
+	})
+
+	initRes, err := uc.transferUc.Initiate(ctx, &transfer.InitiateRequest{
+		SourceAccount:      goal.SourceAccount,
+		DestinationAccount: goal.PocketAccount,
+		Amount:             amount,
+		Note:               "Savings goal " + goal.Name,
+		Origin:             transaction.OriginSavingsGoal,
+		UUID:               sweepTransferUUID(goal),
+	})
+	if err != nil {
+		return "", err
+	}
+	switch initRes.Status {
+	case transaction.StatusInitiated:
+	case transaction.StatusCompleted, transaction.StatusPending:
+		return initRes.UUID, nil
+	default:
+		return initRes.UUID, fmt.Errorf("transfer of the sweep is %s", initRes.Status)
+	}
+
+	_, err = uc.transferUc.Process(ctx, &transfer.ProcessRequest{
+		UUID:               initRes.UUID,
+		SourceAccount:      goal.SourceAccount,
+		DestinationAccount: goal.PocketAccount,
+		Amount:             amount,
+	})
+	if err != nil {
+		return initRes.UUID, err
+	}
+	return initRes.UUID, nil
+}
+
+// sweepTransferUUID returns the UUID of the transfer of the current attempt of the goal sweep,
+// derived from the goal, its next sweep date or round-up window and the attempt.
+func sweepTransferUUID(goal savingsgoal.Goal) string {
+	since := goal.LastSweptAt.UTC().Format(time.RFC3339Nano)
+	if goal.SweepRule == savingsgoal.SweepScheduled {
+		since = goal.NextSweepDate.Format(dateLayout)
+	}
+	name := fmt.Sprintf("savings-goal/%s/%s/%d", goal.UUID, since, goal.Attempts)
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+// reach marks the goal reached and tells the owner.
+func (uc *Usecase) reach(ctx context.Context, goal *savingsgoal.Goal) {
+	l := log.WithContext(ctx, "reach")
+
+	goal.Status = savingsgoal.StatusReached
+	err := uc.notificationSvc.Send(ctx, notification.Notification{
+		Username: goal.Username,
+		Title:    "Savings goal reached",
+		Message:  "Your savings goal " + goal.Name + " has reached its target.",
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", goal.UUID).
+			Msg("Failed to send savings goal notification")
+	}
+}
+
+// update stores the goal, logging the failure since the sweep job has no caller to report to.
+func (uc *Usecase) update(ctx context.Context, goal savingsgoal.Goal) {
+	l := log.WithContext(ctx, "update")
+
+	err := uc.goalRepo.Update(ctx, goal)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", goal.UUID).
+			Msg("Failed to update savings goal")
+	}
+}
+
+func (uc *Usecase) getOwnedGoal(ctx context.Context, goalUUID string) (savingsgoal.Goal, error) {
+	l := log.WithContext(ctx, "getOwnedGoal")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return savingsgoal.Goal{}, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	goal, err := uc.goalRepo.GetByUUID(ctx, goalUUID)
+	if err != nil && errors.Is(err, savingsgoal.ErrNotFound) {
+		return savingsgoal.Goal{}, pkgerror.NotFound().SetMsg("Savings goal not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", goalUUID).
+			Msg("Failed to get savings goal")
+		return savingsgoal.Goal{}, pkgerror.InternalServerError()
+	}
+	if goal.Username != userFromCtx.Username {
+		return savingsgoal.Goal{}, pkgerror.NotFound().SetMsg("Savings goal not found")
+	}
+	return goal, nil
+}
+
+// newGoalResponse returns the goal with its progress, the amount saved is the ledger balance of the pocket.
+func newGoalResponse(goal savingsgoal.Goal, pocket account.Account) *GoalResponse {
+	saved := pocket.Balance
+	progress := 100
+	if saved < goal.TargetAmount {
+		progress = int(saved * 100 / goal.TargetAmount)
+	}
+	return &GoalResponse{
+		UUID:            goal.UUID,
+		Name:            goal.Name,
+		SourceAccount:   goal.SourceAccount,
+		PocketAccount:   goal.PocketAccount,
+		TargetAmount:    goal.TargetAmount,
+		SavedAmount:     saved,
+		RemainingAmount: goal.Remaining(saved),
+		Progress:        progress,
+		Deadline:        goal.Deadline,
+		DaysLeft:        max(int(goal.Deadline.Sub(today()).Hours()/24), 0),
+		SweepRule:       goal.SweepRule,
+		SweepAmount:     goal.SweepAmount,
+		Frequency:       goal.Frequency,
+		NextSweepDate:   goal.NextSweepDate,
+		Status:          goal.Status,
+	}
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,494 @@
+package savingsgoal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/savingsgoal"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	domaintransfer "go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+func TestCreate_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		goalRepo        = savingsgoal.NewMockRepository(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		userRepo        = user.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.SavingsGoal.RoundUpUnit = 1000
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, goalRepo, txRepo, accountRepo, userRepo, unitOfWork, notificationSvc, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(account.Account{
+			CIF:           "0000000001",
+			AccountNumber: "1000000003",
+			ParentAccount: "1000000001",
+			Type:          account.TypePocket,
+			Status:        account.StatusActive,
+			Balance:       2500000,
+		}, nil)
+	goalRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(nil, nil)
+	goalRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(goal savingsgoal.Goal) bool {
+		return goal.SourceAccount == "1000000001" &&
+			goal.PocketAccount == "1000000003" &&
+			goal.SweepRule == savingsgoal.SweepScheduled &&
+			goal.NextSweepDate.Equal(today()) &&
+			goal.Status == savingsgoal.StatusActive
+	})).Return(nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		Name:          "Holiday",
+		PocketAccount: "1000000003",
+		TargetAmount:  10000000,
+		Deadline:      today().AddDate(0, 6, 0).Format(dateLayout),
+		SweepRule:     savingsgoal.SweepScheduled,
+		SweepAmount:   500000,
+		Frequency:     savingsgoal.FrequencyMonthly,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500000), res.SavedAmount)
+	assert.Equal(t, int64(7500000), res.RemainingAmount)
+	assert.Equal(t, 25, res.Progress)
+}
+
+func TestCreate_NotPocket(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		goalRepo        = savingsgoal.NewMockRepository(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		userRepo        = user.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.SavingsGoal.RoundUpUnit = 1000
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, goalRepo, txRepo, accountRepo, userRepo, unitOfWork, notificationSvc, transferUc)
+
+	userRepo.EXPECT().GetByUsername(mock.Anything, "johndoe").
+		Return(user.User{Username: "johndoe", CIF: "0000000001"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{
+			CIF:           "0000000001",
+			AccountNumber: "1000000001",
+			Type:          account.TypeSavings,
+			Status:        account.StatusActive,
+		}, nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		Name:          "Holiday",
+		PocketAccount: "1000000001",
+		TargetAmount:  10000000,
+		Deadline:      today().AddDate(0, 6, 0).Format(dateLayout),
+		SweepRule:     savingsgoal.SweepRoundUp,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Pocket not found"), err)
+}
+
+func TestSweep_ScheduledReachesTarget(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		goalRepo        = savingsgoal.NewMockRepository(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		userRepo        = user.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.SavingsGoal.RoundUpUnit = 1000
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, goalRepo, txRepo, accountRepo, userRepo, unitOfWork, notificationSvc, transferUc)
+
+	sweepDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	goal := savingsgoal.Goal{
+		UUID:          "goal-123",
+		Username:      "johndoe",
+		Name:          "Holiday",
+		SourceAccount: "1000000001",
+		PocketAccount: "1000000003",
+		TargetAmount:  10000000,
+		SweepRule:     savingsgoal.SweepScheduled,
+		SweepAmount:   500000,
+		Frequency:     savingsgoal.FrequencyMonthly,
+		NextSweepDate: sweepDate,
+		Status:        savingsgoal.StatusActive,
+		ClaimedUntil:  time.Now().Add(claimLease),
+		CreatedAt:     sweepDate,
+	}
+	txUUID := sweepTransferUUID(goal)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	goalRepo.EXPECT().ClaimActive(mock.Anything, mock.Anything, claimLease).
+		Return([]savingsgoal.Goal{goal}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(account.Account{AccountNumber: "1000000003", Status: account.StatusActive, Balance: 9800000}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": txUUID}).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{AccountNumber: "1000000001", AvailableBalance: 10000000}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "1000000001", txUUID, int64(200000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == txUUID && tx.Origin == transaction.OriginSavingsGoal
+	})).Return(nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, txUUID).
+		Return(transaction.Transaction{
+			UUID:               txUUID,
+			Status:             transaction.StatusInitiated,
+			Rail:               domaintransfer.RailInternal,
+			SourceAccount:      "1000000001",
+			DestinationAccount: "1000000003",
+			Amount:             200000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, txUUID, mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               txUUID,
+			Status:             transaction.StatusPending,
+			Rail:               domaintransfer.RailInternal,
+			SourceAccount:      "1000000001",
+			DestinationAccount: "1000000003",
+			Amount:             200000,
+		}, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "1000000001", "1000000003", int64(200000), mock.Anything).
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
+		Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, txUUID).
+		Return(nil)
+	notificationSvc.EXPECT().Send(mock.Anything, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Username == "johndoe" && n.Title == "Savings goal reached"
+	})).Return(nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	goalRepo.EXPECT().CreateSweep(mock.Anything, mock.MatchedBy(func(sweep savingsgoal.Sweep) bool {
+		return sweep.Status == savingsgoal.SweepStatusCompleted && sweep.Amount == 200000 && sweep.TransactionUUID == txUUID
+	})).Return(nil)
+	goalRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(goal savingsgoal.Goal) bool {
+		return goal.Status == savingsgoal.StatusReached &&
+			goal.NextSweepDate.Equal(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)) &&
+			goal.ClaimedUntil.IsZero()
+	})).Return(nil)
+
+	err := uc.Sweep(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestSweep_RoundUp(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		goalRepo        = savingsgoal.NewMockRepository(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		userRepo        = user.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.SavingsGoal.RoundUpUnit = 1000
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, goalRepo, txRepo, accountRepo, userRepo, unitOfWork, notificationSvc, transferUc)
+
+	lastSweptAt := time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)
+	goal := savingsgoal.Goal{
+		UUID:          "goal-123",
+		Username:      "johndoe",
+		Name:          "Holiday",
+		SourceAccount: "1000000001",
+		PocketAccount: "1000000003",
+		TargetAmount:  10000000,
+		SweepRule:     savingsgoal.SweepRoundUp,
+		LastSweptAt:   lastSweptAt,
+		Status:        savingsgoal.StatusActive,
+	}
+	txUUID := sweepTransferUUID(goal)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	goalRepo.EXPECT().ClaimActive(mock.Anything, mock.Anything, claimLease).
+		Return([]savingsgoal.Goal{goal}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(account.Account{AccountNumber: "1000000003", Status: account.StatusActive, Balance: 100000}, nil)
+	txRepo.EXPECT().GetCompletedDebits(mock.Anything, "1000000001", lastSweptAt).
+		Return([]transaction.Transaction{
+			{DestinationAccount: "456", Amount: 23500, UpdatedAt: lastSweptAt.Add(time.Minute)},
+			{DestinationAccount: "789", Amount: 10000, Fee: 2500, UpdatedAt: lastSweptAt.Add(2 * time.Minute)},
+			{DestinationAccount: "1000000003", Amount: 700, UpdatedAt: lastSweptAt.Add(3 * time.Minute)},
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": txUUID}).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{AccountNumber: "1000000001", AvailableBalance: 10000000}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "1000000001", txUUID, int64(1000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == txUUID
+	})).Return(nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, txUUID).
+		Return(transaction.Transaction{
+			UUID:               txUUID,
+			Status:             transaction.StatusInitiated,
+			Rail:               domaintransfer.RailInternal,
+			SourceAccount:      "1000000001",
+			DestinationAccount: "1000000003",
+			Amount:             1000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, txUUID, mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               txUUID,
+			Status:             transaction.StatusPending,
+			Rail:               domaintransfer.RailInternal,
+			SourceAccount:      "1000000001",
+			DestinationAccount: "1000000003",
+			Amount:             1000,
+		}, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "1000000001", "1000000003", int64(1000), mock.Anything).
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
+		Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, txUUID).
+		Return(nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	goalRepo.EXPECT().CreateSweep(mock.Anything, mock.MatchedBy(func(sweep savingsgoal.Sweep) bool {
+		return sweep.Status == savingsgoal.SweepStatusCompleted && sweep.Amount == 1000
+	})).Return(nil)
+	goalRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(goal savingsgoal.Goal) bool {
+		return goal.Status == savingsgoal.StatusActive &&
+			goal.LastSweptAt.Equal(lastSweptAt.Add(3*time.Minute))
+	})).Return(nil)
+
+	err := uc.Sweep(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestSweep_TransferFailed(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		goalRepo        = savingsgoal.NewMockRepository(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		userRepo        = user.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.SavingsGoal.RoundUpUnit = 1000
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, goalRepo, txRepo, accountRepo, userRepo, unitOfWork, notificationSvc, transferUc)
+
+	sweepDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	goal := savingsgoal.Goal{
+		UUID:          "goal-123",
+		Username:      "johndoe",
+		Name:          "Holiday",
+		SourceAccount: "1000000001",
+		PocketAccount: "1000000003",
+		TargetAmount:  10000000,
+		SweepRule:     savingsgoal.SweepScheduled,
+		SweepAmount:   500000,
+		Frequency:     savingsgoal.FrequencyMonthly,
+		NextSweepDate: sweepDate,
+		Status:        savingsgoal.StatusActive,
+		ClaimedUntil:  time.Now().Add(claimLease),
+		CreatedAt:     sweepDate,
+	}
+	txUUID := sweepTransferUUID(goal)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	goalRepo.EXPECT().ClaimActive(mock.Anything, mock.Anything, claimLease).
+		Return([]savingsgoal.Goal{goal}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(account.Account{AccountNumber: "1000000003", Status: account.StatusActive, Balance: 2500000}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": txUUID}).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000001").
+		Return(account.Account{AccountNumber: "1000000001", AvailableBalance: 100000}, nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	goalRepo.EXPECT().CreateSweep(mock.Anything, mock.MatchedBy(func(sweep savingsgoal.Sweep) bool {
+		return sweep.Status == savingsgoal.SweepStatusFailed && sweep.Amount == 500000
+	})).Return(nil)
+	goalRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(goal savingsgoal.Goal) bool {
+		return goal.Status == savingsgoal.StatusActive &&
+			goal.NextSweepDate.Equal(sweepDate) &&
+			goal.Attempts == 1 &&
+			goal.ClaimedUntil.IsZero()
+	})).Return(nil)
+
+	err := uc.Sweep(context.Background())
+
+	assert.NoError(t, err)
+	transferSvc.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSweep_AlreadyTransferred(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		goalRepo        = savingsgoal.NewMockRepository(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		userRepo        = user.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.SavingsGoal.RoundUpUnit = 1000
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, goalRepo, txRepo, accountRepo, userRepo, unitOfWork, notificationSvc, transferUc)
+
+	lastSweptAt := time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)
+	goal := savingsgoal.Goal{
+		UUID:          "goal-123",
+		Username:      "johndoe",
+		Name:          "Holiday",
+		SourceAccount: "1000000001",
+		PocketAccount: "1000000003",
+		TargetAmount:  10000000,
+		SweepRule:     savingsgoal.SweepRoundUp,
+		LastSweptAt:   lastSweptAt,
+		Status:        savingsgoal.StatusActive,
+	}
+	txUUID := sweepTransferUUID(goal)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	goalRepo.EXPECT().ClaimActive(mock.Anything, mock.Anything, claimLease).
+		Return([]savingsgoal.Goal{goal}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "1000000003").
+		Return(account.Account{AccountNumber: "1000000003", Status: account.StatusActive, Balance: 100000}, nil)
+	txRepo.EXPECT().GetCompletedDebits(mock.Anything, "1000000001", lastSweptAt).
+		Return([]transaction.Transaction{
+			{DestinationAccount: "456", Amount: 23500, UpdatedAt: lastSweptAt.Add(time.Minute)},
+		}, nil)
+	// The sweep was transferred by a scheduler that failed to record it.
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": txUUID}).
+		Return([]transaction.Transaction{{
+			UUID:   txUUID,
+			Status: transaction.StatusCompleted,
+			Rail:   domaintransfer.RailInternal,
+		}}, nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	goalRepo.EXPECT().CreateSweep(mock.Anything, mock.MatchedBy(func(sweep savingsgoal.Sweep) bool {
+		return sweep.Status == savingsgoal.SweepStatusCompleted && sweep.TransactionUUID == txUUID
+	})).Return(nil)
+	goalRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(goal savingsgoal.Goal) bool {
+		return goal.LastSweptAt.Equal(lastSweptAt.Add(time.Minute))
+	})).Return(nil)
+
+	err := uc.Sweep(context.Background())
+
+	assert.NoError(t, err)
+	transferSvc.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSweep_CbsNotReady(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		goalRepo        = savingsgoal.NewMockRepository(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		userRepo        = user.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.SavingsGoal.RoundUpUnit = 1000
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, goalRepo, txRepo, accountRepo, userRepo, unitOfWork, notificationSvc, transferUc)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31", IsEOD: true}, nil)
+
+	err := uc.Sweep(context.Background())
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,47 @@
+package standingorder
+
+import "time"
+
+type CreateRequest struct {
+	SourceAccount       string `json:"source_account" validate:"required,accountnumber"`
+	DestinationBankCode string `json:"destination_bank_code" validate:"omitempty,number,len=3"`
+	DestinationAccount  string `json:"destination_account" validate:"required,accountnumber=DestinationBankCode"`
+	Amount              int64  `json:"amount" validate:"required,gte=1000"`
+	Note                string `json:"note" validate:"max=255"`
+	Frequency           string `json:"frequency" validate:"required,only=once daily weekly monthly"`
+	StartDate           string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate             string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type StandingOrderResponse struct {
+	UUID                string    `json:"uuid"`
+	SourceAccount       string    `json:"source_account"`
+	DestinationBankCode string    `json:"destination_bank_code,omitempty"`
+	DestinationAccount  string    `json:"destination_account"`
+	Amount              int64     `json:"amount"`
+	Note                string    `json:"note"`
+	Frequency           string    `json:"frequency"`
+	StartDate           time.Time `json:"start_date"`
+	EndDate             time.Time `json:"end_date,omitzero"`
+	NextRunDate         time.Time `json:"next_run_date,omitzero"`
+	Status              string    `json:"status"`
+}
+
+type GetRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type UpdateRequest struct {
+	UUID    string `param:"uuid" json:"uuid" validate:"required,uuid"`
+	Amount  int64  `json:"amount" validate:"omitempty,gte=1000"`
+	Note    string `json:"note" validate:"max=255"`
+	EndDate string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type DeleteRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type DeleteResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,398 @@
+package standingorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standingorder"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// defaultRunAttempts is the number of failed attempts after which a run is given up.
+	defaultRunAttempts = 3
+	// claimLease is how long a scheduler holds the run of a standing order,
+	// a run that is not recorded by then is taken up again.
+	claimLease = 15 * time.Minute
+)
+
+// Usecase defines the use case for scheduled and recurring transfers.
+type Usecase struct {
+	maxAttempts     int
+	cbsSvc          cbs.Service
+	orderRepo       standingorder.Repository
+	uow             uow.UnitOfWork
+	notificationSvc notification.Service
+	transferUc      *transfer.Usecase
+}
+
+func NewUsecase(
+	cfg *config.Configs,
+	cbsSvc cbs.Service,
+	orderRepo standingorder.Repository,
+	uow uow.UnitOfWork,
+	notificationSvc notification.Service,
+	transferUc *transfer.Usecase,
+) *Usecase {
+	maxAttempts := cfg.StandingOrder.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRunAttempts
+	}
+
+	return &Usecase{
+		maxAttempts:     maxAttempts,
+		cbsSvc:          cbsSvc,
+		orderRepo:       orderRepo,
+		uow:             uow,
+		notificationSvc: notificationSvc,
+		transferUc:      transferUc,
+	}
+}
+
+func (uc *Usecase) Create(ctx context.Context, req *CreateRequest) (*StandingOrderResponse, error) {
+	l := log.WithContext(ctx, "Create")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil || startDate.Before(today()) {
+		l.Error().Err(err).
+			Str("start_date", req.StartDate).
+			Msg("Invalid start date")
+		return nil, pkgerror.BadRequest().SetMsg("Start date must not be in the past")
+	}
+
+	var endDate time.Time
+	if req.EndDate != "" && req.Frequency != standingorder.FrequencyOnce {
+		endDate, err = time.Parse(dateLayout, req.EndDate)
+		if err != nil || endDate.Before(startDate) {
+			l.Error().Err(err).
+				Str("end_date", req.EndDate).
+				Msg("Invalid end date")
+			return nil, pkgerror.BadRequest().SetMsg("End date must not be before the start date")
+		}
+	}
+
+	order := standingorder.StandingOrder{
+		UUID:                uuid.New().String(),
+		Username:            userFromCtx.Username,
+		SourceAccount:       req.SourceAccount,
+		DestinationBankCode: req.DestinationBankCode,
+		DestinationAccount:  req.DestinationAccount,
+		Amount:              req.Amount,
+		Note:                req.Note,
+		Frequency:           req.Frequency,
+		StartDate:           startDate,
+		EndDate:             endDate,
+		NextRunDate:         startDate,
+		Status:              standingorder.StatusActive,
+	}
+
+	err = uc.orderRepo.Create(ctx, order)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to create standing order")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return newStandingOrderResponse(order), nil
+}
+
+func (uc *Usecase) GetStandingOrders(ctx context.Context) ([]*StandingOrderResponse, error) {
+	l := log.WithContext(ctx, "GetStandingOrders")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	orders, err := uc.orderRepo.GetByUsername(ctx, userFromCtx.Username)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get standing orders")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := make([]*StandingOrderResponse, 0, len(orders))
+	for _, order := range orders {
+		res = append(res, newStandingOrderResponse(order))
+	}
+	return res, nil
+}
+
+func (uc *Usecase) GetStandingOrder(ctx context.Context, req *GetRequest) (*StandingOrderResponse, error) {
+	order, err := uc.getOwnedOrder(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+	return newStandingOrderResponse(order), nil
+}
+
+func (uc *Usecase) Update(ctx context.Context, req *UpdateRequest) (*StandingOrderResponse, error) {
+	l := log.WithContext(ctx, "Update")
+
+	order, err := uc.getOwnedOrder(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.IsActive() {
+		l.Error().
+			Str("uuid", order.UUID).
+			Str("status", order.Status).
+			Msg("Standing order is not active")
+		return nil, pkgerror.Conflict().SetMsg("Standing order is not active")
+	}
+
+	if req.Amount != 0 {
+		order.Amount = req.Amount
+	}
+	if req.Note != "" {
+		order.Note = req.Note
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse(dateLayout, req.EndDate)
+		if err != nil || endDate.Before(order.NextRunDate) {
+			l.Error().Err(err).
+				Str("end_date", req.EndDate).
+				Msg("Invalid end date")
+			return nil, pkgerror.BadRequest().SetMsg("End date must not be before the next run date")
+		}
+		order.EndDate = endDate
+	}
+
+	err = uc.orderRepo.Update(ctx, order)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", order.UUID).
+			Msg("Failed to update standing order")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return newStandingOrderResponse(order), nil
+}
+
+func (uc *Usecase) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	l := log.WithContext(ctx, "Delete")
+
+	order, err := uc.getOwnedOrder(ctx, req.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = standingorder.StatusCancelled
+	err = uc.orderRepo.Update(ctx, order)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", order.UUID).
+			Msg("Failed to cancel standing order")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return &DeleteResponse{
+		Message: "Standing order cancelled",
+	}, nil
+}
+
+// ExecuteDue runs every standing order that is due through the transfer usecase.
+// The runs are claimed first, so a run is executed by one scheduler even when every replica runs one.
+// Nothing is executed while the core banking system is not ready for transactions.
+func (uc *Usecase) ExecuteDue(ctx context.Context) error {
+	l := log.WithContext(ctx, "ExecuteDue")
+
+	cbsStatus, err := uc.cbsSvc.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if cbsStatus.NotReady() {
+		l.Info().
+			Bool("is_eod", cbsStatus.IsEOD).
+			Bool("is_stand_in", cbsStatus.IsStandIn).
+			Msg("CBS is not ready, standing orders are postponed")
+		return nil
+	}
+
+	orders, err := uc.orderRepo.ClaimDue(ctx, time.Now(), claimLease)
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		uc.execute(ctx, order)
+	}
+	return nil
+}
+
+// execute runs a claimed standing order once and records the outcome, which releases the claim.
+// A failed run is retried on the next schedule tick until the attempts are exhausted,
+// then the user is notified and the order moves on to its next run date.
+// A run whose outcome could not be recorded is taken up again once its claim expires,
+// its transfer is idempotent so the amount is not transferred twice.
+func (uc *Usecase) execute(ctx context.Context, order standingorder.StandingOrder) {
+	l := log.WithContext(ctx, "execute")
+
+	execution := standingorder.Execution{
+		OrderUUID:  order.UUID,
+		Attempt:    order.Attempts + 1,
+		ExecutedAt: time.Now(),
+	}
+
+	txUUID, err := uc.transfer(ctx, order)
+	execution.TransactionUUID = txUUID
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", order.UUID).
+			Int("attempt", execution.Attempt).
+			Msg("Standing order run failed")
+		execution.Status = standingorder.ExecutionStatusFailed
+		execution.Reason = err.Error()
+		order.Attempts++
+		if order.Attempts >= uc.maxAttempts {
+			uc.notifyFailure(ctx, order)
+			order.Advance()
+		}
+	} else {
+		execution.Status = standingorder.ExecutionStatusCompleted
+		order.Advance()
+	}
+	order.ClaimedUntil = time.Time{}
+
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		err := uc.orderRepo.CreateExecution(ctx, execution)
+		if err != nil {
+			return err
+		}
+		return uc.orderRepo.Update(ctx, order)
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", order.UUID).
+			Msg("Failed to record standing order execution")
+	}
+}
+
+// transfer initiates and processes the transfer of a standing order on behalf of its owner.
+// An attempt that was already taken up resumes its transfer instead of initiating another one.
+func (uc *Usecase) transfer(ctx context.Context, order standingorder.StandingOrder) (string, error) {
+	ctx = context.WithValue(ctx, user.ContextKey, user.User{
+		Username: order.Username,
+	})
+
+	initRes, err := uc.transferUc.Initiate(ctx, &transfer.InitiateRequest{
+		SourceAccount:       order.SourceAccount,
+		DestinationBankCode: order.DestinationBankCode,
+		DestinationAccount:  order.DestinationAccount,
+		Amount:              order.Amount,
+		Note:                order.Note,
+		Origin:              transaction.OriginStandingOrder,
+		UUID:                runTransferUUID(order),
+	})
+	if err != nil {
+		return "", err
+	}
+	switch initRes.Status {
+	case transaction.StatusInitiated:
+	case transaction.StatusCompleted, transaction.StatusPending:
+		return initRes.UUID, nil
+	default:
+		return initRes.UUID, fmt.Errorf("transfer of the run is %s", initRes.Status)
+	}
+
+	_, err = uc.transferUc.Process(ctx, &transfer.ProcessRequest{
+		UUID:               initRes.UUID,
+		SourceAccount:      order.SourceAccount,
+		DestinationAccount: order.DestinationAccount,
+		Amount:             order.Amount,
+	})
+	if err != nil {
+		return initRes.UUID, err
+	}
+	return initRes.UUID, nil
+}
+
+// runTransferUUID returns the UUID of the transfer of the current attempt of the order run,
+// derived from the order, its run date and the attempt.
+func runTransferUUID(order standingorder.StandingOrder) string {
+	name := fmt.Sprintf("standing-order/%s/%s/%d", order.UUID, order.NextRunDate.Format(dateLayout), order.Attempts)
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+// notifyFailure tells the owner that a standing order run was given up.
+func (uc *Usecase) notifyFailure(ctx context.Context, order standingorder.StandingOrder) {
+	l := log.WithContext(ctx, "notifyFailure")
+
+	err := uc.notificationSvc.Send(ctx, notification.Notification{
+		Username: order.Username,
+		Title:    "Scheduled transfer failed",
+		Message: "Your scheduled transfer to " + order.DestinationAccount +
+			" on " + order.NextRunDate.Format(dateLayout) + " could not be completed.",
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", order.UUID).
+			Msg("Failed to send notification")
+	}
+}
+
+// getOwnedOrder retrieves a standing order that belongs to the user in the context.
+func (uc *Usecase) getOwnedOrder(ctx context.Context, orderUUID string) (standingorder.StandingOrder, error) {
+	l := log.WithContext(ctx, "getOwnedOrder")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return standingorder.StandingOrder{}, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	order, err := uc.orderRepo.GetByUUID(ctx, orderUUID)
+	if err != nil && errors.Is(err, standingorder.ErrNotFound) {
+		return standingorder.StandingOrder{}, pkgerror.NotFound().SetMsg("Standing order not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", orderUUID).
+			Msg("Failed to get standing order")
+		return standingorder.StandingOrder{}, pkgerror.InternalServerError()
+	}
+	if order.Username != userFromCtx.Username {
+		return standingorder.StandingOrder{}, pkgerror.NotFound().SetMsg("Standing order not found")
+	}
+	return order, nil
+}
+
+func newStandingOrderResponse(order standingorder.StandingOrder) *StandingOrderResponse {
+	res := &StandingOrderResponse{
+		UUID:                order.UUID,
+		SourceAccount:       order.SourceAccount,
+		DestinationBankCode: order.DestinationBankCode,
+		DestinationAccount:  order.DestinationAccount,
+		Amount:              order.Amount,
+		Note:                order.Note,
+		Frequency:           order.Frequency,
+		StartDate:           order.StartDate,
+		EndDate:             order.EndDate,
+		Status:              order.Status,
+	}
+	if order.IsActive() {
+		res.NextRunDate = order.NextRunDate
+	}
+	return res
+}
+
+// today returns the start of the current day in UTC.
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,464 @@
+package standingorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standingorder"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	domaintransfer "go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/usecase/transfer"
+)
+
+func TestCreate_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(new(config.Configs), cbsService, orderRepo, unitOfWork, notificationSvc, nil)
+	)
+
+	startDate := time.Now().AddDate(0, 0, 1).Format(dateLayout)
+
+	orderRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(order standingorder.StandingOrder) bool {
+		return order.Username == "johndoe" &&
+			order.Status == standingorder.StatusActive &&
+			order.NextRunDate.Equal(order.StartDate)
+	})).Return(nil)
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             2500000,
+		Note:               "Rent",
+		Frequency:          standingorder.FrequencyMonthly,
+		StartDate:          startDate,
+	})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.UUID)
+	assert.Equal(t, startDate, res.NextRunDate.Format(dateLayout))
+	assert.Equal(t, standingorder.StatusActive, res.Status)
+}
+
+func TestCreate_StartDateInThePast(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(new(config.Configs), cbsService, orderRepo, unitOfWork, notificationSvc, nil)
+	)
+
+	log.Configure("test")
+
+	res, err := uc.Create(ctx, &CreateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             2500000,
+		Frequency:          standingorder.FrequencyOnce,
+		StartDate:          "2020-01-01",
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Start date must not be in the past"), err)
+}
+
+func TestGetStandingOrder_NotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(new(config.Configs), cbsService, orderRepo, unitOfWork, notificationSvc, nil)
+	)
+
+	orderRepo.EXPECT().GetByUUID(mock.Anything, "so-123").
+		Return(standingorder.StandingOrder{
+			UUID:     "so-123",
+			Username: "janedoe",
+		}, nil)
+
+	res, err := uc.GetStandingOrder(ctx, &GetRequest{UUID: "so-123"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Standing order not found"), err)
+}
+
+func TestExecuteDue_CbsNotReady(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(new(config.Configs), cbsService, orderRepo, unitOfWork, notificationSvc, nil)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      true,
+			IsStandIn:  false,
+		}, nil)
+
+	err := uc.ExecuteDue(context.Background())
+
+	assert.NoError(t, err)
+	orderRepo.AssertNotCalled(t, "ClaimDue", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecuteDue_Success(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, orderRepo, unitOfWork, notificationSvc, transferUc)
+
+	runDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	order := standingorder.StandingOrder{
+		UUID:               "so-123",
+		Username:           "johndoe",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             2500000,
+		Frequency:          standingorder.FrequencyMonthly,
+		StartDate:          runDate,
+		NextRunDate:        runDate,
+		Status:             standingorder.StatusActive,
+		ClaimedUntil:       time.Now().Add(claimLease),
+	}
+	txUUID := runTransferUUID(order)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-01-31",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+	orderRepo.EXPECT().ClaimDue(mock.Anything, mock.Anything, claimLease).
+		Return([]standingorder.StandingOrder{order}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": txUUID}).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{AccountNumber: "123", AvailableBalance: 10000000}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{AccountNumber: "456"}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", txUUID, int64(2500000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == txUUID && tx.Origin == transaction.OriginStandingOrder
+	})).Return(nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, txUUID).
+		Return(transaction.Transaction{
+			UUID:               txUUID,
+			Status:             transaction.StatusInitiated,
+			Rail:               domaintransfer.RailInternal,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             2500000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, txUUID, mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               txUUID,
+			Status:             transaction.StatusPending,
+			Rail:               domaintransfer.RailInternal,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             2500000,
+		}, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(2500000), mock.Anything).
+		Return(domaintransfer.Transfer{TransactionReference: "ref-123"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
+		Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, txUUID).
+		Return(nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	orderRepo.EXPECT().CreateExecution(mock.Anything, mock.MatchedBy(func(execution standingorder.Execution) bool {
+		return execution.Status == standingorder.ExecutionStatusCompleted && execution.TransactionUUID == txUUID
+	})).Return(nil)
+	orderRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(order standingorder.StandingOrder) bool {
+		return order.NextRunDate.Equal(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)) &&
+			order.Attempts == 0 &&
+			order.ClaimedUntil.IsZero()
+	})).Return(nil)
+
+	err := uc.ExecuteDue(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestExecuteDue_RunAlreadyTransferred(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		transferSvc     = domaintransfer.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t), transferSvc,
+		domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t), domaintransfer.NewMockRTGSService(t),
+		standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, orderRepo, unitOfWork, notificationSvc, transferUc)
+
+	runDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	order := standingorder.StandingOrder{
+		UUID:               "so-123",
+		Username:           "johndoe",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             2500000,
+		Frequency:          standingorder.FrequencyMonthly,
+		StartDate:          runDate,
+		NextRunDate:        runDate,
+		Status:             standingorder.StatusActive,
+	}
+	txUUID := runTransferUUID(order)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-31"}, nil)
+	orderRepo.EXPECT().ClaimDue(mock.Anything, mock.Anything, claimLease).
+		Return([]standingorder.StandingOrder{order}, nil)
+	// The run was transferred by a scheduler that failed to record it.
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": txUUID}).
+		Return([]transaction.Transaction{{
+			UUID:   txUUID,
+			Status: transaction.StatusCompleted,
+			Rail:   domaintransfer.RailInternal,
+		}}, nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	orderRepo.EXPECT().CreateExecution(mock.Anything, mock.MatchedBy(func(execution standingorder.Execution) bool {
+		return execution.Status == standingorder.ExecutionStatusCompleted && execution.TransactionUUID == txUUID
+	})).Return(nil)
+	orderRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(order standingorder.StandingOrder) bool {
+		return order.NextRunDate.Equal(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+	})).Return(nil)
+
+	err := uc.ExecuteDue(context.Background())
+
+	assert.NoError(t, err)
+	transferSvc.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecuteDue_AttemptsExhausted(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+	)
+
+	log.Configure("test")
+
+	cfg.StandingOrder.MaxAttempts = 2
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t),
+		domaintransfer.NewMockService(t), domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t),
+		domaintransfer.NewMockRTGSService(t), standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, orderRepo, unitOfWork, notificationSvc, transferUc)
+
+	runDate := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-01-10",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+	orderRepo.EXPECT().ClaimDue(mock.Anything, mock.Anything, claimLease).
+		Return([]standingorder.StandingOrder{
+			{
+				UUID:               "so-123",
+				Username:           "johndoe",
+				SourceAccount:      "123",
+				DestinationAccount: "456",
+				Amount:             2500000,
+				Frequency:          standingorder.FrequencyOnce,
+				StartDate:          runDate,
+				NextRunDate:        runDate,
+				Status:             standingorder.StatusActive,
+				Attempts:           1,
+			},
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{}, errors.New("mock error"))
+	notificationSvc.EXPECT().Send(mock.Anything, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Username == "johndoe"
+	})).Return(nil)
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	orderRepo.EXPECT().CreateExecution(mock.Anything, mock.MatchedBy(func(execution standingorder.Execution) bool {
+		return execution.Status == standingorder.ExecutionStatusFailed && execution.Attempt == 2
+	})).Return(nil)
+	orderRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(order standingorder.StandingOrder) bool {
+		return order.Status == standingorder.StatusFinished
+	})).Return(nil)
+
+	err := uc.ExecuteDue(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestExecuteDue_DefaultMaxAttempts(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+	)
+
+	log.Configure("test")
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t),
+		domaintransfer.NewMockService(t), domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t),
+		domaintransfer.NewMockRTGSService(t), standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, orderRepo, unitOfWork, notificationSvc, transferUc)
+
+	runDate := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-10"}, nil)
+	orderRepo.EXPECT().ClaimDue(mock.Anything, mock.Anything, claimLease).
+		Return([]standingorder.StandingOrder{
+			{
+				UUID:               "so-123",
+				Username:           "johndoe",
+				SourceAccount:      "123",
+				DestinationAccount: "456",
+				Amount:             2500000,
+				Frequency:          standingorder.FrequencyOnce,
+				StartDate:          runDate,
+				NextRunDate:        runDate,
+				Status:             standingorder.StatusActive,
+			},
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{}, errors.New("mock error"))
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	orderRepo.EXPECT().CreateExecution(mock.Anything, mock.Anything).
+		Return(nil)
+	orderRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(order standingorder.StandingOrder) bool {
+		return order.Status == standingorder.StatusActive && order.Attempts == 1 && order.NextRunDate.Equal(runDate)
+	})).Return(nil)
+
+	err := uc.ExecuteDue(context.Background())
+
+	assert.NoError(t, err)
+	notificationSvc.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestExecuteDue_RecordExecutionFailed(t *testing.T) {
+	var (
+		cfg             = new(config.Configs)
+		cbsService      = cbs.NewMockService(t)
+		orderRepo       = standingorder.NewMockRepository(t)
+		unitOfWork      = uow.NewMockUnitOfWork(t)
+		notificationSvc = notification.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+	)
+
+	log.Configure("test")
+
+	transferUc := transfer.NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiary.NewMockRepository(t),
+		domaintransfer.NewMockService(t), domaintransfer.NewMockBIFastService(t), domaintransfer.NewMockSKNService(t),
+		domaintransfer.NewMockRTGSService(t), standin.NewMockRepository(t), notificationSvc, uow.NewMockUnitOfWork(t))
+	uc := NewUsecase(cfg, cbsService, orderRepo, unitOfWork, notificationSvc, transferUc)
+
+	runDate := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-01-10"}, nil)
+	orderRepo.EXPECT().ClaimDue(mock.Anything, mock.Anything, claimLease).
+		Return([]standingorder.StandingOrder{
+			{
+				UUID:               "so-123",
+				Username:           "johndoe",
+				SourceAccount:      "123",
+				DestinationAccount: "456",
+				Amount:             2500000,
+				Frequency:          standingorder.FrequencyOnce,
+				StartDate:          runDate,
+				NextRunDate:        runDate,
+				Status:             standingorder.StatusActive,
+			},
+		}, nil)
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{}, errors.New("mock error"))
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	orderRepo.EXPECT().CreateExecution(mock.Anything, mock.Anything).
+		Return(errors.New("db error"))
+
+	err := uc.ExecuteDue(context.Background())
+
+	assert.NoError(t, err)
+	orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
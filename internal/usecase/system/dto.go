@@ -0,0 +1,26 @@
+package system
+
+import (
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+)
+
+type StatusResponse struct {
+	SystemDate string `json:"system_date"`
+	// Available is false while transactions are refused for the end of day.
+	Available bool `json:"available"`
+	EOD       bool `json:"eod"`
+	// StandInLimit is the largest transfer accepted during the end of day, zero when none are.
+	StandInLimit int64 `json:"stand_in_limit"`
+	// MaintenanceStart and MaintenanceEnd are the end of day in progress or the next one.
+	MaintenanceStart time.Time `json:"maintenance_start"`
+	MaintenanceEnd   time.Time `json:"maintenance_end"`
+}
+
+// Readiness is the core banking system status a request was checked against.
+type Readiness struct {
+	Status cbs.Status
+	// RetryAfter estimates when the end of day is over, zero outside the end of day.
+	RetryAfter time.Duration
+}
@@ -0,0 +1,87 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+)
+
+// Usecase defines the use case for the availability of the core banking system.
+type Usecase struct {
+	schedule     cbs.Schedule
+	standInLimit int64
+	cbsSvc       cbs.Service
+}
+
+func NewUsecase(cfg *config.Configs, cbsSvc cbs.Service) *Usecase {
+	return &Usecase{
+		schedule: cbs.Schedule{
+			Start:    cfg.CBS.EODStart,
+			Duration: cfg.CBS.EODDuration,
+		},
+		standInLimit: cfg.StandIn.Limit,
+		cbsSvc:       cbsSvc,
+	}
+}
+
+// GetStatus returns whether transactions are accepted and the end of day window
+// clients announce ahead of time.
+func (uc *Usecase) GetStatus(ctx context.Context) (*StatusResponse, error) {
+	l := log.WithContext(ctx, "GetStatus")
+
+	status, err := uc.cbsSvc.GetStatus(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to Get CBS status")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	now := time.Now()
+	start, end := uc.schedule.Window(now)
+	if status.IsEOD {
+		// The end of day may start early or overrun its window.
+		if start.After(now) {
+			start = now
+		}
+		end = now.Add(uc.schedule.RetryAfter(now))
+	}
+
+	return &StatusResponse{
+		SystemDate:       status.SystemDate,
+		Available:        !status.NotReady(),
+		EOD:              status.IsEOD,
+		StandInLimit:     uc.standInLimit,
+		MaintenanceStart: start,
+		MaintenanceEnd:   end,
+	}, nil
+}
+
+// CheckReady returns the core banking system status for a request that moves money.
+// While the end of day runs without stand-in it returns a ServiceUnavailable error,
+// unless standIn is set and the stand-in is enabled, the request is then queued by its usecase.
+func (uc *Usecase) CheckReady(ctx context.Context, standIn bool) (*Readiness, error) {
+	l := log.WithContext(ctx, "CheckReady")
+
+	status, err := uc.cbsSvc.GetStatus(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to Get CBS status")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	r := &Readiness{Status: status}
+	if !status.NotReady() {
+		return r, nil
+	}
+	r.RetryAfter = uc.schedule.RetryAfter(time.Now())
+	if standIn && uc.standInLimit > 0 {
+		return r, nil
+	}
+	l.Error().
+		Bool("is_eod", status.IsEOD).
+		Bool("is_stand_in", status.IsStandIn).
+		Msg("CBS is not ready for transactions")
+	return r, pkgerror.ServiceUnavailable().SetMsg("Transactions are unavailable during the end of day process, please try again later")
+}
@@ -0,0 +1,128 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+)
+
+func newTestConfig() *config.Configs {
+	cfg := new(config.Configs)
+	cfg.CBS.EODStart = 23 * time.Hour
+	cfg.CBS.EODDuration = 2 * time.Hour
+	return cfg
+}
+
+func TestGetStatus_Available(t *testing.T) {
+	var (
+		cbsService = cbs.NewMockService(t)
+		uc         = NewUsecase(newTestConfig(), cbsService)
+	)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+
+	res, err := uc.GetStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, res.Available)
+	assert.Equal(t, "2025-08-21", res.SystemDate)
+	assert.Equal(t, 2*time.Hour, res.MaintenanceEnd.Sub(res.MaintenanceStart))
+	assert.True(t, res.MaintenanceEnd.After(time.Now()))
+	assert.Equal(t, 23, res.MaintenanceStart.Hour())
+}
+
+func TestGetStatus_EOD(t *testing.T) {
+	var (
+		cbsService = cbs.NewMockService(t)
+		uc         = NewUsecase(newTestConfig(), cbsService)
+	)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+
+	res, err := uc.GetStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.False(t, res.Available)
+	assert.True(t, res.EOD)
+	assert.False(t, res.MaintenanceStart.After(time.Now()))
+	assert.True(t, res.MaintenanceEnd.After(time.Now()))
+}
+
+func TestCheckReady_Ready(t *testing.T) {
+	var (
+		cbsService = cbs.NewMockService(t)
+		uc         = NewUsecase(newTestConfig(), cbsService)
+	)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true, IsStandIn: true}, nil)
+
+	res, err := uc.CheckReady(context.Background(), false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Readiness{Status: cbs.Status{SystemDate: "2025-08-21", IsEOD: true, IsStandIn: true}}, res)
+}
+
+func TestCheckReady_EOD(t *testing.T) {
+	var (
+		cbsService = cbs.NewMockService(t)
+		uc         = NewUsecase(newTestConfig(), cbsService)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+
+	res, err := uc.CheckReady(context.Background(), true)
+
+	assert.Equal(t, pkgerror.ServiceUnavailable().SetMsg("Transactions are unavailable during the end of day process, please try again later"), err)
+	assert.GreaterOrEqual(t, res.RetryAfter, time.Minute)
+	assert.LessOrEqual(t, res.RetryAfter, 2*time.Hour)
+}
+
+func TestCheckReady_EODStandIn(t *testing.T) {
+	var (
+		cfg        = newTestConfig()
+		cbsService = cbs.NewMockService(t)
+	)
+
+	cfg.StandIn.Limit = 1000000
+	uc := NewUsecase(cfg, cbsService)
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+
+	res, err := uc.CheckReady(context.Background(), true)
+
+	assert.NoError(t, err)
+	assert.True(t, res.Status.NotReady())
+	assert.GreaterOrEqual(t, res.RetryAfter, time.Minute)
+}
+
+func TestCheckReady_GetStatusFailed(t *testing.T) {
+	var (
+		cbsService = cbs.NewMockService(t)
+		uc         = NewUsecase(newTestConfig(), cbsService)
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{}, errors.New("mock error"))
+
+	res, err := uc.CheckReady(context.Background(), false)
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.InternalServerError(), err)
+}
@@ -1,10 +1,14 @@
 package tapmoney
 
-const SuccessfulMessage = "Payment successful"
+const (
+	SuccessfulMessage = "Payment successful"
+	// PendingMessage is the message of a payment waiting for TapMoney to confirm it.
+	PendingMessage = "Payment is waiting for confirmation"
+)
 
 type InitiateRequest struct {
-	CardNumber    string `json:"card_number" validate:"required,min=16,max=19"`
-	SourceAccount string `json:"source_account" validate:"required,number"`
+	CardNumber    string `json:"card_number" validate:"required,cardnumber"`
+	SourceAccount string `json:"source_account" validate:"required,accountnumber"`
 	Amount        int64  `json:"amount" validate:"required,min=10000,max=1000000"`
 }
 
@@ -19,7 +23,7 @@ type InitiateResponse struct {
 
 type ProcessRequest struct {
 	UUID       string `param:"uuid" json:"uuid" validate:"required,uuid"`
-	CardNumber string `json:"card_number" validate:"required,min=16,max=19"`
+	CardNumber string `json:"card_number" validate:"required,cardnumber"`
 	Amount     int64  `json:"amount" validate:"required,min=10000,max=1000000"`
 	Notes      string `json:"notes" validate:"max=255"`
 }
@@ -47,3 +51,24 @@ type DetailResponse struct {
 	Notes      string `json:"notes"`
 	Fee        int64  `json:"fee"`
 }
+
+type CancelRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type CancelResponse struct {
+	UUID   string `json:"uuid"`
+	Status string `json:"status"`
+}
+
+// CallbackRequest is the outcome of a payment the TapMoney service sends back.
+type CallbackRequest struct {
+	// TransactionID is the TapMoney transaction of the payment, the payment ID of the inquiry.
+	TransactionID string `json:"transaction_id" validate:"required"`
+	Status        string `json:"status" validate:"required,oneof=success failed"`
+}
+
+type CallbackResponse struct {
+	UUID   string `json:"uuid"`
+	Status string `json:"status"`
+}
@@ -2,13 +2,16 @@ package tapmoney
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
-	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
 )
@@ -17,6 +20,18 @@ const (
 	tapMoneyChannelID       = "01"
 	tapMoneyBillerCode      = "99999"
 	tapMoneyTransactionType = "tapmoney"
+	// paymentSuccess is the status of a payment the TapMoney service has executed.
+	paymentSuccess = "success"
+)
+
+// Status reasons of TapMoney payments.
+const (
+	reasonProcessing = "Payment is being processed"
+	// reasonAwaitingConfirmation is the reason of a payment TapMoney may have executed,
+	// it is settled by the callback of TapMoney.
+	reasonAwaitingConfirmation = "Waiting for TapMoney to confirm the payment"
+	reasonFailed               = "Payment could not be processed"
+	reasonCancelled            = "Payment was cancelled by the user"
 )
 
 // tapMoneyChannel represents the payment channel for Tap Money transactions.
@@ -26,49 +41,43 @@ var tapMoneyChannel = payment.Channel{
 
 // Usecase defines the use case for handling TapMoney transactions.
 type Usecase struct {
-	cbs         cbs.Service
-	txRepo      transaction.Repository
-	paymentSvc  payment.Service
-	accountRepo account.Repository
+	initiatedTTL time.Duration
+	txRepo       transaction.Repository
+	paymentSvc   payment.Service
+	accountRepo  account.Repository
 }
 
 func NewUsecase(
-	cbs cbs.Service,
+	cfg *config.Configs,
 	txRepo transaction.Repository,
 	paymentSvc payment.Service,
 	accountRepo account.Repository) *Usecase {
 	return &Usecase{
-		cbs:         cbs,
-		txRepo:      txRepo,
-		paymentSvc:  paymentSvc,
-		accountRepo: accountRepo,
+		initiatedTTL: cfg.Transaction.InitiatedTTL,
+		txRepo:       txRepo,
+		paymentSvc:   paymentSvc,
+		accountRepo:  accountRepo,
 	}
 }
 
 func (uc *Usecase) Initiate(ctx context.Context, req *InitiateRequest) (*InitiateResponse, error) {
 	l := log.WithContext(ctx, "Initiate")
 
-	cbsStatus, err := uc.cbs.GetStatus(ctx)
+	srcAccount, err := uc.accountRepo.Get(ctx, req.SourceAccount)
 	if err != nil {
-		l.Error().Err(err).Msg("Failed to Get CBS status")
+		l.Error().Err(err).Msg("Failed to get account")
 		return nil, pkgerror.InternalServerError()
 	}
-	if cbsStatus.NotReady() {
+	if !srcAccount.CanDebit() {
 		l.Error().
-			Bool("is_eod", cbsStatus.IsEOD).
-			Bool("is_stand_in", cbsStatus.IsStandIn).
-			Msg("CBS is not ready for transactions")
-		return nil, pkgerror.InternalServerError()
-	}
-
-	srcAccount, err := uc.accountRepo.Get(ctx, req.SourceAccount)
-	if err != nil {
-		l.Error().Err(err).Msg("Failed to get pocket")
-		return nil, pkgerror.InternalServerError()
+			Str("account_number", srcAccount.AccountNumber).
+			Str("status", srcAccount.Status).
+			Msg("Account cannot be debited")
+		return nil, pkgerror.BadRequest().SetMsg("Account cannot be debited")
 	}
 	if !srcAccount.CanTransfer(req.Amount) {
 		l.Error().
-			Int64("account_balance", srcAccount.Balance).
+			Int64("available_balance", srcAccount.AvailableBalance).
 			Int64("request_amount", req.Amount).
 			Msg("Insufficient balance")
 		return nil, pkgerror.BadRequest().SetMsg("Insufficient balance")
@@ -99,12 +108,31 @@ func (uc *Usecase) Initiate(ctx context.Context, req *InitiateRequest) (*Initiat
 		Status:             transaction.StatusInitiated,
 		PaymentID:          result.ID,
 		Amount:             req.Amount,
+		Fee:                result.Bill.Fee,
 		Username:           user.Username,
 	}
 
+	err = uc.accountRepo.PlaceHold(ctx, tx.SourceAccount, tx.UUID, tx.HoldAmount())
+	if err != nil && errors.Is(err, account.ErrInsufficientBalance) {
+		l.Error().Err(err).
+			Int64("hold_amount", tx.HoldAmount()).
+			Msg("Insufficient balance")
+		return nil, pkgerror.BadRequest().SetMsg("Insufficient balance")
+	}
+	if err != nil {
+		l.Error().Err(err).Msg("Place hold failed")
+		return nil, pkgerror.InternalServerError()
+	}
+
 	err = uc.txRepo.Create(ctx, tx)
 	if err != nil {
 		l.Error().Err(err).Msg("Create transaction failed")
+		err = uc.accountRepo.ReleaseHold(ctx, tx.UUID)
+		if err != nil {
+			l.Error().Err(err).
+				Str("uuid", tx.UUID).
+				Msg("Release hold failed")
+		}
 		return nil, pkgerror.InternalServerError()
 	}
 
@@ -118,22 +146,11 @@ func (uc *Usecase) Initiate(ctx context.Context, req *InitiateRequest) (*Initiat
 	}, nil
 }
 
+// Process pays an initiated payment. A payment TapMoney may have executed stays pending with its hold
+// until the callback of TapMoney settles it, only a refused payment fails.
 func (uc *Usecase) Process(ctx context.Context, req *ProcessRequest) (*ProcessResponse, error) {
 	l := log.WithContext(ctx, "Process")
 
-	cbsStatus, err := uc.cbs.GetStatus(ctx)
-	if err != nil {
-		l.Error().Err(err).Msg("Failed to Get CBS status")
-		return nil, pkgerror.InternalServerError()
-	}
-	if cbsStatus.NotReady() {
-		l.Error().
-			Bool("is_eod", cbsStatus.IsEOD).
-			Bool("is_stand_in", cbsStatus.IsStandIn).
-			Msg("CBS is not ready for transactions")
-		return nil, pkgerror.InternalServerError()
-	}
-
 	tx, err := uc.txRepo.GetByUUID(ctx, req.UUID)
 	if err != nil {
 		l.Error().Err(err).Msg("Transaction was not found")
@@ -145,18 +162,37 @@ func (uc *Usecase) Process(ctx context.Context, req *ProcessRequest) (*ProcessRe
 			Msg("Transaction is already processed")
 		return nil, pkgerror.BadRequest().SetMsg("Transaction is already processed")
 	}
+	if tx.Expired(uc.initiatedTTL, time.Now()) {
+		l.Error().
+			Str("uuid", tx.UUID).
+			Time("created_at", tx.CreatedAt).
+			Msg("Transaction has expired")
+		err = tx.Expire()
+		if err == nil {
+			err = uc.txRepo.Update(ctx, tx)
+		}
+		if err != nil {
+			l.Error().Err(err).
+				Str("uuid", tx.UUID).
+				Msg("Update transaction failed")
+		} else {
+			uc.settleHold(ctx, tx)
+		}
+		return nil, pkgerror.BadRequest().SetMsg("Transaction has expired")
+	}
 
-	srcAccount, err := uc.accountRepo.Get(ctx, tx.SourceAccount)
-	if err != nil {
-		l.Error().Err(err).Msg("Source account was not found")
-		return nil, pkgerror.NotFound().SetMsg("Source account was not found")
+	tx, err = uc.txRepo.Claim(ctx, tx.UUID, reasonProcessing)
+	if err != nil && errors.Is(err, transaction.ErrConflict) {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Transaction is already being processed")
+		return nil, pkgerror.BadRequest().SetMsg("Transaction is already processed")
 	}
-	if !srcAccount.CanTransfer(tx.Amount) {
-		l.Error().
-			Int64("account_balance", srcAccount.Balance).
-			Int64("request_amount", tx.Amount).
-			Msg("Insufficient balance")
-		return nil, pkgerror.BadRequest().SetMsg("Insufficient balance")
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Claim transaction failed")
+		return nil, pkgerror.InternalServerError()
 	}
 
 	payResp, err := uc.paymentSvc.Payment(ctx, payment.Bill{
@@ -164,22 +200,42 @@ func (uc *Usecase) Process(ctx context.Context, req *ProcessRequest) (*ProcessRe
 		BillerCode:         tapMoneyBillerCode,
 		Amount:             tx.Amount,
 		SourceAccount:      tx.SourceAccount,
+		ReferenceID:        tx.PaymentID,
 	})
+	if err != nil && (errors.Is(err, payment.ErrOutcomeUnknown) || transfer.IsOutcomeUnknown(err)) {
+		l.Error().Err(err).
+			Str("uuid", tx.UUID).
+			Msg("Payment outcome is unknown, waiting for the callback")
+		return uc.markPending(ctx, tx)
+	}
 	if err != nil {
 		l.Error().Err(err).Msg("Payment to payment service failed")
+		err = tx.Transition(transaction.StatusFailed, reasonFailed)
+		if err == nil {
+			err = uc.txRepo.Update(ctx, tx)
+		}
+		if err != nil {
+			l.Error().Err(err).
+				Str("uuid", tx.UUID).
+				Msg("Update transaction failed")
+		} else {
+			uc.settleHold(ctx, tx)
+		}
 		return nil, pkgerror.InternalServerError()
 	}
 
-	tx.Status = transaction.StatusCompleted
 	tx.PaymentID = payResp.ID
-
-	err = uc.txRepo.Update(ctx, tx)
+	err = tx.Transition(transaction.StatusCompleted, "")
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
 	if err != nil {
 		l.Error().Err(err).
 			Str("uuid", tx.UUID).
 			Msg("Update transaction failed")
 		return nil, pkgerror.InternalServerError()
 	}
+	uc.settleHold(ctx, tx)
 
 	return &ProcessResponse{
 		UUID:       tx.UUID,
@@ -191,3 +247,172 @@ func (uc *Usecase) Process(ctx context.Context, req *ProcessRequest) (*ProcessRe
 		Fee:        tx.Fee,
 	}, nil
 }
+
+// Cancel cancels an initiated payment of the user in the context before it is processed
+// and releases its inquiry session at the payment gateway.
+func (uc *Usecase) Cancel(ctx context.Context, req *CancelRequest) (*CancelResponse, error) {
+	l := log.WithContext(ctx, "Cancel")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	tx, err := uc.txRepo.GetByUUID(ctx, req.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Transaction was not found")
+		return nil, pkgerror.NotFound().SetMsg("Transaction was not found")
+	}
+	if tx.Username != userFromCtx.Username || tx.TransactionType != tapMoneyTransactionType {
+		return nil, pkgerror.NotFound().SetMsg("Transaction was not found")
+	}
+
+	err = tx.Transition(transaction.StatusCancelled, reasonCancelled)
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil && (errors.Is(err, transaction.ErrInvalidTransition) || errors.Is(err, transaction.ErrConflict)) {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Transaction cannot be cancelled")
+		return nil, pkgerror.Conflict().SetMsg("Only initiated transactions can be cancelled")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Update transaction failed")
+		return nil, pkgerror.InternalServerError()
+	}
+	uc.settleHold(ctx, tx)
+
+	// The session also expires at the gateway, a failed release is not fatal.
+	if tx.PaymentID != "" {
+		err = uc.paymentSvc.CancelInquiry(ctx, tx.PaymentID)
+		if err != nil {
+			l.Error().Err(err).
+				Str("uuid", tx.UUID).
+				Str("payment_id", tx.PaymentID).
+				Msg("Failed to cancel payment inquiry")
+		}
+	}
+
+	return &CancelResponse{
+		UUID:   tx.UUID,
+		Status: tx.Status,
+	}, nil
+}
+
+// Callback settles a pending payment with the outcome the TapMoney service sends back.
+// A callback repeating the outcome of a settled payment is answered again.
+func (uc *Usecase) Callback(ctx context.Context, req *CallbackRequest) (*CallbackResponse, error) {
+	l := log.WithContext(ctx, "Callback")
+
+	txs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+		"payment_id":       req.TransactionID,
+		"transaction_type": tapMoneyTransactionType,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("payment_id", req.TransactionID).
+			Msg("Failed to get transaction")
+		return nil, pkgerror.InternalServerError()
+	}
+	if len(txs) == 0 {
+		l.Error().
+			Str("payment_id", req.TransactionID).
+			Msg("Transaction was not found")
+		return nil, pkgerror.NotFound().SetMsg("Transaction was not found")
+	}
+	tx := txs[0]
+
+	status, reason := transaction.StatusCompleted, ""
+	if req.Status != paymentSuccess {
+		status, reason = transaction.StatusFailed, reasonFailed
+	}
+	if tx.Status == status {
+		return &CallbackResponse{
+			UUID:   tx.UUID,
+			Status: tx.Status,
+		}, nil
+	}
+	if tx.Status != transaction.StatusPending {
+		l.Error().
+			Str("uuid", tx.UUID).
+			Str("transaction_status", tx.Status).
+			Str("payment_status", req.Status).
+			Msg("Callback does not match the settled transaction")
+		return nil, pkgerror.Conflict().SetMsg("Transaction is already settled")
+	}
+
+	err = tx.Transition(status, reason)
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil && errors.Is(err, transaction.ErrConflict) {
+		l.Error().Err(err).
+			Str("uuid", tx.UUID).
+			Msg("Transaction is being settled")
+		return nil, pkgerror.Conflict().SetMsg("Transaction is already settled")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", tx.UUID).
+			Msg("Update transaction failed")
+		return nil, pkgerror.InternalServerError()
+	}
+	uc.settleHold(ctx, tx)
+
+	return &CallbackResponse{
+		UUID:   tx.UUID,
+		Status: tx.Status,
+	}, nil
+}
+
+// markPending keeps a claimed payment pending with its hold until TapMoney confirms it.
+func (uc *Usecase) markPending(ctx context.Context, tx transaction.Transaction) (*ProcessResponse, error) {
+	l := log.WithContext(ctx, "markPending")
+
+	tx.StatusReason = reasonAwaitingConfirmation
+	err := uc.txRepo.Update(ctx, tx)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", tx.UUID).
+			Msg("Update transaction failed")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return &ProcessResponse{
+		UUID:       tx.UUID,
+		Message:    PendingMessage,
+		Status:     tx.Status,
+		Amount:     tx.Amount,
+		CardNumber: tx.DestinationAccount,
+		Notes:      tx.Note,
+		Fee:        tx.Fee,
+	}, nil
+}
+
+// settleHold captures the amount held for a completed payment
+// and releases it for a payment that moved no money.
+func (uc *Usecase) settleHold(ctx context.Context, tx transaction.Transaction) {
+	l := log.WithContext(ctx, "settleHold")
+
+	var err error
+	switch tx.Status {
+	case transaction.StatusCompleted:
+		err = uc.accountRepo.CaptureHold(ctx, tx.UUID)
+	case transaction.StatusFailed, transaction.StatusCancelled, transaction.StatusExpired:
+		err = uc.accountRepo.ReleaseHold(ctx, tx.UUID)
+	default:
+		return
+	}
+	if err != nil && !errors.Is(err, account.ErrHoldNotFound) {
+		l.Error().Err(err).
+			Str("uuid", tx.UUID).
+			Str("status", tx.Status).
+			Msg("Settle hold failed")
+	}
+}
@@ -3,46 +3,47 @@ package tapmoney
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
-	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/payment"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
 )
 
 func TestInitiate_Success(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
 	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
 		Return(account.Account{
-			Balance:       1000000,
-			AccountNumber: "123",
+			AvailableBalance: 1000000,
+			AccountNumber:    "123",
 		}, nil)
 	paymentSvc.EXPECT().Inquiry(mock.Anything, mock.Anything, mock.Anything).
 		Return(payment.Payment{
 			ID: "pay-123",
 		}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(nil)
 	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
 		Return(nil)
 
-	resp, err := uc.Initiate(context.Background(), &InitiateRequest{
+	resp, err := uc.Initiate(ctx, &InitiateRequest{
 		CardNumber:    "6013501000500719",
 		SourceAccount: "123",
 		Amount:        10000,
@@ -50,61 +51,25 @@ func TestInitiate_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
-	assert.Equal(t, "inq-success", resp.Status)
+	assert.Equal(t, transaction.StatusInitiated, resp.Status)
 
 	t.Log(resp)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestInitiate_GetCbsFailed(t *testing.T) {
-	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		paymentSvc  = payment.NewMockService(t)
-		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
-	)
-
-	log.Configure("test")
-
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{}, errors.New("get cbs status failed"))
-
-	resp, err := uc.Initiate(context.Background(), &InitiateRequest{
-		CardNumber:    "6013501000500719",
-		SourceAccount: "123",
-		Amount:        10000,
-	})
-
-	assert.Nil(t, resp)
-	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
-
-	txRepo.AssertExpectations(t)
-	paymentSvc.AssertExpectations(t)
-}
-
-func TestInitiate_CbsNotReady(t *testing.T) {
+func TestInitiate_GetAccountFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	log.Configure("development")
-
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2030-01-01",
-			IsEOD:      true,
-			IsStandIn:  false,
-		}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
+		Return(account.Account{}, errors.New("account not found"))
 
 	resp, err := uc.Initiate(context.Background(), &InitiateRequest{
 		CardNumber:    "6013501000500719",
@@ -118,64 +83,55 @@ func TestInitiate_CbsNotReady(t *testing.T) {
 
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
+	accountRepo.AssertExpectations(t)
 }
 
-func TestInitiate_GetAccountFailed(t *testing.T) {
+func TestInitiate_AccountInsufficientBalance(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
-
 	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
-		Return(account.Account{}, errors.New("account not found"))
+		Return(account.Account{
+			AvailableBalance: 5000,
+			AccountNumber:    "123",
+		}, nil)
 
 	resp, err := uc.Initiate(context.Background(), &InitiateRequest{
 		CardNumber:    "6013501000500719",
-		SourceAccount: "123",
+		SourceAccount: "321",
 		Amount:        10000,
 	})
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Insufficient balance"), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestInitiate_AccountInsufficientBalance(t *testing.T) {
+func TestInitiate_FailedToInitiatePayment(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
 	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
 		Return(account.Account{
-			Balance:       5000,
-			AccountNumber: "123",
+			AvailableBalance: 5000000,
+			AccountNumber:    "123",
 		}, nil)
 
+	paymentSvc.EXPECT().Inquiry(mock.Anything, mock.Anything, mock.Anything).
+		Return(payment.Payment{}, errors.New("Initiate failed"))
+
 	resp, err := uc.Initiate(context.Background(), &InitiateRequest{
 		CardNumber:    "6013501000500719",
 		SourceAccount: "321",
@@ -184,83 +140,80 @@ func TestInitiate_AccountInsufficientBalance(t *testing.T) {
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
-	assert.Equal(t, pkgerror.BadRequest().SetMsg("Insufficient balance"), err)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Inquiry failed"), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestInitiate_FailedToInitiatePayment(t *testing.T) {
+func TestInitiate_FailedCreateTransaction(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
-
 	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
 		Return(account.Account{
-			Balance:       5000000,
-			AccountNumber: "123",
+			AvailableBalance: 1000000,
+			AccountNumber:    "123",
 		}, nil)
-
 	paymentSvc.EXPECT().Inquiry(mock.Anything, mock.Anything, mock.Anything).
-		Return(payment.Payment{}, errors.New("Initiate failed"))
+		Return(payment.Payment{
+			ID: "pay-123",
+		}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(errors.New("failed to create transaction"))
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, mock.Anything).
+		Return(nil)
 
-	resp, err := uc.Initiate(context.Background(), &InitiateRequest{
+	resp, err := uc.Initiate(ctx, &InitiateRequest{
 		CardNumber:    "6013501000500719",
-		SourceAccount: "321",
+		SourceAccount: "123",
 		Amount:        10000,
 	})
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
-	assert.Equal(t, pkgerror.BadRequest().SetMsg("Initiate failed"), err)
+	assert.Equal(t, pkgerror.InternalServerError(), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestInitiate_FailedCreateTransaction(t *testing.T) {
+func TestInitiate_HoldInsufficientBalance(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
 	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
 		Return(account.Account{
-			Balance:       1000000,
-			AccountNumber: "123",
+			AvailableBalance: 1000000,
+			AccountNumber:    "123",
 		}, nil)
 	paymentSvc.EXPECT().Inquiry(mock.Anything, mock.Anything, mock.Anything).
 		Return(payment.Payment{
-			ID: "pay-123",
+			ID:   "pay-123",
+			Bill: payment.Bill{Fee: 1500},
 		}, nil)
-	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
-		Return(errors.New("failed to create transaction"))
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(11500)).
+		Return(account.ErrInsufficientBalance)
 
-	resp, err := uc.Initiate(context.Background(), &InitiateRequest{
+	resp, err := uc.Initiate(ctx, &InitiateRequest{
 		CardNumber:    "6013501000500719",
 		SourceAccount: "123",
 		Amount:        10000,
@@ -268,9 +221,8 @@ func TestInitiate_FailedCreateTransaction(t *testing.T) {
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Insufficient balance"), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
@@ -278,40 +230,42 @@ func TestInitiate_FailedCreateTransaction(t *testing.T) {
 
 func TestPayment_Success(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
 	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
 		Return(transaction.Transaction{
 			UUID:               "trx-123",
 			SourceAccount:      "001201001479315",
 			DestinationAccount: "6013501000500719",
 			Amount:             10000,
-			Status:             "pending",
+			Status:             transaction.StatusInitiated,
 			Note:               "test",
 			Fee:                1500,
 		}, nil)
-	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
-		Return(account.Account{
-			Balance:       1000000,
-			AccountNumber: "001201001479315",
-		}, nil)
-	paymentSvc.EXPECT().Payment(mock.Anything, mock.Anything).
-		Return(payment.Payment{
-			Status: "success",
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               "trx-123",
+			SourceAccount:      "001201001479315",
+			DestinationAccount: "6013501000500719",
+			Amount:             10000,
+			Status:             transaction.StatusPending,
+			PaymentID:          "pay-123",
+			Note:               "test",
+			Fee:                1500,
 		}, nil)
+	paymentSvc.EXPECT().Payment(mock.Anything, mock.MatchedBy(func(bill payment.Bill) bool {
+		return bill.ReferenceID == "pay-123" && bill.Amount == 10000
+	})).Return(payment.Payment{
+		Status: "success",
+	}, nil)
 	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
 		Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, "trx-123").
+		Return(nil)
 
 	resp, err := uc.Process(context.Background(), &ProcessRequest{
 		UUID:   "trx-123",
@@ -323,7 +277,7 @@ func TestPayment_Success(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, "6013501000500719", resp.CardNumber)
 	assert.Equal(t, "trx-123", resp.UUID)
-	assert.Equal(t, "inq-success", resp.Status)
+	assert.Equal(t, transaction.StatusCompleted, resp.Status)
 	assert.Equal(t, "Payment successful", resp.Message)
 	assert.Equal(t, int64(10000), resp.Amount)
 	assert.Equal(t, int64(1500), resp.Fee)
@@ -331,87 +285,19 @@ func TestPayment_Success(t *testing.T) {
 
 	t.Log(resp)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestPayment_GetCbsFailed(t *testing.T) {
-	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		paymentSvc  = payment.NewMockService(t)
-		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
-	)
-
-	log.Configure("test")
-
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{}, errors.New("get cbs status failed"))
-
-	resp, err := uc.Process(context.Background(), &ProcessRequest{
-		UUID:   "trx-123",
-		Amount: 10000,
-		Notes:  "test",
-	})
-
-	assert.Nil(t, resp)
-	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
-
-	txRepo.AssertExpectations(t)
-	paymentSvc.AssertExpectations(t)
-}
-
-func TestPayment_CbsNotReady(t *testing.T) {
-	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		paymentSvc  = payment.NewMockService(t)
-		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
-	)
-
-	log.Configure("development")
-
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2030-01-01",
-			IsEOD:      true,
-			IsStandIn:  false,
-		}, nil)
-
-	resp, err := uc.Process(context.Background(), &ProcessRequest{
-		UUID:   "trx-123",
-		Amount: 10000,
-		Notes:  "test",
-	})
-
-	assert.Nil(t, resp)
-	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
-
-	txRepo.AssertExpectations(t)
-	paymentSvc.AssertExpectations(t)
-}
-
 func TestPayment_TransactionNotFound(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
 	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
 		Return(transaction.Transaction{}, errors.New("transaction not found"))
 
@@ -425,7 +311,6 @@ func TestPayment_TransactionNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, pkgerror.NotFound().SetMsg("Transaction was not found"), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
@@ -433,19 +318,12 @@ func TestPayment_TransactionNotFound(t *testing.T) {
 
 func TestPayment_TransactionAlreadyProcessed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
 	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
 		Return(transaction.Transaction{
 			UUID:               "trx-123",
@@ -467,27 +345,21 @@ func TestPayment_TransactionAlreadyProcessed(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, pkgerror.BadRequest().SetMsg("Transaction is already processed"), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestPayment_FailedToGetSourceAccount(t *testing.T) {
+func TestPayment_TransactionExpired(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
+		cfg         = new(config.Configs)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
+	cfg.Transaction.InitiatedTTL = 15 * time.Minute
+	uc := NewUsecase(cfg, txRepo, paymentSvc, accountRepo)
 
 	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
 		Return(transaction.Transaction{
@@ -495,63 +367,62 @@ func TestPayment_FailedToGetSourceAccount(t *testing.T) {
 			SourceAccount:      "001201001479315",
 			DestinationAccount: "6013501000500719",
 			Amount:             10000,
-			Status:             "pending",
-			Note:               "test",
-			Fee:                1500,
+			Status:             transaction.StatusInitiated,
+			CreatedAt:          time.Now().Add(-time.Hour),
 		}, nil)
-
-	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
-		Return(account.Account{}, errors.New("account not found"))
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusExpired && tx.StatusReason == transaction.ReasonExpired
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "trx-123").
+		Return(nil)
 
 	resp, err := uc.Process(context.Background(), &ProcessRequest{
 		UUID:   "trx-123",
 		Amount: 10000,
-		Notes:  "test",
 	})
 
 	assert.Nil(t, resp)
-	assert.Error(t, err)
-	assert.Equal(t, pkgerror.NotFound().SetMsg("Source account was not found"), err)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Transaction has expired"), err)
 
-	cbsService.AssertExpectations(t)
-	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestPayment_InsufficientBalance(t *testing.T) {
+func TestPayment_FailedToProcessPayment(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
-
 	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
 		Return(transaction.Transaction{
 			UUID:               "trx-123",
 			SourceAccount:      "001201001479315",
 			DestinationAccount: "6013501000500719",
 			Amount:             10000,
-			Status:             "pending",
+			Status:             transaction.StatusInitiated,
 			Note:               "test",
-			Fee:                1500,
 		}, nil)
-
-	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
-		Return(account.Account{
-			Balance:       5000,
-			AccountNumber: "001201001479315",
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               "trx-123",
+			SourceAccount:      "001201001479315",
+			DestinationAccount: "6013501000500719",
+			Amount:             10000,
+			Status:             transaction.StatusPending,
+			Note:               "test",
 		}, nil)
 
+	paymentSvc.EXPECT().Payment(mock.Anything, mock.Anything).
+		Return(payment.Payment{}, errors.New("payment failed"))
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusFailed && tx.StatusReason == reasonFailed
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "trx-123").
+		Return(nil)
+
 	resp, err := uc.Process(context.Background(), &ProcessRequest{
 		UUID:   "trx-123",
 		Amount: 10000,
@@ -560,48 +431,105 @@ func TestPayment_InsufficientBalance(t *testing.T) {
 
 	assert.Nil(t, resp)
 	assert.Error(t, err)
-	assert.Equal(t, pkgerror.BadRequest().SetMsg("Insufficient balance"), err)
+	assert.Equal(t, pkgerror.InternalServerError(), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestPayment_FailedToProcessPayment(t *testing.T) {
+func TestPayment_OutcomeUnknown(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "server_error", err: fmt.Errorf("%w: tapmoney: 503 Service Unavailable", payment.ErrOutcomeUnknown)},
+		{name: "timeout", err: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				txRepo      = transaction.NewMockRepository(t)
+				paymentSvc  = payment.NewMockService(t)
+				accountRepo = account.NewMockRepository(t)
+				uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
+			)
+
+			log.Configure("test")
+
+			txRepo.EXPECT().GetByUUID(mock.Anything, "trx-123").
+				Return(transaction.Transaction{
+					UUID:               "trx-123",
+					SourceAccount:      "001201001479315",
+					DestinationAccount: "6013501000500719",
+					Amount:             10000,
+					Status:             transaction.StatusInitiated,
+				}, nil)
+			txRepo.EXPECT().Claim(mock.Anything, "trx-123", reasonProcessing).
+				Return(transaction.Transaction{
+					UUID:               "trx-123",
+					SourceAccount:      "001201001479315",
+					DestinationAccount: "6013501000500719",
+					Amount:             10000,
+					Status:             transaction.StatusPending,
+				}, nil)
+			paymentSvc.EXPECT().Payment(mock.Anything, mock.Anything).
+				Return(payment.Payment{}, tt.err)
+			txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+				return tx.Status == transaction.StatusPending && tx.StatusReason == reasonAwaitingConfirmation
+			})).Return(nil)
+
+			resp, err := uc.Process(context.Background(), &ProcessRequest{
+				UUID:   "trx-123",
+				Amount: 10000,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, transaction.StatusPending, resp.Status)
+			assert.Equal(t, PendingMessage, resp.Message)
+			accountRepo.AssertNotCalled(t, "ReleaseHold", mock.Anything, mock.Anything)
+			accountRepo.AssertNotCalled(t, "CaptureHold", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPayment_FailedToUpdateTransaction(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
-
 	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
 		Return(transaction.Transaction{
 			UUID:               "trx-123",
 			SourceAccount:      "001201001479315",
 			DestinationAccount: "6013501000500719",
 			Amount:             10000,
-			Status:             "pending",
+			Status:             transaction.StatusInitiated,
 			Note:               "test",
+			Fee:                1500,
 		}, nil)
-
-	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
-		Return(account.Account{
-			Balance:       1000000,
-			AccountNumber: "001201001479315",
+	txRepo.EXPECT().Claim(mock.Anything, mock.Anything, mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               "trx-123",
+			SourceAccount:      "001201001479315",
+			DestinationAccount: "6013501000500719",
+			Amount:             10000,
+			Status:             transaction.StatusPending,
+			Note:               "test",
+			Fee:                1500,
 		}, nil)
 
 	paymentSvc.EXPECT().Payment(mock.Anything, mock.Anything).
-		Return(payment.Payment{}, errors.New("payment failed"))
+		Return(payment.Payment{
+			Status: "success",
+		}, nil)
+
+	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
+		Return(errors.New("failed to update transaction"))
 
 	resp, err := uc.Process(context.Background(), &ProcessRequest{
 		UUID:   "trx-123",
@@ -613,65 +541,212 @@ func TestPayment_FailedToProcessPayment(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, pkgerror.InternalServerError(), err)
 
-	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	paymentSvc.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
-func TestPayment_FailedToUpdateTransaction(t *testing.T) {
+func TestCancel_Success(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
 		txRepo      = transaction.NewMockRepository(t)
 		paymentSvc  = payment.NewMockService(t)
 		accountRepo = account.NewMockRepository(t)
-		uc          = NewUsecase(cbsService, txRepo, paymentSvc, accountRepo)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
 	)
 
-	cbsService.EXPECT().GetStatus(mock.Anything).
-		Return(cbs.Status{
-			SystemDate: "2025-08-21",
-			IsEOD:      false,
-			IsStandIn:  false,
-		}, nil)
+	log.Configure("test")
 
-	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
+	txRepo.EXPECT().GetByUUID(mock.Anything, "trx-123").
 		Return(transaction.Transaction{
-			UUID:               "trx-123",
-			SourceAccount:      "001201001479315",
-			DestinationAccount: "6013501000500719",
-			Amount:             10000,
-			Status:             "pending",
-			Note:               "test",
-			Fee:                1500,
-		}, nil)
+			UUID:            "trx-123",
+			TransactionType: "tapmoney",
+			Status:          transaction.StatusInitiated,
+			PaymentID:       "pay-123",
+			Username:        "johndoe",
+		}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusCancelled
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "trx-123").
+		Return(nil)
+	paymentSvc.EXPECT().CancelInquiry(mock.Anything, "pay-123").
+		Return(errors.New("gateway timeout"))
 
-	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
-		Return(account.Account{
-			Balance:       1000000,
-			AccountNumber: "001201001479315",
+	resp, err := uc.Cancel(ctx, &CancelRequest{UUID: "trx-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &CancelResponse{UUID: "trx-123", Status: transaction.StatusCancelled}, resp)
+}
+
+func TestCancel_NotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		paymentSvc  = payment.NewMockService(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "trx-123").
+		Return(transaction.Transaction{
+			UUID:            "trx-123",
+			TransactionType: "tapmoney",
+			Status:          transaction.StatusInitiated,
+			Username:        "janedoe",
 		}, nil)
 
-	paymentSvc.EXPECT().Payment(mock.Anything, mock.Anything).
-		Return(payment.Payment{
-			Status: "success",
+	resp, err := uc.Cancel(ctx, &CancelRequest{UUID: "trx-123"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Transaction was not found"), err)
+}
+
+func TestCancel_AlreadyProcessed(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		paymentSvc  = payment.NewMockService(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "trx-123").
+		Return(transaction.Transaction{
+			UUID:            "trx-123",
+			TransactionType: "tapmoney",
+			Status:          transaction.StatusPending,
+			Username:        "johndoe",
 		}, nil)
 
-	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
-		Return(errors.New("failed to update transaction"))
+	resp, err := uc.Cancel(ctx, &CancelRequest{UUID: "trx-123"})
 
-	resp, err := uc.Process(context.Background(), &ProcessRequest{
-		UUID:   "trx-123",
-		Amount: 10000,
-		Notes:  "test",
-	})
+	assert.Nil(t, resp)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Only initiated transactions can be cancelled"), err)
+}
+
+func TestCallback_Settles(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		wantStatus string
+		settle     func(accountRepo *account.MockRepository)
+	}{
+		{
+			name:       "success",
+			status:     "success",
+			wantStatus: transaction.StatusCompleted,
+			settle: func(accountRepo *account.MockRepository) {
+				accountRepo.EXPECT().CaptureHold(mock.Anything, "trx-123").Return(nil)
+			},
+		},
+		{
+			name:       "failed",
+			status:     "failed",
+			wantStatus: transaction.StatusFailed,
+			settle: func(accountRepo *account.MockRepository) {
+				accountRepo.EXPECT().ReleaseHold(mock.Anything, "trx-123").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				txRepo      = transaction.NewMockRepository(t)
+				paymentSvc  = payment.NewMockService(t)
+				accountRepo = account.NewMockRepository(t)
+				uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
+			)
+
+			txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{
+				"payment_id":       "pay-123",
+				"transaction_type": "tapmoney",
+			}).Return([]transaction.Transaction{{
+				UUID:      "trx-123",
+				PaymentID: "pay-123",
+				Status:    transaction.StatusPending,
+			}}, nil)
+			txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+				return tx.Status == tt.wantStatus
+			})).Return(nil)
+			tt.settle(accountRepo)
+
+			resp, err := uc.Callback(context.Background(), &CallbackRequest{TransactionID: "pay-123", Status: tt.status})
+
+			assert.NoError(t, err)
+			assert.Equal(t, &CallbackResponse{UUID: "trx-123", Status: tt.wantStatus}, resp)
+		})
+	}
+}
+
+func TestCallback_Repeated(t *testing.T) {
+	var (
+		txRepo      = transaction.NewMockRepository(t)
+		paymentSvc  = payment.NewMockService(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
+	)
+
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return([]transaction.Transaction{{
+			UUID:   "trx-123",
+			Status: transaction.StatusCompleted,
+		}}, nil)
+
+	resp, err := uc.Callback(context.Background(), &CallbackRequest{TransactionID: "pay-123", Status: "success"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &CallbackResponse{UUID: "trx-123", Status: transaction.StatusCompleted}, resp)
+}
+
+func TestCallback_AlreadySettled(t *testing.T) {
+	var (
+		txRepo      = transaction.NewMockRepository(t)
+		paymentSvc  = payment.NewMockService(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return([]transaction.Transaction{{
+			UUID:   "trx-123",
+			Status: transaction.StatusFailed,
+		}}, nil)
+
+	resp, err := uc.Callback(context.Background(), &CallbackRequest{TransactionID: "pay-123", Status: "success"})
 
 	assert.Nil(t, resp)
-	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Transaction is already settled"), err)
+}
 
-	cbsService.AssertExpectations(t)
-	txRepo.AssertExpectations(t)
-	paymentSvc.AssertExpectations(t)
-	accountRepo.AssertExpectations(t)
+func TestCallback_NotFound(t *testing.T) {
+	var (
+		txRepo      = transaction.NewMockRepository(t)
+		paymentSvc  = payment.NewMockService(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, paymentSvc, accountRepo)
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByParams(mock.Anything, mock.Anything).
+		Return(nil, nil)
+
+	resp, err := uc.Callback(context.Background(), &CallbackRequest{TransactionID: "pay-123", Status: "success"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Transaction was not found"), err)
 }
@@ -5,9 +5,11 @@ import "time"
 type TransactionDataResponse struct {
 	UUID               string    `json:"uuid"`
 	TransactionType    string    `json:"transaction_type"`
+	Rail               string    `json:"rail,omitempty"`
 	SourceAccount      string    `json:"source_account"`
 	DestinationAccount string    `json:"destination_account"`
 	Status             string    `json:"status"`
+	StatusReason       string    `json:"status_reason,omitempty"`
 	Notes              string    `json:"notes"`
 	Amount             int64     `json:"amount"`
 	Fee                int64     `json:"fee"`
@@ -15,9 +17,9 @@ type TransactionDataResponse struct {
 }
 
 type GetTransactionsRequest struct {
-	TransactionType string `query:"transaction_type" json:"transaction_type" validate:"omitempty,only=transfer tapmoney"`
-	SourceAccount   string `query:"source_account" json:"source_account" validate:"omitempty,number"`
-	Status          string `query:"status" json:"status" validate:"omitempty,only=initiated pending failed completed"`
+	TransactionType string `query:"transaction_type" json:"transaction_type" validate:"omitempty,only=transfer tapmoney reversal"`
+	SourceAccount   string `query:"source_account" json:"source_account" validate:"omitempty,accountnumber"`
+	Status          string `query:"status" json:"status" validate:"omitempty,only=initiated pending failed completed expired cancelled reversed"`
 }
 
 // Map converts the request to a map for repository queries.
@@ -38,3 +40,10 @@ func (req *GetTransactionsRequest) Map() map[string]any {
 type GetTransactionRequest struct {
 	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
 }
+
+type StatusChangeResponse struct {
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+	Reason    string    `json:"reason,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
@@ -2,20 +2,28 @@ package transaction
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
 )
 
 type Usecase struct {
-	txRepo transaction.Repository
+	initiatedTTL time.Duration
+	txRepo       transaction.Repository
+	accountRepo  account.Repository
 }
 
-func NewUsecase(txRepo transaction.Repository) *Usecase {
+func NewUsecase(cfg *config.Configs, txRepo transaction.Repository, accountRepo account.Repository) *Usecase {
 	return &Usecase{
-		txRepo: txRepo,
+		initiatedTTL: cfg.Transaction.InitiatedTTL,
+		txRepo:       txRepo,
+		accountRepo:  accountRepo,
 	}
 }
 
@@ -44,7 +52,9 @@ func (uc *Usecase) GetTransactions(ctx context.Context, req *GetTransactionsRequ
 		details = append(details, &TransactionDataResponse{
 			UUID:               tx.UUID,
 			TransactionType:    tx.TransactionType,
+			Rail:               tx.Rail,
 			Status:             tx.Status,
+			StatusReason:       tx.StatusReason,
 			SourceAccount:      tx.SourceAccount,
 			DestinationAccount: tx.DestinationAccount,
 			Amount:             tx.Amount,
@@ -67,10 +77,78 @@ func (uc *Usecase) GetTransactionByUUID(ctx context.Context, req *GetTransaction
 	return &TransactionDataResponse{
 		UUID:               tx.UUID,
 		TransactionType:    tx.TransactionType,
+		Rail:               tx.Rail,
 		Status:             tx.Status,
+		StatusReason:       tx.StatusReason,
 		SourceAccount:      tx.SourceAccount,
 		DestinationAccount: tx.DestinationAccount,
 		Amount:             tx.Amount,
 		ProcessedAt:        tx.ProcessedAt,
 	}, nil
 }
+
+// GetHistory returns the status changes of a transaction of the user in the context, oldest first.
+func (uc *Usecase) GetHistory(ctx context.Context, req *GetTransactionRequest) ([]*StatusChangeResponse, error) {
+	l := log.WithContext(ctx, "GetHistory")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User not authorized")
+	}
+
+	tx, err := uc.txRepo.GetByUUID(ctx, req.UUID)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to get transaction")
+		return nil, pkgerror.NotFound().SetMsg("Failed to get transaction")
+	}
+	if tx.Username != userFromCtx.Username {
+		return nil, pkgerror.NotFound().SetMsg("Failed to get transaction")
+	}
+
+	history, err := uc.txRepo.GetHistory(ctx, tx.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("uuid", tx.UUID).
+			Msg("Failed to get transaction history")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	res := make([]*StatusChangeResponse, 0, len(history))
+	for _, c := range history {
+		res = append(res, &StatusChangeResponse{
+			From:      c.From,
+			To:        c.To,
+			Reason:    c.Reason,
+			ChangedAt: c.ChangedAt,
+		})
+	}
+	return res, nil
+}
+
+// ExpireStale moves the transactions that stayed initiated longer than the TTL to expired
+// and releases the amounts held for them.
+func (uc *Usecase) ExpireStale(ctx context.Context) error {
+	l := log.WithContext(ctx, "ExpireStale")
+
+	if uc.initiatedTTL <= 0 {
+		return nil
+	}
+
+	expired, err := uc.txRepo.ExpireInitiated(ctx, time.Now().Add(-uc.initiatedTTL), transaction.ReasonExpired)
+	if err != nil {
+		return err
+	}
+	for _, tx := range expired {
+		err = uc.accountRepo.ReleaseHold(ctx, tx.UUID)
+		if err != nil && !errors.Is(err, account.ErrHoldNotFound) {
+			l.Error().Err(err).
+				Str("transaction_id", tx.UUID).
+				Msg("Failed to release hold of expired transaction")
+		}
+	}
+	if len(expired) > 0 {
+		l.Info().Int("expired", len(expired)).Msg("Expired stale transactions")
+	}
+	return nil
+}
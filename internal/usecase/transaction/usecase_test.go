@@ -0,0 +1,120 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
+)
+
+func TestExpireStale_Success(t *testing.T) {
+	var (
+		cfg         = new(config.Configs)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Transaction.InitiatedTTL = 15 * time.Minute
+	uc := NewUsecase(cfg, txRepo, accountRepo)
+
+	txRepo.EXPECT().ExpireInitiated(mock.Anything, mock.MatchedBy(func(createdBefore time.Time) bool {
+		return time.Since(createdBefore) >= 15*time.Minute
+	}), transaction.ReasonExpired).Return([]transaction.Transaction{
+		{UUID: "tx-1", Status: transaction.StatusExpired},
+		{UUID: "tx-2", Status: transaction.StatusExpired},
+	}, nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-1").Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-2").Return(account.ErrHoldNotFound)
+
+	err := uc.ExpireStale(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestExpireStale_Disabled(t *testing.T) {
+	var (
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, accountRepo)
+	)
+
+	err := uc.ExpireStale(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestExpireStale_Failed(t *testing.T) {
+	var (
+		cfg         = new(config.Configs)
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+	)
+
+	cfg.Transaction.InitiatedTTL = 15 * time.Minute
+	uc := NewUsecase(cfg, txRepo, accountRepo)
+
+	txRepo.EXPECT().ExpireInitiated(mock.Anything, mock.Anything, transaction.ReasonExpired).
+		Return(nil, errors.New("db error"))
+
+	err := uc.ExpireStale(context.Background())
+
+	assert.EqualError(t, err, "db error")
+}
+
+func TestGetHistory_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, accountRepo)
+	)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{UUID: "tx-123", Username: "johndoe"}, nil)
+	txRepo.EXPECT().GetHistory(mock.Anything, "tx-123").
+		Return([]transaction.StatusChange{
+			{TransactionUUID: "tx-123", To: transaction.StatusInitiated},
+			{TransactionUUID: "tx-123", From: transaction.StatusInitiated, To: transaction.StatusPending, Reason: "Transfer is being processed"},
+			{TransactionUUID: "tx-123", From: transaction.StatusPending, To: transaction.StatusCompleted},
+		}, nil)
+
+	res, err := uc.GetHistory(ctx, &GetTransactionRequest{UUID: "tx-123"})
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+	assert.Equal(t, transaction.StatusInitiated, res[1].From)
+	assert.Equal(t, transaction.StatusPending, res[1].To)
+	assert.Equal(t, transaction.StatusCompleted, res[2].To)
+}
+
+func TestGetHistory_NotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		txRepo      = transaction.NewMockRepository(t)
+		accountRepo = account.NewMockRepository(t)
+		uc          = NewUsecase(new(config.Configs), txRepo, accountRepo)
+	)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{UUID: "tx-123", Username: "janedoe"}, nil)
+
+	res, err := uc.GetHistory(ctx, &GetTransactionRequest{UUID: "tx-123"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Failed to get transaction"), err)
+}
@@ -3,20 +3,31 @@ package transfer
 import "time"
 
 type InitiateRequest struct {
-	SourceAccount      string `json:"source_account" validate:"required,number"`
-	DestinationAccount string `json:"destination_account" validate:"required,number"`
-	Amount             int64  `json:"amount" validate:"required,gte=1000,lte=50000000"`
-	Note               string `json:"note"`
+	SourceAccount       string `json:"source_account" validate:"required,accountnumber"`
+	BeneficiaryID       string `json:"beneficiary_id" validate:"omitempty,uuid"`
+	DestinationBankCode string `json:"destination_bank_code" validate:"omitempty,number,len=3"`
+	DestinationAccount  string `json:"destination_account" validate:"required_without=BeneficiaryID,omitempty,accountnumber=DestinationBankCode"`
+	Amount              int64  `json:"amount" validate:"required,gte=1000"`
+	Note                string `json:"note"`
+	// BatchUUID links the transfer to a bulk transfer batch.
+	BatchUUID string `json:"-"`
+	// Origin is the scheduled job initiating the transfer, see transaction.OriginStandingOrder.
+	Origin string `json:"-"`
+	// UUID makes the initiation idempotent, the transfer with the UUID is returned instead of being initiated again.
+	UUID string `json:"-"`
 }
 
 type InitiateResponse struct {
 	UUID   string `json:"uuid"`
 	Status string `json:"status"`
+	Rail   string `json:"rail"`
 }
 
 type ProcessRequest struct {
-	UUID               string `param:"uuid" json:"uuid" validate:"required,uuid"`
-	SourceAccount      string `json:"source_account" validate:"required,number"`
+	UUID          string `param:"uuid" json:"uuid" validate:"required,uuid"`
+	SourceAccount string `json:"source_account" validate:"required,accountnumber"`
+	// DestinationAccount must be the one of the initiated transfer, it may belong to another bank
+	// so only its digits are validated.
 	DestinationAccount string `json:"destination_account" validate:"required,number"`
 	Amount             int64  `json:"amount" validate:"required"`
 }
@@ -33,6 +44,7 @@ type DetailRequest struct {
 type DetailResponse struct {
 	UUID               string    `json:"uuid"`
 	Status             string    `json:"status"`
+	StatusReason       string    `json:"status_reason,omitempty"`
 	Amount             int64     `json:"amount"`
 	Fee                int64     `json:"fee"`
 	SourceAccount      string    `json:"source_account"`
@@ -40,3 +52,12 @@ type DetailResponse struct {
 	Note               string    `json:"note"`
 	ProcessedAt        time.Time `json:"processed_at"`
 }
+
+type CancelRequest struct {
+	UUID string `param:"uuid" json:"uuid" validate:"required,uuid"`
+}
+
+type CancelResponse struct {
+	UUID   string `json:"uuid"`
+	Status string `json:"status"`
+}
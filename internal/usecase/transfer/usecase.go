@@ -2,58 +2,198 @@ package transfer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
 )
 
 const (
 	transferTransactionType = "transfer"
+	// msgUnavailable is shown for transfers refused while the core banking system runs its end of day.
+	msgUnavailable = "Transfer is unavailable during the end of day process, please try again later"
+	// defaultReplayAttempts is the number of failed forwards after which a queued transfer is parked.
+	defaultReplayAttempts = 5
+	// defaultTransferLimit is the largest transfer between accounts of this bank.
+	defaultTransferLimit = 50_000_000
+)
+
+// Defaults of the interbank rails.
+const (
+	defaultBIFastLimit = 250_000_000
+	defaultSKNLimit    = 1_000_000_000
+	defaultRTGSMinimum = 100_000_000
+	defaultRTGSCutOff  = 16 * time.Hour
+)
+
+// defaultSKNBatchCutOffs are the cut-off times of the daily SKN clearing batches.
+var defaultSKNBatchCutOffs = []time.Duration{9 * time.Hour, 12 * time.Hour, 15 * time.Hour}
+
+// Status reasons of transfers settled by the reconciliation.
+const (
+	reasonProcessing           = "Transfer is being processed"
+	reasonFailed               = "Transfer could not be processed"
+	reasonAwaitingConfirmation = "Waiting for the core banking system to confirm the transfer"
+	reasonNotReceived          = "Transfer was not received by the core banking system"
+	reasonRefused              = "Transfer was refused by the core banking system"
+	reasonRejected             = "Transfer was rejected by the destination bank"
+	reasonCancelled            = "Transfer was cancelled by the user"
+	reasonQueued               = "Queued until the core banking system is available"
+	reasonStandInRefused       = "Transfer queued during the end of day was refused by the core banking system"
+	reasonStandInParked        = "Transfer queued during the end of day could not be sent to the core banking system"
 )
 
 // Usecase defines the use case for handling transfers.
 type Usecase struct {
-	cbsSvc      cbs.Service
-	txRepo      transaction.Repository
-	accountRepo account.Repository
-	transferSvc transfer.Service
+	initiatedTTL    time.Duration
+	reconcileAfter  time.Duration
+	transferLimit   int64
+	bankCode        string
+	railRouter      transfer.RailRouter
+	cbsSvc          cbs.Service
+	txRepo          transaction.Repository
+	accountRepo     account.Repository
+	beneficiaryRepo beneficiary.Repository
+	transferSvc     transfer.Service
+	bifastSvc       transfer.BIFastService
+	sknSvc          transfer.SKNService
+	rtgsSvc         transfer.RTGSService
+	standInPolicy   standin.Policy
+	standInRepo     standin.Repository
+	notificationSvc notification.Service
+	uow             uow.UnitOfWork
 }
 
 func NewUsecase(
+	cfg *config.Configs,
 	cbsSvc cbs.Service,
 	txRepo transaction.Repository,
 	accountRepo account.Repository,
+	beneficiaryRepo beneficiary.Repository,
 	transferSvc transfer.Service,
+	bifastSvc transfer.BIFastService,
+	sknSvc transfer.SKNService,
+	rtgsSvc transfer.RTGSService,
+	standInRepo standin.Repository,
+	notificationSvc notification.Service,
+	uow uow.UnitOfWork,
 ) *Usecase {
+	maxAttempts := cfg.StandIn.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultReplayAttempts
+	}
+	transferLimit := cfg.Transaction.TransferLimit
+	if transferLimit <= 0 {
+		transferLimit = defaultTransferLimit
+	}
+	railRouter := transfer.RailRouter{
+		BIFastLimit:     cfg.Interbank.BIFastLimit,
+		SKNLimit:        cfg.Interbank.SKNLimit,
+		RTGSMinimum:     cfg.Interbank.RTGSMinimum,
+		SKNBatchCutOffs: cfg.Interbank.SKNBatchCutOffs,
+		RTGSCutOff:      cfg.Interbank.RTGSCutOff,
+		BIFastFee:       cfg.Interbank.BIFastFee,
+		SKNFee:          cfg.Interbank.SKNFee,
+		RTGSFee:         cfg.Interbank.RTGSFee,
+	}
+	if railRouter.BIFastLimit <= 0 {
+		railRouter.BIFastLimit = defaultBIFastLimit
+	}
+	if railRouter.SKNLimit <= 0 {
+		railRouter.SKNLimit = defaultSKNLimit
+	}
+	if railRouter.RTGSMinimum <= 0 {
+		railRouter.RTGSMinimum = defaultRTGSMinimum
+	}
+	if len(railRouter.SKNBatchCutOffs) == 0 {
+		railRouter.SKNBatchCutOffs = defaultSKNBatchCutOffs
+	}
+	if railRouter.RTGSCutOff <= 0 {
+		railRouter.RTGSCutOff = defaultRTGSCutOff
+	}
+
 	return &Usecase{
-		cbsSvc:      cbsSvc,
-		txRepo:      txRepo,
-		accountRepo: accountRepo,
-		transferSvc: transferSvc,
+		initiatedTTL:    cfg.Transaction.InitiatedTTL,
+		reconcileAfter:  cfg.Transaction.ReconcileAfter,
+		transferLimit:   transferLimit,
+		bankCode:        cfg.Interbank.BankCode,
+		railRouter:      railRouter,
+		cbsSvc:          cbsSvc,
+		txRepo:          txRepo,
+		accountRepo:     accountRepo,
+		beneficiaryRepo: beneficiaryRepo,
+		transferSvc:     transferSvc,
+		bifastSvc:       bifastSvc,
+		sknSvc:          sknSvc,
+		rtgsSvc:         rtgsSvc,
+		standInPolicy: standin.Policy{
+			Limit:        cfg.StandIn.Limit,
+			AccountLimit: cfg.StandIn.AccountLimit,
+			MaxAttempts:  maxAttempts,
+		},
+		standInRepo:     standInRepo,
+		notificationSvc: notificationSvc,
+		uow:             uow,
 	}
 }
 
+// Initiate holds the amount of a new transfer of the user in the context.
+// During the end of day only internal transfers within the stand-in limit are accepted.
 func (uc *Usecase) Initiate(ctx context.Context, req *InitiateRequest) (*InitiateResponse, error) {
 	l := log.WithContext(ctx, "Initiate")
 
-	cbsStatus, err := uc.cbsSvc.GetStatus(ctx)
+	if req.UUID != "" {
+		txs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+			"uuid": req.UUID,
+		})
+		if err != nil {
+			l.Error().Err(err).
+				Str("transaction_id", req.UUID).
+				Msg("Failed to get transaction")
+			return nil, pkgerror.InternalServerError()
+		}
+		if len(txs) > 0 {
+			return &InitiateResponse{
+				UUID:   txs[0].UUID,
+				Status: txs[0].Status,
+				Rail:   txs[0].Rail,
+			}, nil
+		}
+	}
+
+	cbsStatus, err := cbs.GetStatus(ctx, uc.cbsSvc)
 	if err != nil {
 		l.Error().Err(err).Msg("Failed to Get CBS status")
 		return nil, pkgerror.InternalServerError()
 	}
-	if cbsStatus.NotReady() {
+	if cbsStatus.NotReady() && !uc.standInPolicy.Accepts(req.Amount) {
 		l.Error().
 			Bool("is_eod", cbsStatus.IsEOD).
 			Bool("is_stand_in", cbsStatus.IsStandIn).
 			Msg("CBS is not ready for transactions")
-		return nil, pkgerror.InternalServerError()
+		return nil, pkgerror.ServiceUnavailable().SetMsg(msgUnavailable)
+	}
+
+	if req.BeneficiaryID != "" {
+		b, err := uc.getOwnedBeneficiary(ctx, req.BeneficiaryID)
+		if err != nil {
+			return nil, err
+		}
+		req.DestinationBankCode = b.BankCode
+		req.DestinationAccount = b.AccountNumber
 	}
 
 	srcAccount, err := uc.accountRepo.Get(ctx, req.SourceAccount)
@@ -63,20 +203,67 @@ func (uc *Usecase) Initiate(ctx context.Context, req *InitiateRequest) (*Initiat
 			Msg("Failed to get account")
 		return nil, pkgerror.InternalServerError()
 	}
+	if !srcAccount.CanDebit() {
+		l.Error().
+			Str("account_number", srcAccount.AccountNumber).
+			Str("status", srcAccount.Status).
+			Msg("Account cannot be debited")
+		return nil, pkgerror.BadRequest().SetMsg("Account cannot be debited")
+	}
 	if !srcAccount.CanTransfer(req.Amount) {
 		l.Error().
-			Int64("account_balance", srcAccount.Balance).
+			Int64("available_balance", srcAccount.AvailableBalance).
 			Int64("request_amount", req.Amount).
 			Msg("Insufficient balance")
 		return nil, pkgerror.BadRequest().SetMsg("Insufficient balance")
 	}
+	if cbsStatus.NotReady() {
+		err = uc.checkStandInLimit(ctx, srcAccount.AccountNumber, req.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	destAccount, err := uc.accountRepo.Get(ctx, req.DestinationAccount)
-	if err != nil {
-		l.Error().Err(err).
-			Str("account_number", req.DestinationAccount).
-			Msg("Failed to get account")
-		return nil, pkgerror.InternalServerError()
+	rail := transfer.RailInternal
+	destAccountNumber := req.DestinationAccount
+	if uc.isInterbank(req.DestinationBankCode) {
+		if cbsStatus.NotReady() {
+			l.Error().
+				Str("bank_code", req.DestinationBankCode).
+				Msg("Interbank transfers cannot be queued during the end of day")
+			return nil, pkgerror.ServiceUnavailable().SetMsg(msgUnavailable)
+		}
+		rail, err = uc.railRouter.Route(req.Amount, time.Now())
+		if err != nil {
+			l.Error().Err(err).
+				Str("bank_code", req.DestinationBankCode).
+				Int64("request_amount", req.Amount).
+				Msg("Failed to route interbank transfer")
+			return nil, pkgerror.BadRequest().SetMsg("No transfer rail is available for the amount at this time")
+		}
+	} else {
+		if req.Amount > uc.transferLimit {
+			l.Error().
+				Int64("request_amount", req.Amount).
+				Int64("transfer_limit", uc.transferLimit).
+				Msg("Amount exceeds the transfer limit")
+			return nil, pkgerror.BadRequest().SetMsg("Amount exceeds the transfer limit")
+		}
+		destAccount, err := uc.accountRepo.Get(ctx, req.DestinationAccount)
+		if err != nil {
+			l.Error().Err(err).
+				Str("account_number", req.DestinationAccount).
+				Msg("Failed to get account")
+			return nil, pkgerror.InternalServerError()
+		}
+		if !destAccount.CanCredit() {
+			l.Error().
+				Str("account_number", destAccount.AccountNumber).
+				Str("status", destAccount.Status).
+				Msg("Destination account cannot be credited")
+			return nil, pkgerror.BadRequest().SetMsg("Destination account cannot receive transfers")
+		}
+		destAccountNumber = destAccount.AccountNumber
 	}
 
 	userFromCtx, err := user.FromContext(ctx)
@@ -85,43 +272,93 @@ func (uc *Usecase) Initiate(ctx context.Context, req *InitiateRequest) (*Initiat
 		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
 	}
 
+	txUUID := req.UUID
+	if txUUID == "" {
+		txUUID = uuid.New().String()
+	}
 	tx := transaction.Transaction{
-		UUID:               uuid.New().String(),
-		SourceAccount:      srcAccount.AccountNumber,
-		DestinationAccount: destAccount.AccountNumber,
-		TransactionType:    transferTransactionType,
-		Status:             transaction.StatusInitiated,
-		Amount:             req.Amount,
-		Username:           userFromCtx.Username,
-		Note:               req.Note,
+		UUID:                txUUID,
+		SourceAccount:       srcAccount.AccountNumber,
+		DestinationBankCode: req.DestinationBankCode,
+		DestinationAccount:  destAccountNumber,
+		TransactionType:     transferTransactionType,
+		BatchUUID:           req.BatchUUID,
+		Origin:              req.Origin,
+		Rail:                rail,
+		Status:              transaction.StatusInitiated,
+		Amount:              req.Amount,
+		Fee:                 uc.railRouter.Fee(rail),
+		Username:            userFromCtx.Username,
+		Note:                req.Note,
+	}
+
+	err = uc.accountRepo.PlaceHold(ctx, tx.SourceAccount, tx.UUID, tx.HoldAmount())
+	if err != nil && errors.Is(err, account.ErrInsufficientBalance) {
+		l.Error().Err(err).
+			Str("account_number", tx.SourceAccount).
+			Int64("hold_amount", tx.HoldAmount()).
+			Msg("Insufficient balance")
+		return nil, pkgerror.BadRequest().SetMsg("Insufficient balance")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", tx.SourceAccount).
+			Msg("Failed to place hold")
+		return nil, pkgerror.InternalServerError()
 	}
 
 	err = uc.txRepo.Create(ctx, tx)
 	if err != nil {
 		l.Error().Err(err).Msg("Failed to create transaction")
+		// The hold of a duplicate is the one of the transfer already initiated with the UUID.
+		if errors.Is(err, transaction.ErrDuplicate) {
+			return nil, pkgerror.Conflict().SetMsg("Transfer is already initiated")
+		}
+		err = uc.accountRepo.ReleaseHold(ctx, tx.UUID)
+		if err != nil {
+			l.Error().Err(err).
+				Str("transaction_id", tx.UUID).
+				Msg("Failed to release hold")
+		}
 		return nil, pkgerror.InternalServerError()
 	}
 
 	return &InitiateResponse{
 		UUID:   tx.UUID,
 		Status: tx.Status,
+		Rail:   tx.Rail,
 	}, nil
 }
 
+// Fee returns the fee of a transfer of the amount to the bank,
+// an interbank transfer pays the fee of the rail it would be routed through now.
+func (uc *Usecase) Fee(bankCode string, amount int64) int64 {
+	if !uc.isInterbank(bankCode) {
+		return 0
+	}
+	rail, err := uc.railRouter.Route(amount, time.Now())
+	if err != nil {
+		return 0
+	}
+	return uc.railRouter.Fee(rail)
+}
+
+// Process sends an initiated transfer. During the end of day the transfer is queued
+// and stays pending until Replay forwards it to the core banking system.
 func (uc *Usecase) Process(ctx context.Context, req *ProcessRequest) (*ProcessResponse, error) {
 	l := log.WithContext(ctx, "Process")
 
-	cbsStatus, err := uc.cbsSvc.GetStatus(ctx)
+	cbsStatus, err := cbs.GetStatus(ctx, uc.cbsSvc)
 	if err != nil {
 		l.Error().Err(err).Msg("Failed to Get CBS status")
 		return nil, pkgerror.InternalServerError()
 	}
-	if cbsStatus.NotReady() {
+	if cbsStatus.NotReady() && !uc.standInPolicy.Enabled() {
 		l.Error().
 			Bool("is_eod", cbsStatus.IsEOD).
 			Bool("is_stand_in", cbsStatus.IsStandIn).
 			Msg("CBS is not ready for transactions")
-		return nil, pkgerror.InternalServerError()
+		return nil, pkgerror.ServiceUnavailable().SetMsg(msgUnavailable)
 	}
 
 	tx, err := uc.txRepo.GetByUUID(ctx, req.UUID)
@@ -136,30 +373,536 @@ func (uc *Usecase) Process(ctx context.Context, req *ProcessRequest) (*ProcessRe
 			Msg("Transaction is not in a valid state to be processed")
 		return nil, pkgerror.Conflict().SetMsg("Transaction is not in a valid state to be processed")
 	}
+	// The amount was checked and held by Initiate, the request may not move another one.
+	if req.Amount != tx.Amount {
+		l.Error().
+			Str("uuid", req.UUID).
+			Int64("request_amount", req.Amount).
+			Int64("amount", tx.Amount).
+			Msg("Amount does not match the initiated transfer")
+		return nil, pkgerror.BadRequest().SetMsg("Amount does not match the initiated transfer")
+	}
+	if req.SourceAccount != tx.SourceAccount || req.DestinationAccount != tx.DestinationAccount {
+		l.Error().
+			Str("uuid", req.UUID).
+			Str("request_source_account", req.SourceAccount).
+			Str("request_destination_account", req.DestinationAccount).
+			Msg("Accounts do not match the initiated transfer")
+		return nil, pkgerror.BadRequest().SetMsg("Accounts do not match the initiated transfer")
+	}
+	if tx.Expired(uc.initiatedTTL, time.Now()) {
+		l.Error().
+			Str("uuid", req.UUID).
+			Time("created_at", tx.CreatedAt).
+			Msg("Transaction has expired")
+		err = tx.Expire()
+		if err == nil {
+			err = uc.txRepo.Update(ctx, tx)
+		}
+		if err != nil {
+			l.Error().Err(err).
+				Str("transaction_id", req.UUID).
+				Msg("Failed to update transaction status")
+		} else {
+			uc.settleHold(ctx, tx)
+		}
+		return nil, pkgerror.Conflict().SetMsg("Transaction has expired")
+	}
+
+	if cbsStatus.NotReady() {
+		if tx.Rail != transfer.RailInternal || !uc.standInPolicy.Accepts(tx.Amount) {
+			l.Error().
+				Str("rail", tx.Rail).
+				Int64("amount", tx.Amount).
+				Msg("Transfer cannot be queued during the end of day")
+			return nil, pkgerror.ServiceUnavailable().SetMsg(msgUnavailable)
+		}
+		err = uc.checkStandInLimit(ctx, tx.SourceAccount, tx.Amount)
+		if err != nil {
+			return nil, err
+		}
+		return uc.enqueue(ctx, tx)
+	}
+
+	tx, err = uc.txRepo.Claim(ctx, tx.UUID, reasonProcessing)
+	if err != nil && errors.Is(err, transaction.ErrConflict) {
+		l.Error().Err(err).
+			Str("uuid", req.UUID).
+			Msg("Transaction is already being processed")
+		return nil, pkgerror.Conflict().SetMsg("Transaction is not in a valid state to be processed")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", req.UUID).
+			Msg("Failed to claim transaction")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	if tx.Rail == transfer.RailBIFast || tx.Rail == transfer.RailSKN || tx.Rail == transfer.RailRTGS {
+		return uc.processInterbank(ctx, tx)
+	}
 
 	res, err := uc.transferSvc.Transfer(
 		ctx,
 		tx.SourceAccount,
 		tx.DestinationAccount,
-		req.Amount,
+		tx.Amount,
 		makeTransferRemark(tx.SourceAccount, tx.DestinationAccount, tx.UUID),
 	)
+	if err != nil && transfer.IsOutcomeUnknown(err) {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Transfer outcome is unknown, waiting for reconciliation")
+		return uc.markPending(ctx, tx)
+	}
 	if err != nil {
 		l.Error().Err(err).Msg("Failed to transfer amount")
+		uc.markFailed(ctx, tx)
 		return nil, pkgerror.InternalServerError()
 	}
 
 	// Update transaction status to success
-	tx.Status = transaction.StatusCompleted
 	tx.TransactionReference = res.TransactionReference
+	err = tx.Transition(transaction.StatusCompleted, "")
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", req.UUID).
+			Msg("Failed to update transaction status")
+		return nil, pkgerror.InternalServerError()
+	}
+	uc.settleHold(ctx, tx)
+
+	return &ProcessResponse{
+		UUID:   tx.UUID,
+		Status: tx.Status,
+	}, nil
+}
+
+// Detail returns the transfer of the user in the context,
+// clients poll it until a pending transfer is settled.
+func (uc *Usecase) Detail(ctx context.Context, req *DetailRequest) (*DetailResponse, error) {
+	l := log.WithContext(ctx, "Detail")
 
-	err = uc.txRepo.Update(ctx, tx)
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	tx, err := uc.txRepo.GetByUUID(ctx, req.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", req.UUID).
+			Msg("Failed to get transaction")
+		return nil, pkgerror.NotFound().SetMsg("Transfer not found")
+	}
+	if tx.Username != userFromCtx.Username || tx.TransactionType != transferTransactionType {
+		return nil, pkgerror.NotFound().SetMsg("Transfer not found")
+	}
+
+	return &DetailResponse{
+		UUID:               tx.UUID,
+		Status:             tx.Status,
+		StatusReason:       tx.StatusReason,
+		Amount:             tx.Amount,
+		Fee:                tx.Fee,
+		SourceAccount:      tx.SourceAccount,
+		DestinationAccount: tx.DestinationAccount,
+		Note:               tx.Note,
+		ProcessedAt:        tx.ProcessedAt,
+	}, nil
+}
+
+// Cancel cancels an initiated transfer of the user in the context before it is processed.
+func (uc *Usecase) Cancel(ctx context.Context, req *CancelRequest) (*CancelResponse, error) {
+	l := log.WithContext(ctx, "Cancel")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return nil, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	tx, err := uc.txRepo.GetByUUID(ctx, req.UUID)
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", req.UUID).
+			Msg("Failed to get transaction")
+		return nil, pkgerror.NotFound().SetMsg("Transfer not found")
+	}
+	if tx.Username != userFromCtx.Username || tx.TransactionType != transferTransactionType {
+		return nil, pkgerror.NotFound().SetMsg("Transfer not found")
+	}
+
+	err = tx.Transition(transaction.StatusCancelled, reasonCancelled)
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil && (errors.Is(err, transaction.ErrInvalidTransition) || errors.Is(err, transaction.ErrConflict)) {
+		l.Error().Err(err).
+			Str("transaction_id", req.UUID).
+			Msg("Transfer cannot be cancelled")
+		return nil, pkgerror.Conflict().SetMsg("Only initiated transfers can be cancelled")
+	}
 	if err != nil {
 		l.Error().Err(err).
 			Str("transaction_id", req.UUID).
 			Msg("Failed to update transaction status")
 		return nil, pkgerror.InternalServerError()
 	}
+	uc.settleHold(ctx, tx)
+
+	return &CancelResponse{
+		UUID:   tx.UUID,
+		Status: tx.Status,
+	}, nil
+}
+
+// Reconcile settles the pending transfers by asking the core banking system
+// or the interbank rail for their outcome. Transfers whose outcome is still
+// unknown stay pending until the next run.
+func (uc *Usecase) Reconcile(ctx context.Context) error {
+	l := log.WithContext(ctx, "Reconcile")
+
+	txs, err := uc.txRepo.GetByParams(ctx, map[string]any{
+		"transaction_type": transferTransactionType,
+		"status":           transaction.StatusPending,
+	})
+	if err != nil {
+		return err
+	}
+
+	settleBefore := time.Now().Add(-uc.reconcileAfter)
+	for _, tx := range txs {
+		// Queued transfers were never sent, Replay settles them.
+		if tx.UpdatedAt.After(settleBefore) || tx.StatusReason == reasonQueued {
+			continue
+		}
+		err = uc.reconcile(ctx, tx)
+		if err != nil {
+			l.Error().Err(err).
+				Str("transaction_id", tx.UUID).
+				Str("rail", tx.Rail).
+				Msg("Failed to reconcile transaction")
+		}
+	}
+	return nil
+}
+
+// reconcile looks up the outcome of one pending transfer and records it.
+func (uc *Usecase) reconcile(ctx context.Context, tx transaction.Transaction) error {
+	switch tx.Rail {
+	case transfer.RailBIFast, transfer.RailSKN, transfer.RailRTGS:
+		if tx.TransactionReference == "" {
+			return errors.New("interbank transfer has no reference")
+		}
+		res, err := uc.getInterbankStatus(ctx, tx)
+		if err != nil {
+			return err
+		}
+		switch {
+		case res.Settled():
+			tx.Fee = uc.chargeFee(ctx, tx)
+			err = tx.Transition(transaction.StatusCompleted, "")
+		case res.Rejected():
+			err = tx.Transition(transaction.StatusFailed, reasonRejected)
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	default:
+		res, err := uc.transferSvc.GetTransferStatus(ctx,
+			makeTransferRemark(tx.SourceAccount, tx.DestinationAccount, tx.UUID))
+		switch {
+		case err != nil && errors.Is(err, transfer.ErrTransferNotFound):
+			err = tx.Transition(transaction.StatusFailed, reasonNotReceived)
+		case err != nil:
+			return err
+		case res.Succeeded():
+			tx.TransactionReference = res.TransactionReference
+			err = tx.Transition(transaction.StatusCompleted, "")
+		case res.Failed():
+			err = tx.Transition(transaction.StatusFailed, reasonRefused)
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err := uc.txRepo.Update(ctx, tx)
+	if err != nil {
+		return err
+	}
+	uc.settleHold(ctx, tx)
+	return nil
+}
+
+// getInterbankStatus asks the adapter of the transaction rail for the transfer status.
+func (uc *Usecase) getInterbankStatus(ctx context.Context, tx transaction.Transaction) (transfer.InterbankTransfer, error) {
+	switch tx.Rail {
+	case transfer.RailBIFast:
+		return uc.bifastSvc.GetStatus(ctx, tx.TransactionReference)
+	case transfer.RailSKN:
+		return uc.sknSvc.GetStatus(ctx, tx.TransactionReference)
+	case transfer.RailRTGS:
+		return uc.rtgsSvc.GetStatus(ctx, tx.TransactionReference)
+	default:
+		return transfer.InterbankTransfer{}, fmt.Errorf("unknown rail %q", tx.Rail)
+	}
+}
+
+// Replay forwards the transfers queued during the end of day to the core banking system
+// in the order they were accepted. It stops at the first transfer that could not be sent,
+// so later transfers of the queue do not overtake it, until the transfer failed the maximum
+// number of attempts and is parked: it is failed with its hold released and the user is told,
+// unless the core banking system received it after all.
+func (uc *Usecase) Replay(ctx context.Context) error {
+	l := log.WithContext(ctx, "Replay")
+
+	cbsStatus, err := uc.cbsSvc.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if cbsStatus.NotReady() {
+		return nil
+	}
+
+	entries, err := uc.standInRepo.GetQueued(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		err = uc.forward(ctx, e)
+		if err == nil {
+			continue
+		}
+
+		e.Attempts++
+		e.Reason = err.Error()
+		if !uc.standInPolicy.Parks(e.Attempts) {
+			l.Error().Err(err).
+				Str("transaction_id", e.TransactionUUID).
+				Int("attempts", e.Attempts).
+				Msg("Stand-in queue is stuck on a transfer that could not be forwarded")
+			updateErr := uc.standInRepo.Update(ctx, e)
+			if updateErr != nil {
+				l.Error().Err(updateErr).
+					Str("transaction_id", e.TransactionUUID).
+					Msg("Failed to update stand-in entry")
+			}
+			return fmt.Errorf("forward transaction %s: %w", e.TransactionUUID, err)
+		}
+
+		l.Error().Err(err).
+			Str("transaction_id", e.TransactionUUID).
+			Int("attempts", e.Attempts).
+			Msg("Stand-in entry parked after failing to be forwarded")
+		err = uc.park(ctx, e)
+		if err != nil {
+			return fmt.Errorf("park transaction %s: %w", e.TransactionUUID, err)
+		}
+	}
+	return nil
+}
+
+// forward sends one queued transfer and marks its entry with the outcome.
+func (uc *Usecase) forward(ctx context.Context, e standin.Entry) error {
+	tx, err := uc.txRepo.GetByUUID(ctx, e.TransactionUUID)
+	if err != nil {
+		return err
+	}
+	// A transaction that is no longer queued was settled before its entry was updated.
+	if tx.Status == transaction.StatusPending && tx.StatusReason == reasonQueued {
+		tx, err = uc.send(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.Status = standin.StatusForwarded
+	e.ForwardedAt = time.Now()
+	if tx.Status == transaction.StatusFailed {
+		e.Status = standin.StatusRejected
+		e.Reason = tx.StatusReason
+	}
+	return uc.standInRepo.Update(ctx, e)
+}
+
+// park gives up a queued transfer and parks its entry. The core banking system is asked
+// for the transfer first, since a failed forward may have reached it: a transfer it does not have
+// or refused is failed and its hold released, one it settled is completed and one still in progress
+// is left to the reconciliation. The entry is parked after the transaction is settled, a transaction
+// settled without its entry is taken up by the next replay.
+func (uc *Usecase) park(ctx context.Context, e standin.Entry) error {
+	tx, err := uc.txRepo.GetByUUID(ctx, e.TransactionUUID)
+	if err != nil {
+		return err
+	}
+	if tx.Status == transaction.StatusPending && tx.StatusReason == reasonQueued {
+		res, err := uc.transferSvc.GetTransferStatus(ctx,
+			makeTransferRemark(tx.SourceAccount, tx.DestinationAccount, tx.UUID))
+		switch {
+		case err != nil && errors.Is(err, transfer.ErrTransferNotFound):
+			err = tx.Transition(transaction.StatusFailed, reasonStandInParked)
+		case err != nil:
+			return err
+		case res.Succeeded():
+			tx.TransactionReference = res.TransactionReference
+			err = tx.Transition(transaction.StatusCompleted, "")
+		case res.Failed():
+			err = tx.Transition(transaction.StatusFailed, reasonStandInParked)
+		default:
+			tx.StatusReason = reasonAwaitingConfirmation
+		}
+		if err == nil {
+			err = uc.txRepo.Update(ctx, tx)
+		}
+		if err != nil {
+			return err
+		}
+		uc.settleHold(ctx, tx)
+		if tx.Status == transaction.StatusFailed {
+			uc.notifyFailed(ctx, tx, "could not be sent")
+		}
+	}
+
+	e.Status = standin.StatusParked
+	return uc.standInRepo.Update(ctx, e)
+}
+
+// send transfers a queued transaction and records the outcome.
+// A transfer whose outcome is unknown is left pending for the reconciliation.
+func (uc *Usecase) send(ctx context.Context, tx transaction.Transaction) (transaction.Transaction, error) {
+	l := log.WithContext(ctx, "send")
+
+	res, err := uc.transferSvc.Transfer(ctx, tx.SourceAccount, tx.DestinationAccount, tx.Amount,
+		makeTransferRemark(tx.SourceAccount, tx.DestinationAccount, tx.UUID))
+	switch {
+	case err != nil && transfer.IsOutcomeUnknown(err):
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Transfer outcome is unknown, waiting for reconciliation")
+		tx.StatusReason = reasonAwaitingConfirmation
+		err = nil
+	case err != nil && isRefused(err):
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Queued transfer was refused")
+		err = tx.Transition(transaction.StatusFailed, reasonStandInRefused)
+	case err != nil:
+		return tx, err
+	default:
+		tx.TransactionReference = res.TransactionReference
+		err = tx.Transition(transaction.StatusCompleted, "")
+	}
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil {
+		return tx, err
+	}
+	uc.settleHold(ctx, tx)
+	if tx.Status == transaction.StatusFailed {
+		uc.notifyFailed(ctx, tx, "was refused")
+	}
+	return tx, nil
+}
+
+// notifyFailed tells the user that a queued transfer failed with the outcome and its hold was released.
+func (uc *Usecase) notifyFailed(ctx context.Context, tx transaction.Transaction, outcome string) {
+	l := log.WithContext(ctx, "notifyFailed")
+
+	err := uc.notificationSvc.Send(ctx, notification.Notification{
+		Username: tx.Username,
+		Title:    "Transfer failed",
+		Message: fmt.Sprintf("Your transfer of %d to %s accepted during the end of day %s, "+
+			"the amount is available in your account again.", tx.Amount, tx.DestinationAccount, outcome),
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Failed to send transfer notification")
+	}
+}
+
+// enqueue claims the transaction and stores it in the stand-in queue in one unit of work,
+// so it is never pending without its entry. It stays pending until it is forwarded.
+func (uc *Usecase) enqueue(ctx context.Context, tx transaction.Transaction) (*ProcessResponse, error) {
+	l := log.WithContext(ctx, "enqueue")
+
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		claimed, err := uc.txRepo.Claim(ctx, tx.UUID, reasonQueued)
+		if err != nil {
+			return err
+		}
+		tx = claimed
+		return uc.standInRepo.Enqueue(ctx, standin.Entry{
+			TransactionUUID:    tx.UUID,
+			SourceAccount:      tx.SourceAccount,
+			DestinationAccount: tx.DestinationAccount,
+			Amount:             tx.Amount,
+			Status:             standin.StatusQueued,
+		})
+	})
+	if err != nil && errors.Is(err, transaction.ErrConflict) {
+		l.Error().Err(err).
+			Str("uuid", tx.UUID).
+			Msg("Transaction is already being processed")
+		return nil, pkgerror.Conflict().SetMsg("Transaction is not in a valid state to be processed")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Failed to queue transfer")
+		return nil, pkgerror.InternalServerError()
+	}
+
+	return &ProcessResponse{
+		UUID:   tx.UUID,
+		Status: tx.Status,
+	}, nil
+}
+
+// checkStandInLimit refuses a transfer that would queue more than the stand-in limit of the account.
+func (uc *Usecase) checkStandInLimit(ctx context.Context, accountNumber string, amount int64) error {
+	l := log.WithContext(ctx, "checkStandInLimit")
+
+	queued, err := uc.standInRepo.GetQueuedAmount(ctx, accountNumber)
+	if err != nil {
+		l.Error().Err(err).
+			Str("account_number", accountNumber).
+			Msg("Failed to get queued amount")
+		return pkgerror.InternalServerError()
+	}
+	if !uc.standInPolicy.WithinAccountLimit(queued, amount) {
+		l.Error().
+			Str("account_number", accountNumber).
+			Int64("queued_amount", queued).
+			Int64("request_amount", amount).
+			Msg("Stand-in limit reached")
+		return pkgerror.BadRequest().SetMsg("Transfer exceeds the limit available during the end of day")
+	}
+	return nil
+}
+
+// markPending keeps the claimed transaction pending until the reconciliation settles it.
+func (uc *Usecase) markPending(ctx context.Context, tx transaction.Transaction) (*ProcessResponse, error) {
+	l := log.WithContext(ctx, "markPending")
+
+	tx.StatusReason = reasonAwaitingConfirmation
+	err := uc.txRepo.Update(ctx, tx)
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Failed to update transaction status")
+		return nil, pkgerror.InternalServerError()
+	}
 
 	return &ProcessResponse{
 		UUID:   tx.UUID,
@@ -167,6 +910,176 @@ func (uc *Usecase) Process(ctx context.Context, req *ProcessRequest) (*ProcessRe
 	}, nil
 }
 
+// markFailed records a claimed transaction that was refused before any money moved.
+func (uc *Usecase) markFailed(ctx context.Context, tx transaction.Transaction) {
+	l := log.WithContext(ctx, "markFailed")
+
+	err := tx.Transition(transaction.StatusFailed, reasonFailed)
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Failed to update transaction status")
+		return
+	}
+	uc.settleHold(ctx, tx)
+}
+
+// settleHold captures the amount held for a completed transaction
+// and releases it for a transaction that moved no money.
+// Pending transactions keep their hold until they are settled.
+func (uc *Usecase) settleHold(ctx context.Context, tx transaction.Transaction) {
+	l := log.WithContext(ctx, "settleHold")
+
+	var err error
+	switch tx.Status {
+	case transaction.StatusCompleted:
+		err = uc.accountRepo.CaptureHold(ctx, tx.UUID)
+	case transaction.StatusFailed, transaction.StatusCancelled, transaction.StatusExpired:
+		err = uc.accountRepo.ReleaseHold(ctx, tx.UUID)
+	default:
+		return
+	}
+	if err != nil && !errors.Is(err, account.ErrHoldNotFound) {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Str("status", tx.Status).
+			Msg("Failed to settle hold")
+	}
+}
+
+// processInterbank sends the transaction through its interbank rail
+// and updates the transaction status from the rail status.
+func (uc *Usecase) processInterbank(ctx context.Context, tx transaction.Transaction) (*ProcessResponse, error) {
+	l := log.WithContext(ctx, "processInterbank")
+
+	res, err := uc.transferInterbank(ctx, tx)
+	if err != nil {
+		l.Error().Err(err).
+			Str("rail", tx.Rail).
+			Msg("Failed to transfer amount")
+		if transfer.IsOutcomeUnknown(err) {
+			return uc.markPending(ctx, tx)
+		}
+		uc.markFailed(ctx, tx)
+		return nil, pkgerror.InternalServerError()
+	}
+
+	tx.TransactionReference = res.TransactionReference
+	switch {
+	case res.Settled():
+		tx.Fee = uc.chargeFee(ctx, tx)
+		err = tx.Transition(transaction.StatusCompleted, "")
+	case res.Rejected():
+		err = tx.Transition(transaction.StatusFailed, reasonRejected)
+	}
+	if err == nil {
+		err = uc.txRepo.Update(ctx, tx)
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Msg("Failed to update transaction status")
+		return nil, pkgerror.InternalServerError()
+	}
+	uc.settleHold(ctx, tx)
+
+	if res.Rejected() {
+		l.Error().
+			Str("rail", tx.Rail).
+			Str("transaction_id", tx.UUID).
+			Msg("Transfer was rejected")
+		return nil, pkgerror.BadRequest().SetMsg("Transfer was rejected by the destination bank")
+	}
+
+	return &ProcessResponse{
+		UUID:   tx.UUID,
+		Status: tx.Status,
+	}, nil
+}
+
+// chargeFee posts the fee of a settled interbank transfer, held with its amount, and returns the fee charged.
+// A fee that could not be posted is waived, so the stored fee is the one a reversal refunds.
+func (uc *Usecase) chargeFee(ctx context.Context, tx transaction.Transaction) int64 {
+	l := log.WithContext(ctx, "chargeFee")
+
+	if tx.Fee <= 0 {
+		return 0
+	}
+	err := uc.transferSvc.ChargeFee(ctx, tx.SourceAccount, tx.Fee,
+		makeTransferRemark(tx.SourceAccount, tx.DestinationAccount, tx.UUID))
+	if err != nil {
+		l.Error().Err(err).
+			Str("transaction_id", tx.UUID).
+			Int64("fee", tx.Fee).
+			Msg("Failed to charge fee, the transfer is completed without it")
+		return 0
+	}
+	return tx.Fee
+}
+
+// transferInterbank sends the transaction to the adapter of its rail.
+func (uc *Usecase) transferInterbank(ctx context.Context, tx transaction.Transaction) (transfer.InterbankTransfer, error) {
+	tf := transfer.InterbankTransfer{
+		SourceAccount:       tx.SourceAccount,
+		DestinationBankCode: tx.DestinationBankCode,
+		DestinationAccount:  tx.DestinationAccount,
+		Amount:              tx.Amount,
+		Remark:              makeTransferRemark(tx.SourceAccount, tx.DestinationAccount, tx.UUID),
+	}
+	switch tx.Rail {
+	case transfer.RailBIFast:
+		return uc.bifastSvc.CreditTransfer(ctx, tf)
+	case transfer.RailSKN:
+		return uc.sknSvc.Submit(ctx, tf)
+	case transfer.RailRTGS:
+		return uc.rtgsSvc.Settle(ctx, tf)
+	default:
+		return transfer.InterbankTransfer{}, fmt.Errorf("unknown rail %q", tx.Rail)
+	}
+}
+
+// getOwnedBeneficiary retrieves a beneficiary that belongs to the user in the context.
+func (uc *Usecase) getOwnedBeneficiary(ctx context.Context, beneficiaryUUID string) (beneficiary.Beneficiary, error) {
+	l := log.WithContext(ctx, "getOwnedBeneficiary")
+
+	userFromCtx, err := user.FromContext(ctx)
+	if err != nil {
+		l.Error().Err(err).Msg("Error getting user from context")
+		return beneficiary.Beneficiary{}, pkgerror.Unauthorized().SetMsg("User unauthorized")
+	}
+
+	b, err := uc.beneficiaryRepo.GetByUUID(ctx, beneficiaryUUID)
+	if err != nil && errors.Is(err, beneficiary.ErrNotFound) {
+		return beneficiary.Beneficiary{}, pkgerror.NotFound().SetMsg("Beneficiary not found")
+	}
+	if err != nil {
+		l.Error().Err(err).
+			Str("beneficiary_id", beneficiaryUUID).
+			Msg("Failed to get beneficiary")
+		return beneficiary.Beneficiary{}, pkgerror.InternalServerError()
+	}
+	if !b.OwnedBy(userFromCtx.Username) {
+		return beneficiary.Beneficiary{}, pkgerror.NotFound().SetMsg("Beneficiary not found")
+	}
+	return b, nil
+}
+
+// isInterbank returns true if the bank code belongs to another bank.
+func (uc *Usecase) isInterbank(bankCode string) bool {
+	return bankCode != "" && bankCode != uc.bankCode
+}
+
+// isRefused returns true if the core banking system refused the transfer for the accounts or amount,
+// as opposed to being unable to process it.
+func isRefused(err error) bool {
+	return errors.Is(err, account.ErrInsufficientBalance) ||
+		errors.Is(err, account.ErrRestricted) ||
+		errors.Is(err, account.ErrNotFound)
+}
+
 // makeTransferRemark creates a remark for the transfer transaction.
 func makeTransferRemark(srcAccount, destAccount, uuid string) string {
 	return fmt.Sprintf("TRF %s %s BNKKRD %s", srcAccount, destAccount, uuid)
@@ -4,24 +4,49 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/account"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/beneficiary"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/cbs"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/notification"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/standin"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transaction"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/transfer"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/uow"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/domain/user"
+	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/config"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/log"
 	"go.bankkrud.com/bankkrud/backend/krudapp/internal/pkg/pkgerror"
 )
 
+func newTestConfig() *config.Configs {
+	cfg := new(config.Configs)
+	cfg.Interbank.BankCode = "999"
+	cfg.Interbank.BIFastLimit = 250000000
+	cfg.Interbank.SKNLimit = 1000000000
+	cfg.Interbank.RTGSMinimum = 100000000
+	cfg.Interbank.SKNBatchCutOffs = []time.Duration{24 * time.Hour}
+	cfg.Interbank.RTGSCutOff = 24 * time.Hour
+	return cfg
+}
+
 func TestInitiate_GetCbsStatusFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -46,11 +71,18 @@ func TestInitiate_GetCbsStatusFailed(t *testing.T) {
 
 func TestInitiate_CbsNotReady(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -70,7 +102,7 @@ func TestInitiate_CbsNotReady(t *testing.T) {
 
 	assert.Nil(t, res)
 	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
+	assert.Equal(t, pkgerror.ServiceUnavailable().SetMsg(msgUnavailable), err)
 
 	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
@@ -79,11 +111,18 @@ func TestInitiate_CbsNotReady(t *testing.T) {
 
 func TestInitiate_GetSourceAccountFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -115,11 +154,18 @@ func TestInitiate_GetSourceAccountFailed(t *testing.T) {
 
 func TestInitiate_SourceAccountCannotTransfer(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -133,10 +179,10 @@ func TestInitiate_SourceAccountCannotTransfer(t *testing.T) {
 
 	accountRepo.EXPECT().Get(mock.Anything, mock.Anything).
 		Return(account.Account{
-			AccountNumber: "123",
-			FullName:      "John Doe",
-			Type:          "savings",
-			Balance:       5000,
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			AvailableBalance: 5000,
 		}, nil)
 
 	res, err := uc.Initiate(context.Background(), &InitiateRequest{
@@ -154,13 +200,64 @@ func TestInitiate_SourceAccountCannotTransfer(t *testing.T) {
 	accountRepo.AssertExpectations(t)
 }
 
-func TestInitiate_GetDestinationAccountFailed(t *testing.T) {
+func TestInitiate_SourceAccountDormant(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			Status:           account.StatusDormant,
+			AvailableBalance: 50000,
+		}, nil)
+
+	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Account cannot be debited"), err)
+}
+
+func TestInitiate_DestinationAccountFrozenForCredits(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -174,10 +271,61 @@ func TestInitiate_GetDestinationAccountFailed(t *testing.T) {
 
 	accountRepo.EXPECT().Get(mock.Anything, "123").
 		Return(account.Account{
-			AccountNumber: "123",
-			FullName:      "John Doe",
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			Status:           account.StatusActive,
+			AvailableBalance: 50000,
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{
+			AccountNumber: "456",
 			Type:          "savings",
-			Balance:       50000,
+			Status:        account.StatusFrozenCredit,
+		}, nil)
+
+	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Destination account cannot receive transfers"), err)
+}
+
+func TestInitiate_GetDestinationAccountFailed(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			AvailableBalance: 50000,
 		}, nil)
 
 	accountRepo.EXPECT().Get(mock.Anything, "456").
@@ -200,11 +348,21 @@ func TestInitiate_GetDestinationAccountFailed(t *testing.T) {
 
 func TestInitiate_CreateTransactionFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -218,24 +376,28 @@ func TestInitiate_CreateTransactionFailed(t *testing.T) {
 
 	accountRepo.EXPECT().Get(mock.Anything, "123").
 		Return(account.Account{
-			AccountNumber: "123",
-			FullName:      "John Doe",
-			Type:          "savings",
-			Balance:       50000,
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			AvailableBalance: 50000,
 		}, nil)
 
 	accountRepo.EXPECT().Get(mock.Anything, "456").
 		Return(account.Account{
-			AccountNumber: "456",
-			FullName:      "Jane Doe",
-			Type:          "savings",
-			Balance:       30000,
+			AccountNumber:    "456",
+			FullName:         "Jane Doe",
+			Type:             "savings",
+			AvailableBalance: 30000,
 		}, nil)
 
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(nil)
 	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
 		Return(errors.New("mock error"))
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, mock.Anything).
+		Return(nil)
 
-	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+	res, err := uc.Initiate(ctx, &InitiateRequest{
 		SourceAccount:      "123",
 		DestinationAccount: "456",
 		Amount:             10000,
@@ -250,13 +412,85 @@ func TestInitiate_CreateTransactionFailed(t *testing.T) {
 	accountRepo.AssertExpectations(t)
 }
 
+func TestInitiate_HoldInsufficientBalance(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			AvailableBalance: 50000,
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{
+			AccountNumber:    "456",
+			FullName:         "Jane Doe",
+			Type:             "savings",
+			AvailableBalance: 30000,
+		}, nil)
+
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(account.ErrInsufficientBalance)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Error(t, err)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Insufficient balance"), err)
+
+	cbsService.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+	accountRepo.AssertExpectations(t)
+}
+
 func TestInitiate_Success(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -270,24 +504,26 @@ func TestInitiate_Success(t *testing.T) {
 
 	accountRepo.EXPECT().Get(mock.Anything, "123").
 		Return(account.Account{
-			AccountNumber: "123",
-			FullName:      "John Doe",
-			Type:          "savings",
-			Balance:       50000,
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			AvailableBalance: 50000,
 		}, nil)
 
 	accountRepo.EXPECT().Get(mock.Anything, "456").
 		Return(account.Account{
-			AccountNumber: "456",
-			FullName:      "Jane Doe",
-			Type:          "savings",
-			Balance:       30000,
+			AccountNumber:    "456",
+			FullName:         "Jane Doe",
+			Type:             "savings",
+			AvailableBalance: 30000,
 		}, nil)
 
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(nil)
 	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
 		Return(nil)
 
-	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+	res, err := uc.Initiate(ctx, &InitiateRequest{
 		SourceAccount:      "123",
 		DestinationAccount: "456",
 		Amount:             10000,
@@ -296,20 +532,117 @@ func TestInitiate_Success(t *testing.T) {
 	assert.NotNil(t, res)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, res.UUID)
-	assert.Equal(t, "inq-success", res.Status)
+	assert.Equal(t, transaction.StatusInitiated, res.Status)
+	assert.Equal(t, transfer.RailInternal, res.Rail)
 
 	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 }
 
+func TestInitiate_AlreadyInitiated(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": "tx-123"}).
+		Return([]transaction.Transaction{
+			{
+				UUID:   "tx-123",
+				Status: transaction.StatusCompleted,
+				Rail:   transfer.RailInternal,
+			},
+		}, nil)
+
+	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &InitiateResponse{
+		UUID:   "tx-123",
+		Status: transaction.StatusCompleted,
+		Rail:   transfer.RailInternal,
+	}, res)
+	accountRepo.AssertNotCalled(t, "PlaceHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInitiate_InitiatedConcurrently(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{"uuid": "tx-123"}).
+		Return(nil, nil)
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{AccountNumber: "123", AvailableBalance: 50000}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{AccountNumber: "456"}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", "tx-123", int64(10000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(transaction.ErrDuplicate)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Transfer is already initiated"), err)
+	accountRepo.AssertNotCalled(t, "ReleaseHold", mock.Anything, mock.Anything)
+}
+
 func TestProcess_GetCbsStatusFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -335,11 +668,18 @@ func TestProcess_GetCbsStatusFailed(t *testing.T) {
 
 func TestProcess_CbsNotReady(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -360,7 +700,7 @@ func TestProcess_CbsNotReady(t *testing.T) {
 
 	assert.Nil(t, res)
 	assert.Error(t, err)
-	assert.Equal(t, pkgerror.InternalServerError(), err)
+	assert.Equal(t, pkgerror.ServiceUnavailable().SetMsg(msgUnavailable), err)
 
 	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
@@ -369,11 +709,18 @@ func TestProcess_CbsNotReady(t *testing.T) {
 
 func TestProcess_GetTransactionFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -406,11 +753,18 @@ func TestProcess_GetTransactionFailed(t *testing.T) {
 
 func TestProcess_TransactionStatusNotInquirySuccess(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -438,7 +792,7 @@ func TestProcess_TransactionStatusNotInquirySuccess(t *testing.T) {
 	assert.Nil(t, res)
 	assert.Error(t, err)
 	assert.Equal(t,
-		pkgerror.BadRequest().SetMsg("Transaction is not in a valid state to be processed"),
+		pkgerror.Conflict().SetMsg("Transaction is not in a valid state to be processed"),
 		err,
 	)
 
@@ -449,11 +803,18 @@ func TestProcess_TransactionStatusNotInquirySuccess(t *testing.T) {
 
 func TestProcess_TransferFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -468,9 +829,18 @@ func TestProcess_TransferFailed(t *testing.T) {
 	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
 		Return(transaction.Transaction{
 			UUID:               "tx-123",
-			Status:             transaction.StatusCompleted,
+			Status:             transaction.StatusInitiated,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             10000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			Status:             transaction.StatusPending,
 			SourceAccount:      "123",
 			DestinationAccount: "456",
+			Amount:             10000,
 		}, nil)
 
 	transferSvc.EXPECT().Transfer(
@@ -480,6 +850,11 @@ func TestProcess_TransferFailed(t *testing.T) {
 		int64(10000),
 		"TRF 123 456 BNKKRD tx-123",
 	).Return(transfer.Transfer{}, errors.New("mock error"))
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusFailed && tx.StatusReason == reasonFailed
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-123").
+		Return(nil)
 
 	res, err := uc.Process(context.Background(), &ProcessRequest{
 		UUID:               "tx-123",
@@ -500,11 +875,18 @@ func TestProcess_TransferFailed(t *testing.T) {
 
 func TestProcess_UpdateTransactionFailed(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -519,9 +901,18 @@ func TestProcess_UpdateTransactionFailed(t *testing.T) {
 	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
 		Return(transaction.Transaction{
 			UUID:               "tx-123",
-			Status:             transaction.StatusCompleted,
+			Status:             transaction.StatusInitiated,
+			SourceAccount:      "121",
+			DestinationAccount: "454",
+			Amount:             10000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			Status:             transaction.StatusPending,
 			SourceAccount:      "121",
 			DestinationAccount: "454",
+			Amount:             10000,
 		}, nil)
 
 	transferSvc.EXPECT().Transfer(
@@ -541,7 +932,7 @@ func TestProcess_UpdateTransactionFailed(t *testing.T) {
 	res, err := uc.Process(context.Background(), &ProcessRequest{
 		UUID:               "tx-123",
 		SourceAccount:      "121",
-		DestinationAccount: "45a",
+		DestinationAccount: "454",
 		Amount:             10000,
 	})
 
@@ -557,11 +948,18 @@ func TestProcess_UpdateTransactionFailed(t *testing.T) {
 
 func TestProcess_Success(t *testing.T) {
 	var (
-		cbsService  = cbs.NewMockService(t)
-		txRepo      = transaction.NewMockRepository(t)
-		accountRepo = account.NewMockRepository(t)
-		transferSvc = transfer.NewMockService(t)
-		uc          = NewUsecase(cbsService, txRepo, accountRepo, transferSvc)
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
 	)
 
 	log.Configure("test")
@@ -576,9 +974,18 @@ func TestProcess_Success(t *testing.T) {
 	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
 		Return(transaction.Transaction{
 			UUID:               "tx-123",
-			Status:             transaction.StatusCompleted,
+			Status:             transaction.StatusInitiated,
+			SourceAccount:      "121",
+			DestinationAccount: "454",
+			Amount:             10000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			Status:             transaction.StatusPending,
 			SourceAccount:      "121",
 			DestinationAccount: "454",
+			Amount:             10000,
 		}, nil)
 
 	transferSvc.EXPECT().Transfer(
@@ -594,6 +1001,8 @@ func TestProcess_Success(t *testing.T) {
 
 	txRepo.EXPECT().Update(mock.Anything, mock.Anything).
 		Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, "tx-123").
+		Return(nil)
 
 	res, err := uc.Process(context.Background(), &ProcessRequest{
 		UUID:               "tx-123",
@@ -605,10 +1014,1491 @@ func TestProcess_Success(t *testing.T) {
 	assert.NotNil(t, res)
 	assert.NoError(t, err)
 	assert.Equal(t, "tx-123", res.UUID)
-	assert.Equal(t, "success", res.Status)
+	assert.Equal(t, transaction.StatusCompleted, res.Status)
 
 	cbsService.AssertExpectations(t)
 	txRepo.AssertExpectations(t)
 	accountRepo.AssertExpectations(t)
 	transferSvc.AssertExpectations(t)
 }
+
+func TestProcess_AmountMismatch(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			Status:             transaction.StatusInitiated,
+			SourceAccount:      "121",
+			DestinationAccount: "454",
+			Amount:             10000,
+		}, nil)
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "121",
+		DestinationAccount: "454",
+		Amount:             90000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Amount does not match the initiated transfer"), err)
+}
+
+func TestProcess_AccountMismatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		sourceAccount      string
+		destinationAccount string
+	}{
+		{name: "other_source_account", sourceAccount: "122", destinationAccount: "454"},
+		{name: "other_destination_account", sourceAccount: "121", destinationAccount: "455"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cbsService      = cbs.NewMockService(t)
+				txRepo          = transaction.NewMockRepository(t)
+				accountRepo     = account.NewMockRepository(t)
+				beneficiaryRepo = beneficiary.NewMockRepository(t)
+				transferSvc     = transfer.NewMockService(t)
+				bifastSvc       = transfer.NewMockBIFastService(t)
+				sknSvc          = transfer.NewMockSKNService(t)
+				rtgsSvc         = transfer.NewMockRTGSService(t)
+				standInRepo     = standin.NewMockRepository(t)
+				notificationSvc = notification.NewMockService(t)
+				uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+					standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+			)
+
+			log.Configure("test")
+
+			cbsService.EXPECT().GetStatus(mock.Anything).
+				Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+			txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+				Return(transaction.Transaction{
+					UUID:               "tx-123",
+					Status:             transaction.StatusInitiated,
+					SourceAccount:      "121",
+					DestinationAccount: "454",
+					Amount:             10000,
+				}, nil)
+
+			res, err := uc.Process(context.Background(), &ProcessRequest{
+				UUID:               "tx-123",
+				SourceAccount:      tt.sourceAccount,
+				DestinationAccount: tt.destinationAccount,
+				Amount:             10000,
+			})
+
+			assert.Nil(t, res)
+			assert.Equal(t, pkgerror.BadRequest().SetMsg("Accounts do not match the initiated transfer"), err)
+		})
+	}
+}
+
+func TestInitiate_InterbankRoutedToBIFast(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			FullName:         "John Doe",
+			Type:             "savings",
+			AvailableBalance: 50000,
+		}, nil)
+
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Rail == transfer.RailBIFast &&
+			tx.DestinationBankCode == "014" &&
+			tx.DestinationAccount == "456"
+	})).Return(nil)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		SourceAccount:       "123",
+		DestinationBankCode: "014",
+		DestinationAccount:  "456",
+		Amount:              10000,
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+	assert.Equal(t, transfer.RailBIFast, res.Rail)
+
+	cbsService.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+	accountRepo.AssertExpectations(t)
+}
+
+func TestInitiate_InterbankRoutedToRTGS(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			AvailableBalance: 2000000000,
+		}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(1500000000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Rail == transfer.RailRTGS && tx.Amount == 1500000000
+	})).Return(nil)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		SourceAccount:       "123",
+		DestinationBankCode: "014",
+		DestinationAccount:  "456",
+		Amount:              1500000000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transfer.RailRTGS, res.Rail)
+}
+
+func TestInitiate_AboveTransferLimit(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			AvailableBalance: 100000000,
+		}, nil)
+
+	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             defaultTransferLimit + 1,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Amount exceeds the transfer limit"), err)
+}
+
+func TestInitiate_InterbankNoRailAvailable(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Interbank.BIFastLimit = 1000
+	cfg.Interbank.SKNLimit = 1000
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			AvailableBalance: 50000,
+		}, nil)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		SourceAccount:       "123",
+		DestinationBankCode: "014",
+		DestinationAccount:  "456",
+		Amount:              10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("No transfer rail is available for the amount at this time"), err)
+
+	cbsService.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+	accountRepo.AssertExpectations(t)
+}
+
+func TestProcess_InterbankSKNQueued(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:                "tx-123",
+			Status:              transaction.StatusInitiated,
+			Rail:                transfer.RailSKN,
+			SourceAccount:       "121",
+			DestinationBankCode: "014",
+			DestinationAccount:  "454",
+			Amount:              300000000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", mock.Anything).
+		Return(transaction.Transaction{
+			UUID:                "tx-123",
+			Status:              transaction.StatusPending,
+			Rail:                transfer.RailSKN,
+			SourceAccount:       "121",
+			DestinationBankCode: "014",
+			DestinationAccount:  "454",
+			Amount:              300000000,
+		}, nil)
+
+	sknSvc.EXPECT().Submit(mock.Anything, transfer.InterbankTransfer{
+		SourceAccount:       "121",
+		DestinationBankCode: "014",
+		DestinationAccount:  "454",
+		Amount:              300000000,
+		Remark:              "TRF 121 454 BNKKRD tx-123",
+	}).Return(transfer.InterbankTransfer{
+		Status:               transfer.RailStatusQueued,
+		TransactionReference: "skn-123",
+	}, nil)
+
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusPending && tx.TransactionReference == "skn-123"
+	})).Return(nil)
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "121",
+		DestinationAccount: "454",
+		Amount:             300000000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusPending, res.Status)
+
+	cbsService.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+	sknSvc.AssertExpectations(t)
+}
+
+func TestProcess_InterbankRejected(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:                "tx-123",
+			Status:              transaction.StatusInitiated,
+			Rail:                transfer.RailBIFast,
+			SourceAccount:       "121",
+			DestinationBankCode: "014",
+			DestinationAccount:  "454",
+			Amount:              10000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", mock.Anything).
+		Return(transaction.Transaction{
+			UUID:                "tx-123",
+			Status:              transaction.StatusPending,
+			Rail:                transfer.RailBIFast,
+			SourceAccount:       "121",
+			DestinationBankCode: "014",
+			DestinationAccount:  "454",
+			Amount:              10000,
+		}, nil)
+
+	bifastSvc.EXPECT().CreditTransfer(mock.Anything, mock.Anything).
+		Return(transfer.InterbankTransfer{
+			Status:               transfer.RailStatusRejected,
+			TransactionReference: "bifast-123",
+		}, nil)
+
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusFailed
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-123").
+		Return(nil)
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "121",
+		DestinationAccount: "454",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Transfer was rejected by the destination bank"), err)
+
+	cbsService.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+	bifastSvc.AssertExpectations(t)
+}
+
+func TestProcess_InterbankSettledChargesFee(t *testing.T) {
+	tests := []struct {
+		name      string
+		chargeErr error
+		wantFee   int64
+	}{
+		{name: "charged", wantFee: 2500},
+		{name: "waived_when_not_posted", chargeErr: errors.New("mock error"), wantFee: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cbsService      = cbs.NewMockService(t)
+				txRepo          = transaction.NewMockRepository(t)
+				accountRepo     = account.NewMockRepository(t)
+				beneficiaryRepo = beneficiary.NewMockRepository(t)
+				transferSvc     = transfer.NewMockService(t)
+				bifastSvc       = transfer.NewMockBIFastService(t)
+				sknSvc          = transfer.NewMockSKNService(t)
+				rtgsSvc         = transfer.NewMockRTGSService(t)
+				standInRepo     = standin.NewMockRepository(t)
+				notificationSvc = notification.NewMockService(t)
+				uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+					standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+			)
+
+			log.Configure("test")
+
+			tx := transaction.Transaction{
+				UUID:                "tx-123",
+				Status:              transaction.StatusInitiated,
+				Rail:                transfer.RailBIFast,
+				SourceAccount:       "121",
+				DestinationBankCode: "014",
+				DestinationAccount:  "454",
+				Amount:              10000,
+				Fee:                 2500,
+			}
+			claimed := tx
+			claimed.Status = transaction.StatusPending
+
+			cbsService.EXPECT().GetStatus(mock.Anything).
+				Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+			txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+				Return(tx, nil)
+			txRepo.EXPECT().Claim(mock.Anything, "tx-123", mock.Anything).
+				Return(claimed, nil)
+			bifastSvc.EXPECT().CreditTransfer(mock.Anything, mock.MatchedBy(func(tf transfer.InterbankTransfer) bool {
+				return tf.Amount == 10000
+			})).Return(transfer.InterbankTransfer{
+				Status:               transfer.RailStatusSettled,
+				TransactionReference: "bifast-123",
+			}, nil)
+			transferSvc.EXPECT().ChargeFee(mock.Anything, "121", int64(2500), makeTransferRemark("121", "454", "tx-123")).
+				Return(tt.chargeErr)
+			txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+				return tx.Status == transaction.StatusCompleted && tx.Fee == tt.wantFee
+			})).Return(nil)
+			accountRepo.EXPECT().CaptureHold(mock.Anything, "tx-123").
+				Return(nil)
+
+			res, err := uc.Process(context.Background(), &ProcessRequest{
+				UUID:               "tx-123",
+				SourceAccount:      "121",
+				DestinationAccount: "454",
+				Amount:             10000,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, transaction.StatusCompleted, res.Status)
+		})
+	}
+}
+
+func TestInitiate_BeneficiarySuccess(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	beneficiaryRepo.EXPECT().GetByUUID(mock.Anything, "ben-123").
+		Return(beneficiary.Beneficiary{
+			UUID:          "ben-123",
+			Username:      "johndoe",
+			BankCode:      "014",
+			AccountNumber: "456",
+		}, nil)
+
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{
+			AccountNumber:    "123",
+			AvailableBalance: 50000,
+		}, nil)
+
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.DestinationBankCode == "014" &&
+			tx.DestinationAccount == "456" &&
+			tx.Rail == transfer.RailBIFast
+	})).Return(nil)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		SourceAccount: "123",
+		BeneficiaryID: "ben-123",
+		Amount:        10000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transfer.RailBIFast, res.Rail)
+}
+
+func TestInitiate_BeneficiaryNotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	beneficiaryRepo.EXPECT().GetByUUID(mock.Anything, "ben-123").
+		Return(beneficiary.Beneficiary{
+			UUID:          "ben-123",
+			Username:      "janedoe",
+			AccountNumber: "456",
+		}, nil)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		SourceAccount: "123",
+		BeneficiaryID: "ben-123",
+		Amount:        10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Beneficiary not found"), err)
+}
+
+func TestProcess_TransactionExpired(t *testing.T) {
+	var (
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Transaction.InitiatedTTL = 15 * time.Minute
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             10000,
+			Rail:               transfer.RailInternal,
+			Status:             transaction.StatusInitiated,
+			CreatedAt:          time.Now().Add(-time.Hour),
+		}, nil)
+
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusExpired && tx.StatusReason == transaction.ReasonExpired
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-123").
+		Return(nil)
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Transaction has expired"), err)
+
+	transferSvc.AssertExpectations(t)
+}
+
+func TestProcess_TransferTimeoutMarksPending(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{
+			SystemDate: "2025-08-21",
+			IsEOD:      false,
+			IsStandIn:  false,
+		}, nil)
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			Status:             transaction.StatusInitiated,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             10000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", mock.Anything).
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			Status:             transaction.StatusPending,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             10000,
+		}, nil)
+
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(10000), "TRF 123 456 BNKKRD tx-123").
+		Return(transfer.Transfer{}, context.DeadlineExceeded)
+
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusPending && tx.StatusReason != ""
+	})).Return(nil)
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusPending, res.Status)
+}
+
+func TestReconcile(t *testing.T) {
+	var (
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.Transaction.ReconcileAfter = time.Minute
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	updatedAt := time.Now().Add(-time.Hour)
+	txRepo.EXPECT().GetByParams(mock.Anything, map[string]any{
+		"transaction_type": "transfer",
+		"status":           transaction.StatusPending,
+	}).Return([]transaction.Transaction{
+		{UUID: "tx-1", Rail: transfer.RailInternal, SourceAccount: "123", DestinationAccount: "456", Status: transaction.StatusPending, UpdatedAt: updatedAt},
+		{UUID: "tx-2", Rail: transfer.RailInternal, SourceAccount: "123", DestinationAccount: "789", Status: transaction.StatusPending, UpdatedAt: updatedAt},
+		{UUID: "tx-3", Rail: transfer.RailSKN, TransactionReference: "SKN-1", Status: transaction.StatusPending, UpdatedAt: updatedAt},
+		{UUID: "tx-4", Rail: transfer.RailInternal, Status: transaction.StatusPending, UpdatedAt: time.Now()},
+	}, nil)
+
+	transferSvc.EXPECT().GetTransferStatus(mock.Anything, "TRF 123 456 BNKKRD tx-1").
+		Return(transfer.Transfer{Status: transfer.StatusSuccess, TransactionReference: "ref-1"}, nil)
+	transferSvc.EXPECT().GetTransferStatus(mock.Anything, "TRF 123 789 BNKKRD tx-2").
+		Return(transfer.Transfer{}, transfer.ErrTransferNotFound)
+	sknSvc.EXPECT().GetStatus(mock.Anything, "SKN-1").
+		Return(transfer.InterbankTransfer{Status: transfer.RailStatusQueued}, nil)
+
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-1" && tx.Status == transaction.StatusCompleted && tx.TransactionReference == "ref-1"
+	})).Return(nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-2" && tx.Status == transaction.StatusFailed && tx.StatusReason != ""
+	})).Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, "tx-1").
+		Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-2").
+		Return(nil)
+
+	err := uc.Reconcile(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestDetail_NotOwned(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:            "tx-123",
+			TransactionType: "transfer",
+			Username:        "janedoe",
+		}, nil)
+
+	res, err := uc.Detail(ctx, &DetailRequest{UUID: "tx-123"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.NotFound().SetMsg("Transfer not found"), err)
+}
+
+func TestProcess_AlreadyClaimed(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21"}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:               "tx-123",
+			Status:             transaction.StatusInitiated,
+			SourceAccount:      "121",
+			DestinationAccount: "454",
+			Amount:             10000,
+		}, nil)
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", reasonProcessing).
+		Return(transaction.Transaction{}, transaction.ErrConflict)
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "121",
+		DestinationAccount: "454",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t,
+		pkgerror.Conflict().SetMsg("Transaction is not in a valid state to be processed"),
+		err,
+	)
+	transferSvc.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancel_Success(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:            "tx-123",
+			TransactionType: "transfer",
+			Status:          transaction.StatusInitiated,
+			Username:        "johndoe",
+		}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.Status == transaction.StatusCancelled && tx.StatusReason == reasonCancelled
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-123").
+		Return(nil)
+
+	res, err := uc.Cancel(ctx, &CancelRequest{UUID: "tx-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &CancelResponse{UUID: "tx-123", Status: transaction.StatusCancelled}, res)
+}
+
+func TestCancel_NotInitiated(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(transaction.Transaction{
+			UUID:            "tx-123",
+			TransactionType: "transfer",
+			Status:          transaction.StatusCompleted,
+			Username:        "johndoe",
+		}, nil)
+
+	res, err := uc.Cancel(ctx, &CancelRequest{UUID: "tx-123"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.Conflict().SetMsg("Only initiated transfers can be cancelled"), err)
+}
+
+func TestInitiate_StandInAccepted(t *testing.T) {
+	var (
+		ctx = context.WithValue(context.Background(), user.ContextKey, user.User{
+			Username: "johndoe",
+		})
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.StandIn.Limit = 50000
+	cfg.StandIn.AccountLimit = 100000
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{AccountNumber: "123", Type: "savings", AvailableBalance: 50000}, nil)
+	standInRepo.EXPECT().GetQueuedAmount(mock.Anything, "123").
+		Return(int64(60000), nil)
+	accountRepo.EXPECT().Get(mock.Anything, "456").
+		Return(account.Account{AccountNumber: "456", Type: "savings"}, nil)
+	accountRepo.EXPECT().PlaceHold(mock.Anything, "123", mock.Anything, int64(10000)).
+		Return(nil)
+	txRepo.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := uc.Initiate(ctx, &InitiateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusInitiated, res.Status)
+}
+
+func TestInitiate_StandInAccountLimitReached(t *testing.T) {
+	var (
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.StandIn.Limit = 50000
+	cfg.StandIn.AccountLimit = 100000
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+	accountRepo.EXPECT().Get(mock.Anything, "123").
+		Return(account.Account{AccountNumber: "123", Type: "savings", AvailableBalance: 50000}, nil)
+	standInRepo.EXPECT().GetQueuedAmount(mock.Anything, "123").
+		Return(int64(95000), nil)
+
+	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.BadRequest().SetMsg("Transfer exceeds the limit available during the end of day"), err)
+}
+
+func TestInitiate_StandInOverLimit(t *testing.T) {
+	var (
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.StandIn.Limit = 50000
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+
+	res, err := uc.Initiate(context.Background(), &InitiateRequest{
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             60000,
+	})
+
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.ServiceUnavailable().SetMsg(msgUnavailable), err)
+}
+
+func TestProcess_StandInQueued(t *testing.T) {
+	var (
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.StandIn.Limit = 50000
+	unitOfWork := uow.NewMockUnitOfWork(t)
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, unitOfWork)
+
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	queuedTx := transaction.Transaction{
+		UUID:               "tx-123",
+		Status:             transaction.StatusInitiated,
+		Rail:               transfer.RailInternal,
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	}
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(queuedTx, nil)
+	standInRepo.EXPECT().GetQueuedAmount(mock.Anything, "123").
+		Return(int64(0), nil)
+	queuedTx.Status = transaction.StatusPending
+	queuedTx.StatusReason = reasonQueued
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", reasonQueued).
+		Return(queuedTx, nil)
+	standInRepo.EXPECT().Enqueue(mock.Anything, standin.Entry{
+		TransactionUUID:    "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+		Status:             standin.StatusQueued,
+	}).Return(nil)
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.StatusPending, res.Status)
+}
+
+func TestProcess_StandInEnqueueFailed(t *testing.T) {
+	var (
+		cfg             = newTestConfig()
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+	)
+
+	log.Configure("test")
+
+	cfg.StandIn.Limit = 50000
+	unitOfWork := uow.NewMockUnitOfWork(t)
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, unitOfWork)
+
+	unitOfWork.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	queuedTx := transaction.Transaction{
+		UUID:               "tx-123",
+		Status:             transaction.StatusInitiated,
+		Rail:               transfer.RailInternal,
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	}
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-123").
+		Return(queuedTx, nil)
+	standInRepo.EXPECT().GetQueuedAmount(mock.Anything, "123").
+		Return(int64(0), nil)
+	queuedTx.Status = transaction.StatusPending
+	queuedTx.StatusReason = reasonQueued
+	txRepo.EXPECT().Claim(mock.Anything, "tx-123", reasonQueued).
+		Return(queuedTx, nil)
+	standInRepo.EXPECT().Enqueue(mock.Anything, standin.Entry{
+		TransactionUUID:    "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+		Status:             standin.StatusQueued,
+	}).Return(errors.New("mock error"))
+
+	res, err := uc.Process(context.Background(), &ProcessRequest{
+		UUID:               "tx-123",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+	})
+
+	// The claim is rolled back with the entry, the transaction stays initiated with its hold.
+	assert.Nil(t, res)
+	assert.Equal(t, pkgerror.InternalServerError(), err)
+}
+
+func TestReplay(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-22"}, nil)
+	standInRepo.EXPECT().GetQueued(mock.Anything).
+		Return([]standin.Entry{
+			{Sequence: 1, TransactionUUID: "tx-1", SourceAccount: "123", DestinationAccount: "456", Amount: 10000, Status: standin.StatusQueued},
+			{Sequence: 2, TransactionUUID: "tx-2", SourceAccount: "123", DestinationAccount: "789", Amount: 20000, Status: standin.StatusQueued},
+		}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, uuid string) (transaction.Transaction, error) {
+			tx := transaction.Transaction{
+				UUID:               uuid,
+				Status:             transaction.StatusPending,
+				StatusReason:       reasonQueued,
+				SourceAccount:      "123",
+				DestinationAccount: "456",
+				Amount:             10000,
+				Username:           "johndoe",
+			}
+			if uuid == "tx-2" {
+				tx.DestinationAccount = "789"
+				tx.Amount = 20000
+			}
+			return tx, nil
+		})
+
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(10000), "TRF 123 456 BNKKRD tx-1").
+		Return(transfer.Transfer{TransactionReference: "ref-1"}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-1" && tx.Status == transaction.StatusCompleted && tx.TransactionReference == "ref-1"
+	})).Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, "tx-1").
+		Return(nil)
+	standInRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(e standin.Entry) bool {
+		return e.TransactionUUID == "tx-1" && e.Status == standin.StatusForwarded && !e.ForwardedAt.IsZero()
+	})).Return(nil)
+
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "789", int64(20000), "TRF 123 789 BNKKRD tx-2").
+		Return(transfer.Transfer{}, account.ErrInsufficientBalance)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-2" && tx.Status == transaction.StatusFailed && tx.StatusReason == reasonStandInRefused
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-2").
+		Return(nil)
+	notificationSvc.EXPECT().Send(mock.Anything, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Username == "johndoe" && n.Title == "Transfer failed"
+	})).Return(nil)
+	standInRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(e standin.Entry) bool {
+		return e.TransactionUUID == "tx-2" && e.Status == standin.StatusRejected && e.Reason == reasonStandInRefused
+	})).Return(nil)
+
+	err := uc.Replay(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestReplay_StopsAtUnsentTransfer(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-22"}, nil)
+	standInRepo.EXPECT().GetQueued(mock.Anything).
+		Return([]standin.Entry{
+			{Sequence: 1, TransactionUUID: "tx-1", SourceAccount: "123", DestinationAccount: "456", Amount: 10000, Status: standin.StatusQueued},
+			{Sequence: 2, TransactionUUID: "tx-2", SourceAccount: "123", DestinationAccount: "789", Amount: 20000, Status: standin.StatusQueued},
+		}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-1").
+		Return(transaction.Transaction{
+			UUID:               "tx-1",
+			Status:             transaction.StatusPending,
+			StatusReason:       reasonQueued,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             10000,
+		}, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(10000), "TRF 123 456 BNKKRD tx-1").
+		Return(transfer.Transfer{}, errors.New("system unavailable"))
+	standInRepo.EXPECT().Update(mock.Anything, standin.Entry{
+		Sequence:           1,
+		TransactionUUID:    "tx-1",
+		SourceAccount:      "123",
+		DestinationAccount: "456",
+		Amount:             10000,
+		Status:             standin.StatusQueued,
+		Reason:             "system unavailable",
+		Attempts:           1,
+	}).Return(nil)
+
+	err := uc.Replay(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestReplay_ParksAfterMaxAttempts(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		cfg             = newTestConfig()
+	)
+
+	log.Configure("test")
+
+	cfg.StandIn.MaxAttempts = 3
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-22"}, nil)
+	standInRepo.EXPECT().GetQueued(mock.Anything).
+		Return([]standin.Entry{
+			{Sequence: 1, TransactionUUID: "tx-1", SourceAccount: "123", DestinationAccount: "456", Amount: 10000, Status: standin.StatusQueued, Attempts: 2},
+			{Sequence: 2, TransactionUUID: "tx-2", SourceAccount: "123", DestinationAccount: "789", Amount: 20000, Status: standin.StatusQueued},
+		}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-1").
+		Return(transaction.Transaction{
+			UUID:               "tx-1",
+			Status:             transaction.StatusPending,
+			StatusReason:       reasonQueued,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             10000,
+		}, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(10000), "TRF 123 456 BNKKRD tx-1").
+		Return(transfer.Transfer{}, errors.New("invalid remark"))
+	transferSvc.EXPECT().GetTransferStatus(mock.Anything, "TRF 123 456 BNKKRD tx-1").
+		Return(transfer.Transfer{}, transfer.ErrTransferNotFound)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-1" && tx.Status == transaction.StatusFailed && tx.StatusReason == reasonStandInParked
+	})).Return(nil)
+	accountRepo.EXPECT().ReleaseHold(mock.Anything, "tx-1").Return(nil)
+	notificationSvc.EXPECT().Send(mock.Anything, mock.MatchedBy(func(n notification.Notification) bool {
+		return n.Title == "Transfer failed"
+	})).Return(nil)
+	standInRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(e standin.Entry) bool {
+		return e.TransactionUUID == "tx-1"
+	})).
+		RunAndReturn(func(ctx context.Context, e standin.Entry) error {
+			assert.Equal(t, standin.StatusParked, e.Status)
+			assert.Equal(t, 3, e.Attempts)
+			assert.Equal(t, "invalid remark", e.Reason)
+			return nil
+		})
+
+	// The parked transfer no longer holds up the next one.
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-2").
+		Return(transaction.Transaction{
+			UUID:               "tx-2",
+			Status:             transaction.StatusPending,
+			StatusReason:       reasonQueued,
+			SourceAccount:      "123",
+			DestinationAccount: "789",
+			Amount:             20000,
+		}, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "789", int64(20000), "TRF 123 789 BNKKRD tx-2").
+		Return(transfer.Transfer{TransactionReference: "ref-2", Status: transfer.StatusSuccess}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, "tx-2").Return(nil)
+	standInRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(e standin.Entry) bool {
+		return e.TransactionUUID == "tx-2" && e.Status == standin.StatusForwarded
+	})).Return(nil)
+
+	err := uc.Replay(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestReplay_ParkedTransferReceived(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		cfg             = newTestConfig()
+	)
+
+	log.Configure("test")
+
+	cfg.StandIn.MaxAttempts = 3
+	uc := NewUsecase(cfg, cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+		standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-22"}, nil)
+	standInRepo.EXPECT().GetQueued(mock.Anything).
+		Return([]standin.Entry{
+			{Sequence: 1, TransactionUUID: "tx-1", SourceAccount: "123", DestinationAccount: "456", Amount: 10000, Status: standin.StatusQueued, Attempts: 2},
+		}, nil)
+	txRepo.EXPECT().GetByUUID(mock.Anything, "tx-1").
+		Return(transaction.Transaction{
+			UUID:               "tx-1",
+			Status:             transaction.StatusPending,
+			StatusReason:       reasonQueued,
+			SourceAccount:      "123",
+			DestinationAccount: "456",
+			Amount:             10000,
+		}, nil)
+	transferSvc.EXPECT().Transfer(mock.Anything, "123", "456", int64(10000), "TRF 123 456 BNKKRD tx-1").
+		Return(transfer.Transfer{}, errors.New("invalid remark"))
+	// The transfer reached the core banking system although its forward failed.
+	transferSvc.EXPECT().GetTransferStatus(mock.Anything, "TRF 123 456 BNKKRD tx-1").
+		Return(transfer.Transfer{TransactionReference: "ref-1", Status: transfer.StatusSuccess}, nil)
+	txRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(tx transaction.Transaction) bool {
+		return tx.UUID == "tx-1" && tx.Status == transaction.StatusCompleted && tx.TransactionReference == "ref-1"
+	})).Return(nil)
+	accountRepo.EXPECT().CaptureHold(mock.Anything, "tx-1").Return(nil)
+	standInRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(e standin.Entry) bool {
+		return e.TransactionUUID == "tx-1" && e.Status == standin.StatusParked
+	})).Return(nil)
+
+	err := uc.Replay(context.Background())
+
+	assert.NoError(t, err)
+	notificationSvc.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestReplay_CbsNotReady(t *testing.T) {
+	var (
+		cbsService      = cbs.NewMockService(t)
+		txRepo          = transaction.NewMockRepository(t)
+		accountRepo     = account.NewMockRepository(t)
+		beneficiaryRepo = beneficiary.NewMockRepository(t)
+		transferSvc     = transfer.NewMockService(t)
+		bifastSvc       = transfer.NewMockBIFastService(t)
+		sknSvc          = transfer.NewMockSKNService(t)
+		rtgsSvc         = transfer.NewMockRTGSService(t)
+		standInRepo     = standin.NewMockRepository(t)
+		notificationSvc = notification.NewMockService(t)
+		uc              = NewUsecase(newTestConfig(), cbsService, txRepo, accountRepo, beneficiaryRepo, transferSvc, bifastSvc, sknSvc, rtgsSvc,
+			standInRepo, notificationSvc, uow.NewMockUnitOfWork(t))
+	)
+
+	log.Configure("test")
+
+	cbsService.EXPECT().GetStatus(mock.Anything).
+		Return(cbs.Status{SystemDate: "2025-08-21", IsEOD: true}, nil)
+
+	err := uc.Replay(context.Background())
+
+	assert.NoError(t, err)
+}
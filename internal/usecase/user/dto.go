@@ -14,7 +14,8 @@ type CreateRequest struct {
 }
 
 type CreateResponse struct {
-	Message string `json:"message"`
+	Message       string `json:"message"`
+	AccountNumber string `json:"account_number,omitempty"`
 }
 
 type GetByUsernameRequest struct {
@@ -48,7 +48,7 @@ func (uc *Usecase) Create(ctx context.Context, req *CreateRequest) (*CreateRespo
 		return nil, pkgerror.InternalServerError().SetMsg("Error creating user")
 	}
 
-	err = uc.userRepo.Create(ctx, user.User{
+	newUser := user.User{
 		CIF:         acc.CIF,
 		UUID:        uuid.New().String(),
 		Username:    req.Username,
@@ -60,7 +60,8 @@ func (uc *Usecase) Create(ctx context.Context, req *CreateRequest) (*CreateRespo
 		DateOfBirth: dob,
 		Status:      user.StatusActive,
 		Password:    hashedPassword,
-	})
+	}
+	err = uc.userRepo.Create(ctx, newUser)
 	if err != nil && errors.Is(err, user.ErrDuplicateUserData) {
 		l.Error().Err(err).Msg("Duplicate user data")
 		return nil, pkgerror.Conflict().SetMsg(err.Error())
@@ -70,8 +71,22 @@ func (uc *Usecase) Create(ctx context.Context, req *CreateRequest) (*CreateRespo
 		return nil, pkgerror.InternalServerError().SetMsg("Error creating user")
 	}
 
+	// The user can open the account later through the accounts endpoint,
+	// so a failure here does not fail the registration.
+	opened, err := uc.accountRepo.Open(ctx, account.Account{
+		CIF:      acc.CIF,
+		FullName: newUser.FullName(),
+		Type:     account.TypeSavings,
+	})
+	if err != nil {
+		l.Error().Err(err).
+			Str("cif", acc.CIF).
+			Msg("Error opening account")
+	}
+
 	return &CreateResponse{
-		Message: "User registered successfully",
+		Message:       "User registered successfully",
+		AccountNumber: opened.AccountNumber,
 	}, nil
 }
 
@@ -6,61 +6,206 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// HeaderRequestID identifies a request, it is the same across the retries of the request.
+	HeaderRequestID = "X-Request-ID"
+	// HeaderIdempotencyKey lets the service recognize a payment it has already executed.
+	HeaderIdempotencyKey = "Idempotency-Key"
 )
 
+// Doer sends a request, e.g. *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is a function that sends a request.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the sending of every attempt of a request, e.g. for logging, metrics or signing.
+// The body of the request can be read again with req.GetBody.
+type Middleware func(next Doer) Doer
+
+// Option configures a Client.
+type Option func(c *Client)
+
+// WithRetryPolicy replaces the DefaultRetryPolicy of the requests that are safe to send again.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// WithMiddleware adds middlewares to the client, the first one is the outermost.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// Signer signs a request, e.g. *signature.Signer.
+type Signer interface {
+	Sign(req *http.Request) error
+}
+
+// WithSigner signs every attempt of a request with the signer,
+// it goes after the middlewares that change the request.
+func WithSigner(s Signer) Option {
+	return WithMiddleware(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			err := s.Sign(req)
+			if err != nil {
+				return nil, err
+			}
+			return next.Do(req)
+		})
+	})
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context that sends the request ID as the X-Request-ID of the requests made with it,
+// a new one is generated for every request otherwise.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
 // Client is the API client that performs all operations
 // against a TapMoney service.
 type Client struct {
-	addr   string
-	client *http.Client
+	addr        string
+	doer        Doer
+	retry       RetryPolicy
+	middlewares []Middleware
 }
 
 // NewClient creates a new instance of the TapMoney API client with the given base URL.
-func NewClient(client *http.Client, addr string) *Client {
-	return &Client{
-		addr:   addr,
-		client: client,
+func NewClient(client *http.Client, addr string, opts ...Option) *Client {
+	c := &Client{
+		addr:  addr,
+		retry: DefaultRetryPolicy,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.doer = client
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		c.doer = c.middlewares[i](c.doer)
+	}
+	return c
 }
 
+// Inquiry moves no money, it is sent with an idempotency key of its own so it is retried
+// by the retry policy of the client.
 func (c *Client) Inquiry(ctx context.Context, request InquiryRequest) (Response[InquiryResponse], error) {
-	url := c.addr + "/api/v1/tapmoney/inquiry"
+	header := http.Header{}
+	header.Set(HeaderIdempotencyKey, uuid.New().String())
 	var apiRes Response[InquiryResponse]
-	err := c.doRequest(ctx, http.MethodPost, url, request, &apiRes)
+	err := c.Do(ctx, http.MethodPost, "/api/v1/tapmoney/inquiry", request, &apiRes, header)
 	if err != nil {
 		return Response[InquiryResponse]{}, err
 	}
 	return apiRes, nil
 }
 
+// Payment is sent with the transaction ID as its idempotency key, so the service executes it once
+// however often it is retried. A payment without a transaction ID is sent once.
 func (c *Client) Payment(ctx context.Context, request PaymentRequest) (Response[PaymentResponse], error) {
-	url := c.addr + "/api/v1/tapmoney/payment"
+	header := http.Header{}
+	if request.TransactionID != "" {
+		header.Set(HeaderIdempotencyKey, request.TransactionID)
+	}
 	var apiRes Response[PaymentResponse]
-	err := c.doRequest(ctx, http.MethodPost, url, request, &apiRes)
+	err := c.Do(ctx, http.MethodPost, "/api/v1/tapmoney/payment", request, &apiRes, header)
 	if err != nil {
 		return Response[PaymentResponse]{}, err
 	}
 	return apiRes, nil
 }
 
-func (c *Client) doRequest(ctx context.Context, method, url string, requestBody any, responseBody any) error {
+// Do sends the request body as JSON to the path and decodes the response into responseBody.
+// A request with a safe method or an idempotency key in the header is retried by the retry policy
+// of the client, any other request is sent once since it could execute twice.
+// A non-2xx status or an unsuccessful response is returned as an *Error.
+func (c *Client) Do(ctx context.Context, method, path string, requestBody any, responseBody any, header http.Header) error {
 	bBody, err := json.Marshal(requestBody)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(bBody))
-	if err != nil {
-		return err
+
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	if !ok || requestID == "" {
+		requestID = uuid.New().String()
 	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
+	retry := RetryPolicy{}
+	if isSafe(method) || header.Get(HeaderIdempotencyKey) != "" {
+		retry = c.retry
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, c.addr+path, bytes.NewReader(bBody))
+		if err != nil {
+			return err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(HeaderRequestID, requestID)
+
+		resp, err := c.doer.Do(req)
+		if attempt+1 >= retry.MaxAttempts || !retryable(ctx, resp, err) {
+			if err != nil {
+				return err
+			}
+			return decodeResponse(resp, requestID, responseBody)
+		}
+
+		d := retry.delay(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// isSafe reports whether the method only reads, so a request with it can be sent again.
+func isSafe(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
 	}
+	return false
+}
+
+// decodeResponse reads the body into responseBody, a non-2xx status or an unsuccessful response is returned as an *Error.
+func decodeResponse(resp *http.Response, requestID string, responseBody any) error {
 	defer resp.Body.Close()
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
+
+	var envelope Response[json.RawMessage]
+	_ = json.Unmarshal(b, &envelope)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return newError(resp.StatusCode, requestID, envelope.Error)
+	}
+	if !envelope.Success && envelope.Error != nil {
+		return newError(resp.StatusCode, requestID, envelope.Error)
+	}
+
 	err = json.Unmarshal(b, responseBody)
 	if err != nil {
 		return err
@@ -0,0 +1,328 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    time.Millisecond,
+}
+
+// recorder is a TapMoney service answering with the statuses in order, the last one repeats.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	body     string
+	requests []*http.Request
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := r.statuses[min(len(r.requests), len(r.statuses)-1)]
+	r.requests = append(r.requests, req)
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(r.body))
+}
+
+func newTestClient(t *testing.T, rec *recorder) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(rec)
+	t.Cleanup(srv.Close)
+	return NewClient(srv.Client(), srv.URL, WithRetryPolicy(testRetryPolicy))
+}
+
+func TestDo_Retry(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		header   http.Header
+		statuses []int
+		wantErr  error
+		calls    int
+	}{
+		{
+			name:     "safe_method_retried_until_success",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			calls:    3,
+		},
+		{
+			name:     "safe_method_retried_up_to_max_attempts",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusServiceUnavailable},
+			wantErr:  ErrServer,
+			calls:    3,
+		},
+		{
+			name:     "safe_method_not_retried_on_client_error",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadRequest},
+			wantErr:  ErrBadRequest,
+			calls:    1,
+		},
+		{
+			name:     "safe_method_retried_when_rate_limited",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			calls:    2,
+		},
+		{
+			name:     "post_without_idempotency_key_sent_once",
+			method:   http.MethodPost,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantErr:  ErrServer,
+			calls:    1,
+		},
+		{
+			name:     "post_with_idempotency_key_retried",
+			method:   http.MethodPost,
+			header:   http.Header{HeaderIdempotencyKey: {"tx-1"}},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			calls:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{statuses: tt.statuses, body: `{"success":true}`}
+			c := newTestClient(t, rec)
+
+			var res Response[struct{}]
+			err := c.Do(context.Background(), tt.method, "/api/v1/resource", struct{}{}, &res, tt.header)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(rec.requests) != tt.calls {
+				t.Errorf("service called %d times, want %d", len(rec.requests), tt.calls)
+			}
+		})
+	}
+}
+
+func TestDo_Headers(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}, body: `{"success":true}`}
+	c := newTestClient(t, rec)
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	var res Response[struct{}]
+	err := c.Do(ctx, http.MethodPost, "/api/v1/resource", struct{}{}, &res, http.Header{HeaderIdempotencyKey: {"tx-1"}})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	for i, req := range rec.requests {
+		if got := req.Header.Get(HeaderRequestID); got != "req-1" {
+			t.Errorf("attempt %d %s = %q, want req-1", i, HeaderRequestID, got)
+		}
+		if got := req.Header.Get(HeaderIdempotencyKey); got != "tx-1" {
+			t.Errorf("attempt %d %s = %q, want tx-1", i, HeaderIdempotencyKey, got)
+		}
+	}
+}
+
+func TestDo_GeneratedRequestID(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}, body: `{"success":true}`}
+	c := newTestClient(t, rec)
+
+	var res Response[struct{}]
+	err := c.Do(context.Background(), http.MethodGet, "/api/v1/resource", struct{}{}, &res, nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	first := rec.requests[0].Header.Get(HeaderRequestID)
+	if first == "" {
+		t.Fatalf("%s is not set", HeaderRequestID)
+	}
+	if got := rec.requests[1].Header.Get(HeaderRequestID); got != first {
+		t.Errorf("retry %s = %q, want the one of the first attempt %q", HeaderRequestID, got, first)
+	}
+}
+
+func TestDo_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantErr     error
+		wantName    string
+		wantMessage string
+	}{
+		{
+			name:        "bad_request",
+			status:      http.StatusBadRequest,
+			body:        `{"success":false,"error":{"name":"INVALID_CARD","message":"card number is invalid"}}`,
+			wantErr:     ErrBadRequest,
+			wantName:    "INVALID_CARD",
+			wantMessage: "card number is invalid",
+		},
+		{
+			name:        "unauthorized",
+			status:      http.StatusUnauthorized,
+			wantErr:     ErrUnauthorized,
+			wantMessage: "Unauthorized",
+		},
+		{
+			name:        "forbidden",
+			status:      http.StatusForbidden,
+			wantErr:     ErrUnauthorized,
+			wantMessage: "Forbidden",
+		},
+		{
+			name:        "not_found",
+			status:      http.StatusNotFound,
+			wantErr:     ErrNotFound,
+			wantMessage: "Not Found",
+		},
+		{
+			name:        "conflict",
+			status:      http.StatusConflict,
+			body:        `{"success":false,"error":{"name":"DUPLICATE","message":"idempotency key reused"}}`,
+			wantErr:     ErrConflict,
+			wantName:    "DUPLICATE",
+			wantMessage: "idempotency key reused",
+		},
+		{
+			name:        "unprocessable",
+			status:      http.StatusUnprocessableEntity,
+			wantErr:     ErrBadRequest,
+			wantMessage: "Unprocessable Entity",
+		},
+		{
+			name:        "too_many_requests",
+			status:      http.StatusTooManyRequests,
+			wantErr:     ErrTooManyRequests,
+			wantMessage: "Too Many Requests",
+		},
+		{
+			name:        "server_error",
+			status:      http.StatusInternalServerError,
+			body:        `not json`,
+			wantErr:     ErrServer,
+			wantMessage: "Internal Server Error",
+		},
+		{
+			name:        "unsuccessful_response",
+			status:      http.StatusOK,
+			body:        `{"success":false,"error":{"name":"LIMIT","message":"card limit reached"}}`,
+			wantErr:     ErrRejected,
+			wantName:    "LIMIT",
+			wantMessage: "card limit reached",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{statuses: []int{tt.status}, body: tt.body}
+			c := newTestClient(t, rec)
+			ctx := WithRequestID(context.Background(), "req-1")
+
+			var res Response[struct{}]
+			err := c.Do(ctx, http.MethodPost, "/api/v1/resource", struct{}{}, &res, nil)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Do() error = %v, want an *Error", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.RequestID != "req-1" {
+				t.Errorf("Error = %d %s, want %d req-1", apiErr.StatusCode, apiErr.RequestID, tt.status)
+			}
+			if apiErr.Name != tt.wantName || apiErr.Message != tt.wantMessage {
+				t.Errorf("Error = %q %q, want %q %q", apiErr.Name, apiErr.Message, tt.wantName, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDo_ContextCancelledDuringBackoff(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusServiceUnavailable}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+	c := NewClient(srv.Client(), srv.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var res Response[struct{}]
+	err := c.Do(ctx, http.MethodGet, "/api/v1/resource", struct{}{}, &res, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want context.DeadlineExceeded", err)
+	}
+	if len(rec.requests) != 1 {
+		t.Errorf("service called %d times, want 1", len(rec.requests))
+	}
+}
+
+func TestInquiry_Retried(t *testing.T) {
+	rec := &recorder{
+		statuses: []int{http.StatusBadGateway, http.StatusOK},
+		body:     `{"success":true,"data":{"sequenceNumber":"seq-1","amount":10000}}`,
+	}
+	c := newTestClient(t, rec)
+
+	res, err := c.Inquiry(context.Background(), InquiryRequest{CardNumber: "6011000000000001", Amount: 10000})
+
+	if err != nil {
+		t.Fatalf("Inquiry() error = %v", err)
+	}
+	if res.Data.SequenceNumber != "seq-1" {
+		t.Errorf("Inquiry() sequence number = %q, want seq-1", res.Data.SequenceNumber)
+	}
+	if len(rec.requests) != 2 {
+		t.Fatalf("service called %d times, want 2", len(rec.requests))
+	}
+	key := rec.requests[0].Header.Get(HeaderIdempotencyKey)
+	if key == "" || rec.requests[1].Header.Get(HeaderIdempotencyKey) != key {
+		t.Errorf("idempotency keys = %q and %q, want the same key on both attempts",
+			key, rec.requests[1].Header.Get(HeaderIdempotencyKey))
+	}
+}
+
+func TestPayment(t *testing.T) {
+	tests := []struct {
+		name          string
+		transactionID string
+		calls         int
+		wantErr       error
+	}{
+		{name: "retried_with_the_transaction_id", transactionID: "tx-1", calls: 2},
+		{name: "sent_once_without_transaction_id", calls: 1, wantErr: ErrServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{
+				statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+				body:     `{"success":true,"data":{"transactionID":"tx-1","status":"success"}}`,
+			}
+			c := newTestClient(t, rec)
+
+			_, err := c.Payment(context.Background(), PaymentRequest{TransactionID: tt.transactionID, Amount: 10000})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Payment() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(rec.requests) != tt.calls {
+				t.Fatalf("service called %d times, want %d", len(rec.requests), tt.calls)
+			}
+			if got := rec.requests[0].Header.Get(HeaderIdempotencyKey); got != tt.transactionID {
+				t.Errorf("%s = %q, want %q", HeaderIdempotencyKey, got, tt.transactionID)
+			}
+		})
+	}
+}
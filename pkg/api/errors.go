@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrBadRequest is returned when the service rejects the request as invalid.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is returned when the service refuses the credentials of the client.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound is returned when the service has no such resource.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when the request conflicts with the state of the resource,
+	// e.g. an idempotency key reused with a different request.
+	ErrConflict = errors.New("conflict")
+	// ErrTooManyRequests is returned when the service rate limits the client.
+	ErrTooManyRequests = errors.New("too many requests")
+	// ErrServer is returned when the service fails to handle the request.
+	ErrServer = errors.New("server error")
+	// ErrRejected is returned when the service answers with a success status but an unsuccessful response.
+	ErrRejected = errors.New("rejected")
+)
+
+// Error is an error response of the TapMoney service.
+// It matches one of the sentinel errors above with errors.Is.
+type Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// RequestID is the X-Request-ID the request was sent with.
+	RequestID string
+	// Name and Message are from the ErrorResponse of the body, when there is one.
+	Name    string
+	Message string
+
+	kind error
+}
+
+func newError(statusCode int, requestID string, errRes *ErrorResponse) *Error {
+	e := &Error{
+		StatusCode: statusCode,
+		RequestID:  requestID,
+		Message:    http.StatusText(statusCode),
+		kind:       errorKind(statusCode),
+	}
+	if errRes != nil {
+		e.Name = errRes.Name
+		e.Message = errRes.Message
+	}
+	return e
+}
+
+func errorKind(statusCode int) error {
+	switch {
+	case statusCode < http.StatusBadRequest:
+		return ErrRejected
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusConflict:
+		return ErrConflict
+	case statusCode == http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case statusCode >= http.StatusInternalServerError:
+		return ErrServer
+	}
+	return ErrBadRequest
+}
+
+func (e *Error) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("tapmoney: %d %s: %s", e.StatusCode, e.Name, e.Message)
+	}
+	return fmt.Sprintf("tapmoney: %d %s", e.StatusCode, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
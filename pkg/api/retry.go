@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy is how the client retries the requests that are safe to send again,
+// the ones with a safe method or an idempotency key.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one, one or less turns the retries off.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled on every following retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between two attempts, zero is uncapped.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of a new client.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// delay returns the wait before the retry after the attempt, with a jitter of up to half of it
+// so that the clients do not retry in step. A Retry-After of the response is waited out when it is longer.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	d := p.BaseDelay
+	for range attempt {
+		// A shift would overflow to zero or a negative delay after enough attempts.
+		if d > math.MaxInt64/2 {
+			d = math.MaxInt64
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d > 0 {
+		d = d/2 + rand.N(d/2+1)
+	}
+
+	if resp != nil {
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err == nil && time.Duration(seconds)*time.Second > d {
+			d = time.Duration(seconds) * time.Second
+		}
+	}
+	return d
+}
+
+// retryable reports whether an attempt that ended with the response or the error may succeed when sent again.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		attempt    int
+		retryAfter string
+		min        time.Duration
+		max        time.Duration
+	}{
+		{
+			name:    "first_retry",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			attempt: 0,
+			min:     50 * time.Millisecond,
+			max:     100 * time.Millisecond,
+		},
+		{
+			name:    "doubled",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			attempt: 2,
+			min:     200 * time.Millisecond,
+			max:     400 * time.Millisecond,
+		},
+		{
+			name:    "capped",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+			attempt: 10,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+		},
+		{
+			name:    "capped_beyond_the_overflow",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+			attempt: 100,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+		},
+		{
+			name:    "uncapped_beyond_the_overflow",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			attempt: 100,
+			min:     math.MaxInt64 / 2,
+			max:     math.MaxInt64,
+		},
+		{
+			name:       "retry_after_longer",
+			policy:     RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			retryAfter: "2",
+			min:        2 * time.Second,
+			max:        2 * time.Second,
+		},
+		{
+			name:       "retry_after_shorter",
+			policy:     RetryPolicy{BaseDelay: 4 * time.Second},
+			retryAfter: "1",
+			min:        2 * time.Second,
+			max:        4 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.retryAfter != "" {
+				resp = &http.Response{Header: http.Header{"Retry-After": {tt.retryAfter}}}
+			}
+
+			for range 100 {
+				d := tt.policy.delay(tt.attempt, resp)
+				if d < tt.min || d > tt.max {
+					t.Fatalf("delay(%d) = %s, want between %s and %s", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
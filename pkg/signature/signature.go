@@ -0,0 +1,193 @@
+// Package signature signs and verifies HTTP requests with HMAC-SHA256.
+//
+// The signature covers the method, the path with the query, the timestamp, the nonce
+// and the SHA-256 hash of the body, each on its own line. It is sent hex encoded in
+// the X-Signature header next to the key ID, the timestamp and the nonce.
+package signature
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderKeyID names the key the request is signed with.
+	HeaderKeyID = "X-Key-ID"
+	// HeaderTimestamp is the time the request is signed at, in Unix seconds.
+	HeaderTimestamp = "X-Timestamp"
+	// HeaderNonce is unique for every signed request, a verifier accepts it only once.
+	HeaderNonce = "X-Nonce"
+	// HeaderSignature is the hex encoded HMAC-SHA256 of the request.
+	HeaderSignature = "X-Signature"
+)
+
+var (
+	// ErrInvalid is returned when the signature does not match the request,
+	// the other errors of the verification wrap it.
+	ErrInvalid = errors.New("invalid signature")
+	// ErrMissing is returned when a signature header is missing.
+	ErrMissing = fmt.Errorf("%w: missing signature header", ErrInvalid)
+	// ErrUnknownKey is returned when the verifier has no key with the key ID.
+	ErrUnknownKey = fmt.Errorf("%w: unknown key", ErrInvalid)
+	// ErrExpired is returned when the timestamp is further from now than the clock skew allows.
+	ErrExpired = fmt.Errorf("%w: timestamp outside the allowed clock skew", ErrInvalid)
+	// ErrReplayed is returned when the nonce was already used.
+	ErrReplayed = fmt.Errorf("%w: nonce already used", ErrInvalid)
+)
+
+// Signer signs requests with a secret key.
+type Signer struct {
+	keyID  string
+	secret []byte
+}
+
+func NewSigner(keyID, secret string) *Signer {
+	return &Signer{
+		keyID:  keyID,
+		secret: []byte(secret),
+	}
+}
+
+// Sign sets the signature headers of the request. The body is read through req.GetBody when it is set,
+// otherwise it is read and put back.
+func (s *Signer) Sign(req *http.Request) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+
+	b := make([]byte, 16)
+	_, err = rand.Read(b)
+	if err != nil {
+		return err
+	}
+	nonce := hex.EncodeToString(b)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set(HeaderKeyID, s.keyID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderNonce, nonce)
+	req.Header.Set(HeaderSignature, sign(s.secret, req.Method, req.URL.RequestURI(), timestamp, nonce, body))
+	return nil
+}
+
+// NonceStore remembers the nonces of the verified requests.
+type NonceStore interface {
+	// Use records the nonce of the key for the ttl, it reports false when the nonce is already recorded.
+	Use(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error)
+}
+
+// Verifier checks the signature of requests against a set of keys.
+type Verifier struct {
+	keys      map[string][]byte
+	clockSkew time.Duration
+	nonces    NonceStore
+}
+
+// NewVerifier returns a verifier of the secrets by their key ID. A request is accepted
+// while its timestamp is within the clock skew of now, and only once per nonce.
+func NewVerifier(keys map[string]string, clockSkew time.Duration, nonces NonceStore) *Verifier {
+	secrets := make(map[string][]byte, len(keys))
+	for keyID, secret := range keys {
+		secrets[keyID] = []byte(secret)
+	}
+	return &Verifier{
+		keys:      secrets,
+		clockSkew: clockSkew,
+		nonces:    nonces,
+	}
+}
+
+// Verify returns an error wrapping ErrInvalid when the request is not signed by one of the keys,
+// other errors are failures to read the body or to record the nonce. The body is put back for the handler.
+func (v *Verifier) Verify(req *http.Request) error {
+	keyID := req.Header.Get(HeaderKeyID)
+	timestamp := req.Header.Get(HeaderTimestamp)
+	nonce := req.Header.Get(HeaderNonce)
+	signature := req.Header.Get(HeaderSignature)
+	if keyID == "" || timestamp == "" || nonce == "" || signature == "" {
+		return ErrMissing
+	}
+
+	secret, ok := v.keys[keyID]
+	if !ok {
+		return ErrUnknownKey
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrExpired
+	}
+	skew := time.Since(time.Unix(unix, 0))
+	if skew > v.clockSkew || skew < -v.clockSkew {
+		return ErrExpired
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+	expected := sign(secret, req.Method, req.URL.RequestURI(), timestamp, nonce, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return ErrInvalid
+	}
+
+	// The nonce is only recorded once the signature holds, so unsigned requests cannot fill the store.
+	// It is kept for both sides of the clock skew, a replay after that fails on the timestamp.
+	fresh, err := v.nonces.Use(req.Context(), keyID, nonce, 2*v.clockSkew)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the request parts with the secret.
+func sign(secret []byte, method, path, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{
+		method,
+		path,
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// readBody returns the body of the request and leaves it readable again.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
@@ -0,0 +1,104 @@
+package signature
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryNonces map[string]bool
+
+func (m memoryNonces) Use(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	if m[keyID+nonce] {
+		return false, nil
+	}
+	m[keyID+nonce] = true
+	return true, nil
+}
+
+func newSignedRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/callbacks/payment?ref=1", strings.NewReader(body))
+	err := NewSigner("partner", "secret").Sign(req)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return req
+}
+
+func newVerifier() *Verifier {
+	return NewVerifier(map[string]string{"partner": "secret"}, time.Minute, memoryNonces{})
+}
+
+func TestVerify(t *testing.T) {
+	req := newSignedRequest(t, `{"amount":1000}`)
+
+	err := newVerifier().Verify(req)
+	if err != nil {
+		t.Fatalf("Verify() error = %v, want nil", err)
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != `{"amount":1000}` {
+		t.Errorf("body after Verify() = %q, want the original body", body)
+	}
+}
+
+func TestVerify_TamperedBody(t *testing.T) {
+	req := newSignedRequest(t, `{"amount":1000}`)
+	req.Body = io.NopCloser(strings.NewReader(`{"amount":9000}`))
+
+	err := newVerifier().Verify(req)
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Verify() error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestVerify_UnknownKey(t *testing.T) {
+	req := newSignedRequest(t, "")
+
+	err := NewVerifier(map[string]string{"other": "secret"}, time.Minute, memoryNonces{}).Verify(req)
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Verify() error = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestVerify_ClockSkew(t *testing.T) {
+	req := newSignedRequest(t, "")
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(time.Now().Add(-2*time.Minute).Unix(), 10))
+
+	err := newVerifier().Verify(req)
+	if !errors.Is(err, ErrExpired) {
+		t.Errorf("Verify() error = %v, want ErrExpired", err)
+	}
+}
+
+func TestVerify_Replayed(t *testing.T) {
+	v := newVerifier()
+	req := newSignedRequest(t, `{"amount":1000}`)
+
+	err := v.Verify(req)
+	if err != nil {
+		t.Fatalf("first Verify() error = %v, want nil", err)
+	}
+	err = v.Verify(req)
+	if !errors.Is(err, ErrReplayed) {
+		t.Errorf("second Verify() error = %v, want ErrReplayed", err)
+	}
+}
+
+func TestVerify_Missing(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/callbacks/payment", nil)
+
+	err := newVerifier().Verify(req)
+	if !errors.Is(err, ErrMissing) {
+		t.Errorf("Verify() error = %v, want ErrMissing", err)
+	}
+}